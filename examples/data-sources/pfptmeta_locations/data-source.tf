@@ -0,0 +1,39 @@
+data "pfptmeta_locations" "nearest_to_branch" {
+  latitude  = 40.71
+  longitude = -74.01
+  limit     = 2
+  status    = "active"
+}
+
+data "pfptmeta_locations" "germany" {
+  country            = "DE"
+  limit              = 3
+  dedicated_ips_only = true
+}
+
+resource "pfptmeta_tunnel" "branch" {
+  name = "branch tunnel"
+  pops = data.pfptmeta_locations.nearest_to_branch.names
+  gre_config {
+    source_ips = ["198.51.100.15"]
+  }
+}
+
+resource "pfptmeta_user_settings" "germany" {
+  name         = "germany settings"
+  apply_on_org = true
+  proxy_pops   = data.pfptmeta_locations.germany.proxy_pops
+}
+
+resource "pfptmeta_aac_rule" "germany_only" {
+  name      = "germany only"
+  priority  = 556
+  action    = "allow"
+  app_ids   = ["app-abcd1234"]
+  sources   = ["usr-abcd1234"]
+  locations = data.pfptmeta_locations.germany.countries
+}
+
+output "nearest_locations" {
+  value = [for l in data.pfptmeta_locations.nearest_to_branch.locations : "${l.name} (${l.distance_km} km)"]
+}
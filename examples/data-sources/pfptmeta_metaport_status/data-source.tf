@@ -0,0 +1,11 @@
+data "pfptmeta_metaport_status" "metaport" {
+  metaport_id = "mp-123"
+}
+
+data "pfptmeta_metaport_status" "cluster" {
+  metaport_cluster_id = "mpc-123"
+}
+
+output "connected_metaports" {
+  value = [for m in data.pfptmeta_metaport_status.cluster.metaports : m.id if m.connection_state == "connected"]
+}
@@ -0,0 +1,17 @@
+data "pfptmeta_notification_preview" "soar" {
+  custom_payload = jsonencode({
+    title    = "{{ alert_name }}"
+    source   = "{{ source_type }}"
+    hits     = "{{ hits }}"
+    entity   = "{{ group_value }}"
+    incident = "{{ console_url }}"
+  })
+  alert = {
+    alert_name  = "Blocked malware download"
+    group_value = "user@example.com"
+  }
+}
+
+output "payload" {
+  value = jsondecode(data.pfptmeta_notification_preview.soar.payload)
+}
@@ -0,0 +1,15 @@
+data "pfptmeta_routing_analysis" "analysis" {
+  destination_ip = "10.20.30.40"
+}
+
+output "overlapping_subnets" {
+  value = [for o in data.pfptmeta_routing_analysis.analysis.overlaps : "${o.cidr} (${o.network_element_name}) contains ${o.overlapping_cidr} (${o.overlapping_network_element_name})"]
+}
+
+output "serving_metaports" {
+  value = data.pfptmeta_routing_analysis.analysis.metaports
+}
+
+output "ambiguous_route" {
+  value = data.pfptmeta_routing_analysis.analysis.ambiguous
+}
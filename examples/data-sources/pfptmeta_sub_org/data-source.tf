@@ -0,0 +1,15 @@
+data "pfptmeta_sub_org" "by_id" {
+  id = "org-123abc"
+}
+
+data "pfptmeta_sub_org" "by_shortname" {
+  shortname = "customer"
+}
+
+output "by_id" {
+  value = data.pfptmeta_sub_org.by_id
+}
+
+output "by_shortname" {
+  value = data.pfptmeta_sub_org.by_shortname
+}
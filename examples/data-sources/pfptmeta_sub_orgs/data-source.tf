@@ -0,0 +1,7 @@
+data "pfptmeta_sub_orgs" "all" {}
+
+resource "pfptmeta_group" "admins" {
+  for_each      = { for so in data.pfptmeta_sub_orgs.all.sub_orgs : so.shortname => so if so.enabled }
+  name          = "admins"
+  org_shortname = each.key
+}
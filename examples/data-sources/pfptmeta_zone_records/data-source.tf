@@ -0,0 +1,38 @@
+data "pfptmeta_zone_records" "corp" {
+  content      = file("${path.module}/corp.example.com.zone")
+  origin       = "corp.example.com"
+  record_types = ["A", "CNAME", "DNAME"]
+}
+
+data "pfptmeta_zone_records" "hosts" {
+  content = file("/etc/hosts")
+  format  = "hosts"
+  suffix  = "corp.example.com"
+}
+
+resource "pfptmeta_network_element" "mapped-subnet" {
+  name           = "mapped subnet name"
+  mapped_subnets = ["10.20.30.0/24"]
+}
+
+resource "pfptmeta_network_element_mapped_hosts" "hosts" {
+  network_element_id = pfptmeta_network_element.mapped-subnet.id
+  dynamic "mapped_hosts" {
+    for_each = data.pfptmeta_zone_records.corp.mapped_hosts
+    content {
+      name        = mapped_hosts.value.name
+      mapped_host = mapped_hosts.value.mapped_host
+    }
+  }
+}
+
+resource "pfptmeta_enterprise_dns" "enterprise_dns" {
+  name = "enterprise dns name"
+  dynamic "mapped_domains" {
+    for_each = data.pfptmeta_zone_records.corp.mapped_domains
+    content {
+      name          = mapped_domains.value.name
+      mapped_domain = mapped_domains.value.mapped_domain
+    }
+  }
+}
@@ -34,5 +34,30 @@ resource "pfptmeta_alert" "threshold_condition_alert" {
     op        = "greater"
     threshold = 0
   }
+  window  = 60
+  dry_run = true
+}
+
+output "current_device_onboardings" {
+  value = pfptmeta_alert.threshold_condition_alert.query_hits
+}
+resource "pfptmeta_alert" "structured_query_alert" {
+  name     = "api keys"
+  channels = [pfptmeta_notification_channel.channel.id]
+  query {
+    condition {
+      field  = "action"
+      values = ["CREATE", "DELETE"]
+    }
+    condition {
+      field  = "resource_type"
+      values = ["API Key"]
+    }
+  }
+  source_type = "api_audit"
+  threshold_condition {
+    op        = "greater"
+    threshold = 0
+  }
   window = 60
-}
\ No newline at end of file
+}
@@ -0,0 +1,23 @@
+resource "pfptmeta_notification_channel" "soc" {
+  name = "soc"
+  email_config {
+    recipients = ["soc@example.com"]
+  }
+}
+
+resource "pfptmeta_alert_pack" "identity_threats" {
+  name        = "identity_threats"
+  name_prefix = "[SOC] "
+  channels    = [pfptmeta_notification_channel.soc.id]
+  thresholds = {
+    brute_force = 20
+  }
+  windows = {
+    password_resets = 1440
+  }
+  disabled_alerts = ["impossible_travel"]
+}
+
+output "brute_force_alert_id" {
+  value = pfptmeta_alert_pack.identity_threats.alert_ids["brute_force"]
+}
@@ -1,3 +1,18 @@
+variable "opensearch_api_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "sentinel_shared_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "datadog_api_key" {
+  type      = string
+  sensitive = true
+}
+
 resource "pfptmeta_notification_channel" "mail" {
   name = "mail-channel"
   email_config {
@@ -27,18 +42,27 @@ resource "pfptmeta_log_streaming_access_bridge" "qradar_http_log_stream" {
   }
 }
 
+# The principal which writes the log objects is assigned when the log streamer is created,
+# so the log streamer is created disabled, and enabled in a second apply once the bucket policy is in place
 resource "pfptmeta_log_streaming_access_bridge" "s3_log_stream" {
   name                  = "S3 log stream"
   description           = "log stream description"
+  enabled               = false
   notification_channels = [pfptmeta_notification_channel.mail.id]
   export_logs           = ["api", "traffic", "security", "metaproxy", "webfilter"]
   s3_config {
     bucket   = "mybucket"
     compress = true
-    prefix   = "mybucketstream"
+    prefix   = "mybucketstream/{{ log_type }}/{{ year }}/{{ month }}/{{ day }}/"
   }
 }
 
+# Allow Proofpoint to write the log objects into the bucket
+resource "aws_s3_bucket_policy" "log_stream" {
+  bucket = "mybucket"
+  policy = pfptmeta_log_streaming_access_bridge.s3_log_stream.s3_bucket_policy
+}
+
 resource "pfptmeta_log_streaming_access_bridge" "splunk_http_log_stream" {
   name                  = "Splunk HTTP log stream"
   description           = "log stream description"
@@ -77,4 +101,36 @@ resource "pfptmeta_log_streaming_access_bridge" "syslog_log_stream" {
     port  = 518
     proto = "tcp"
   }
-}
\ No newline at end of file
+}
+resource "pfptmeta_log_streaming_access_bridge" "elastic_log_stream" {
+  name                  = "OpenSearch log stream"
+  notification_channels = [pfptmeta_notification_channel.mail.id]
+  export_logs           = ["traffic", "security"]
+  elastic_config {
+    distribution = "opensearch"
+    url          = "https://opensearch.example.com:9200"
+    index        = "meta-logs"
+    api_key      = var.opensearch_api_key
+  }
+}
+
+resource "pfptmeta_log_streaming_access_bridge" "sentinel_log_stream" {
+  name                  = "Azure Sentinel log stream"
+  notification_channels = [pfptmeta_notification_channel.mail.id]
+  export_logs           = ["api", "security"]
+  azure_sentinel_config {
+    workspace_id = "0b7c3f6e-6d1a-4a7e-9f51-2d5c8e4b1a90"
+    shared_key   = var.sentinel_shared_key
+  }
+}
+
+resource "pfptmeta_log_streaming_access_bridge" "datadog_log_stream" {
+  name                  = "Datadog log stream"
+  notification_channels = [pfptmeta_notification_channel.mail.id]
+  export_logs           = ["metaproxy", "webfilter"]
+  datadog_config {
+    api_key = var.datadog_api_key
+    site    = "datadoghq.eu"
+    tags    = ["env:prod"]
+  }
+}
@@ -0,0 +1,42 @@
+resource "pfptmeta_notification_channel" "channel" {
+  name = "mail-channel"
+  email_config {
+    recipients = ["user1@example.com", "user2@example.com"]
+  }
+}
+
+# Suppress the alert during the weekly change window
+resource "pfptmeta_time_frame" "change_window" {
+  name = "weekly change window"
+  days = ["sunday"]
+  start_time {
+    hour   = 2
+    minute = 0
+  }
+  end_time {
+    hour   = 4
+    minute = 0
+  }
+}
+
+resource "pfptmeta_alert" "metaport_disconnected" {
+  name                  = "metaport disconnected"
+  channels              = [pfptmeta_notification_channel.channel.id]
+  suppression_schedules = [pfptmeta_time_frame.change_window.id]
+  query_text            = "event:keepalive AND src_type:MetaPort"
+  source_type           = "traffic_audit"
+  threshold_condition {
+    op        = "greater"
+    threshold = 0
+  }
+  window = 5
+}
+
+# Disable the alert during a one-off upgrade, it is re-enabled when the window expires
+resource "pfptmeta_maintenance_window" "metaport_upgrade" {
+  name        = "metaport upgrade"
+  description = "upgrade of the data center metaports"
+  alerts      = [pfptmeta_alert.metaport_disconnected.id]
+  start_time  = "2022-11-05T22:00:00Z"
+  end_time    = "2022-11-06T02:00:00Z"
+}
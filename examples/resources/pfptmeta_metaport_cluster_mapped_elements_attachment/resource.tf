@@ -22,4 +22,9 @@ resource "pfptmeta_metaport_cluster" "metaport_cluster" {
 resource "pfptmeta_metaport_cluster_mapped_elements_attachment" "attachment" {
   metaport_cluster_id = pfptmeta_metaport_cluster.metaport_cluster.id
   mapped_elements     = [pfptmeta_network_element.mapped-subnet.id]
+  # Shifts the traffic onto the cluster only once one of its MetaPorts is connected
+  wait_until_connected = true
+  timeouts {
+    create = "20m"
+  }
 }
\ No newline at end of file
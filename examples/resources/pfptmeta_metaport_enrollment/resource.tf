@@ -0,0 +1,39 @@
+resource "pfptmeta_metaport" "metaport" {
+  name = "metaport name"
+}
+
+data "aws_ami" "ubuntu" {
+  most_recent = true
+  owners      = ["099720109477"]
+  filter {
+    name   = "name"
+    values = ["ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-amd64-server-*"]
+  }
+}
+
+# Deploys a MetaPort instance on AWS, a new activation code is generated whenever the AMI changes.
+resource "pfptmeta_metaport_enrollment" "aws" {
+  metaport_id = pfptmeta_metaport.metaport.id
+  triggers = {
+    ami = data.aws_ami.ubuntu.id
+  }
+}
+
+resource "aws_instance" "metaport" {
+  ami           = data.aws_ami.ubuntu.id
+  instance_type = "t3.medium"
+  user_data     = pfptmeta_metaport_enrollment.aws.cloud_init
+}
+
+# Deploys a MetaPort instance in a Kubernetes cluster.
+resource "pfptmeta_metaport_enrollment" "kubernetes" {
+  metaport_id          = pfptmeta_metaport.metaport.id
+  name                 = "metaport"
+  kubernetes_namespace = "network"
+}
+
+# The manifest holds a secret and a deployment.
+resource "kubectl_manifest" "metaport" {
+  count     = 2
+  yaml_body = split("\n---\n", pfptmeta_metaport_enrollment.kubernetes.kubernetes_manifest)[count.index]
+}
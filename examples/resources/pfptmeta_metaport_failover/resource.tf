@@ -11,14 +11,17 @@ resource "pfptmeta_metaport" "metaport2" {
   name = "metaport2"
 }
 
+# The mapped elements of the failover should be mapped to both clusters
 resource "pfptmeta_metaport_cluster" "metaport_cluster1" {
-  name      = "metaport cluster1"
-  metaports = [pfptmeta_metaport.metaport1.id]
+  name            = "metaport cluster1"
+  metaports       = [pfptmeta_metaport.metaport1.id]
+  mapped_elements = [pfptmeta_network_element.mapped-subnet.id]
 }
 
 resource "pfptmeta_metaport_cluster" "metaport_cluster2" {
-  name      = "metaport cluster2"
-  metaports = [pfptmeta_metaport.metaport2.id]
+  name            = "metaport cluster2"
+  metaports       = [pfptmeta_metaport.metaport2.id]
+  mapped_elements = [pfptmeta_network_element.mapped-subnet.id]
 }
 
 resource "pfptmeta_notification_channel" "mail" {
@@ -33,8 +36,8 @@ resource "pfptmeta_metaport_failover" "failover" {
   name                  = "metaport failover name"
   description           = "metaport failover description"
   mapped_elements       = [pfptmeta_network_element.mapped-subnet.id]
-  cluster_1             = pfptmeta_metaport_cluster.metaport_cluster1
-  cluster_2             = pfptmeta_metaport_cluster.metaport_cluster2
+  cluster_1             = pfptmeta_metaport_cluster.metaport_cluster1.id
+  cluster_2             = pfptmeta_metaport_cluster.metaport_cluster2.id
   notification_channels = [pfptmeta_notification_channel.mail.id]
   failback {
     trigger = "auto"
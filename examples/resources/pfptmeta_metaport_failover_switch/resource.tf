@@ -0,0 +1,14 @@
+variable "dr_drill" {
+  type    = bool
+  default = false
+}
+
+# Activates the secondary cluster while dr_drill is set, destroying the switch activates the primary cluster again.
+resource "pfptmeta_metaport_failover_switch" "drill" {
+  count                = var.dr_drill ? 1 : 0
+  metaport_failover_id = pfptmeta_metaport_failover.failover.id
+  active_cluster       = pfptmeta_metaport_failover.failover.cluster_2
+  timeouts {
+    create = "15m"
+  }
+}
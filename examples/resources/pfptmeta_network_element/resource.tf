@@ -8,6 +8,12 @@ resource "pfptmeta_network_element" "mapped-subnet" {
   }
 }
 
+resource "pfptmeta_network_element" "office" {
+  name                        = "office"
+  mapped_subnets              = ["192.168.10.0/24"]
+  prevent_overlapping_subnets = true
+}
+
 resource "pfptmeta_network_element" "mapped-service" {
   name           = "mapped service name"
   description    = "some details about the mapped service"
@@ -0,0 +1,16 @@
+resource "pfptmeta_network_element" "mapped-subnet" {
+  name           = "mapped subnet name"
+  mapped_subnets = ["10.20.30.0/24"]
+}
+
+resource "pfptmeta_network_element_mapped_domains" "domains" {
+  network_element_id = pfptmeta_network_element.mapped-subnet.id
+  mapped_domains {
+    name          = "corp.example.com"
+    mapped_domain = "corp.internal"
+  }
+  mapped_domains {
+    name          = "lab.example.com"
+    mapped_domain = "lab.internal"
+  }
+}
@@ -0,0 +1,23 @@
+resource "pfptmeta_network_element" "mapped-subnet" {
+  name           = "mapped subnet name"
+  mapped_subnets = ["10.20.30.0/24"]
+}
+
+locals {
+  hosts = {
+    "db.example.com"   = "10.20.30.10"
+    "web.example.com"  = "10.20.30.20"
+    "mail.example.com" = "mail.internal"
+  }
+}
+
+resource "pfptmeta_network_element_mapped_hosts" "hosts" {
+  network_element_id = pfptmeta_network_element.mapped-subnet.id
+  dynamic "mapped_hosts" {
+    for_each = local.hosts
+    content {
+      name        = mapped_hosts.key
+      mapped_host = mapped_hosts.value
+    }
+  }
+}
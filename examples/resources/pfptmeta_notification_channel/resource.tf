@@ -14,6 +14,16 @@ resource "pfptmeta_notification_channel" "pagerduty" {
   }
 }
 
+# The API key is read from a file managed by a secret manager, rotating it updates the channel.
+# Only hashes of secrets are stored in the state, change secret_version to send them again.
+resource "pfptmeta_notification_channel" "pagerduty_from_file" {
+  name           = "pagerduty-channel-from-file"
+  secret_version = "1"
+  pagerduty_config {
+    api_key_file = "/run/secrets/pagerduty_api_key"
+  }
+}
+
 resource "pfptmeta_notification_channel" "slack" {
   name        = "slack-channel"
   description = "slack channel description"
@@ -34,9 +44,50 @@ resource "pfptmeta_notification_channel" "webhook" {
         token_url     = "https://token.url.com/test"
       }
     }
-    custom_payload = "{\"key1\": \"value1\"}"
-    headers        = ["Header1Name:Header1Value", "Header2Name:Header2Value"]
-    method         = "POST"
-    url            = "https://hooks.com/test"
+    custom_payload = "{\"key1\": \"value1\", \"alert\": \"{{ alert_name }}\", \"hits\": {{ hits }}}"
+    headers = {
+      Header1Name = "Header1Value"
+      Header2Name = "Header2Value"
+    }
+    method = "POST"
+    url    = "https://hooks.com/test"
+  }
+}
+
+resource "pfptmeta_notification_channel" "syslog" {
+  name                = "syslog-channel"
+  send_test_on_change = true
+  syslog_config {
+    host  = "syslog.example.com"
+    port  = 514
+    proto = "udp"
+  }
+}
+
+resource "pfptmeta_notification_channel" "teams" {
+  name = "teams-channel"
+  teams_config {
+    url = "https://example.webhook.office.com/webhookb2/test"
+  }
+}
+
+resource "pfptmeta_notification_channel" "opsgenie" {
+  name = "opsgenie-channel"
+  opsgenie_config {
+    api_key  = "api-key"
+    region   = "EU"
+    priority = "P2"
+    team     = "soc"
   }
-}
\ No newline at end of file
+}
+
+resource "pfptmeta_notification_channel" "servicenow" {
+  name = "servicenow-channel"
+  servicenow_config {
+    instance_url     = "https://example.service-now.com"
+    username         = "meta"
+    password         = "password"
+    urgency          = 1
+    assignment_group = "SOC"
+  }
+}
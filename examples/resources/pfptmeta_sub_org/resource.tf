@@ -0,0 +1,10 @@
+resource "pfptmeta_sub_org" "customer" {
+  name        = "Customer"
+  shortname   = "customer"
+  description = "customer managed by the MSP org"
+}
+
+resource "pfptmeta_group" "customer_admins" {
+  name          = "admins"
+  org_shortname = pfptmeta_sub_org.customer.shortname
+}
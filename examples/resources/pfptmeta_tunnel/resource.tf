@@ -1,7 +1,32 @@
+variable "tunnel_psk" {
+  type      = string
+  sensitive = true
+}
+
 resource "pfptmeta_tunnel" "tunnel1" {
   name        = "tunnel name1"
   description = "some details about the tunnel"
   gre_config {
-    source_ups = ["198.51.100.15"]
+    source_ips = ["198.51.100.15"]
+  }
+}
+
+resource "pfptmeta_tunnel" "ipsec" {
+  name = "ipsec tunnel"
+  ipsec_config {
+    source_ips    = ["198.51.100.20"]
+    ike_version   = 2
+    psk           = var.tunnel_psk
+    ike_proposals = ["aes256-sha384-ecp384", "aes256-sha256-modp2048"]
+    esp_proposals = ["aes256-sha256-ecp384"]
   }
 }
+
+output "gre_endpoints" {
+  value = { for e in pfptmeta_tunnel.tunnel1.endpoints : e.pop => e.ip }
+}
+
+output "ipsec_router_config" {
+  value     = pfptmeta_tunnel.ipsec.router_config[0].cisco_ios
+  sensitive = true
+}
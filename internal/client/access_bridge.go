@@ -43,9 +43,10 @@ func newQradarHttpConfig(conf []interface{}) *QradarHttpConfig {
 }
 
 type S3Config struct {
-	Bucket   string `json:"bucket"`
-	Compress bool   `json:"compress"`
-	Prefix   string `json:"prefix,omitempty"`
+	Bucket       string `json:"bucket"`
+	Compress     bool   `json:"compress"`
+	Prefix       string `json:"prefix,omitempty"`
+	PrincipalArn string `json:"principal_arn,omitempty"`
 }
 
 func newS3Config(conf []interface{}) *S3Config {
@@ -98,14 +99,121 @@ func newSyslogConfig(conf []interface{}) *SyslogConfig {
 	return res
 }
 
+type ElasticConfig struct {
+	ApiKey       string `json:"api_key,omitempty"`
+	Certificate  string `json:"certificate,omitempty"`
+	Distribution string `json:"distribution"`
+	Index        string `json:"index"`
+	Password     string `json:"password,omitempty"`
+	Url          string `json:"url"`
+	Username     string `json:"username,omitempty"`
+}
+
+func newElasticConfig(conf []interface{}) *ElasticConfig {
+	res := &ElasticConfig{}
+	if len(conf) == 0 {
+		return nil
+	}
+	confMap := conf[0].(map[string]interface{})
+	res.ApiKey = confMap["api_key"].(string)
+	res.Certificate = confMap["certificate"].(string)
+	res.Distribution = confMap["distribution"].(string)
+	res.Index = confMap["index"].(string)
+	res.Password = confMap["password"].(string)
+	res.Url = confMap["url"].(string)
+	res.Username = confMap["username"].(string)
+	return res
+}
+
+type AzureSentinelConfig struct {
+	LogType     string `json:"log_type"`
+	SharedKey   string `json:"shared_key"`
+	WorkspaceId string `json:"workspace_id"`
+}
+
+func newAzureSentinelConfig(conf []interface{}) *AzureSentinelConfig {
+	res := &AzureSentinelConfig{}
+	if len(conf) == 0 {
+		return nil
+	}
+	confMap := conf[0].(map[string]interface{})
+	res.LogType = confMap["log_type"].(string)
+	res.SharedKey = confMap["shared_key"].(string)
+	res.WorkspaceId = confMap["workspace_id"].(string)
+	return res
+}
+
+type GoogleChronicleConfig struct {
+	Credentials string `json:"credentials"`
+	CustomerId  string `json:"customer_id"`
+	LogType     string `json:"log_type"`
+	Region      string `json:"region"`
+}
+
+func newGoogleChronicleConfig(conf []interface{}) *GoogleChronicleConfig {
+	res := &GoogleChronicleConfig{}
+	if len(conf) == 0 {
+		return nil
+	}
+	confMap := conf[0].(map[string]interface{})
+	res.Credentials = confMap["credentials"].(string)
+	res.CustomerId = confMap["customer_id"].(string)
+	res.LogType = confMap["log_type"].(string)
+	res.Region = confMap["region"].(string)
+	return res
+}
+
+type SumoLogicConfig struct {
+	Url string `json:"url"`
+}
+
+func newSumoLogicConfig(conf []interface{}) *SumoLogicConfig {
+	res := &SumoLogicConfig{}
+	if len(conf) == 0 {
+		return nil
+	}
+	confMap := conf[0].(map[string]interface{})
+	res.Url = confMap["url"].(string)
+	return res
+}
+
+type DatadogConfig struct {
+	ApiKey  string   `json:"api_key"`
+	Service string   `json:"service,omitempty"`
+	Site    string   `json:"site"`
+	Tags    []string `json:"tags"`
+}
+
+func newDatadogConfig(conf []interface{}) *DatadogConfig {
+	res := &DatadogConfig{}
+	if len(conf) == 0 {
+		return nil
+	}
+	confMap := conf[0].(map[string]interface{})
+	res.ApiKey = confMap["api_key"].(string)
+	res.Service = confMap["service"].(string)
+	res.Site = confMap["site"].(string)
+	tags := confMap["tags"].([]interface{})
+	res.Tags = make([]string, len(tags))
+	for i, tag := range tags {
+		res.Tags[i] = tag.(string)
+	}
+	return res
+}
+
 type SiemConfig struct {
-	Type                 string                `json:"type,omitempty"`
-	ExportLogs           []string              `json:"export_logs"`
-	ProofpointCasbConfig *ProofpointCasbConfig `json:"proofpoint_casb_config,omitempty"`
-	QradarHttpConfig     *QradarHttpConfig     `json:"qradar_http_config,omitempty"`
-	S3Config             *S3Config             `json:"s3_config,omitempty"`
-	SplunkHttpConfig     *SplunkHttpConfig     `json:"splunk_http_config,omitempty"`
-	SyslogConfig         *SyslogConfig         `json:"syslog_config,omitempty"`
+	Type                  string                 `json:"type,omitempty"`
+	ExportLogs            []string               `json:"export_logs"`
+	ProofpointCasbConfig  *ProofpointCasbConfig  `json:"proofpoint_casb_config,omitempty"`
+	QradarHttpConfig      *QradarHttpConfig      `json:"qradar_http_config,omitempty"`
+	S3Config              *S3Config              `json:"s3_config,omitempty"`
+	SplunkHttpConfig      *SplunkHttpConfig      `json:"splunk_http_config,omitempty"`
+	SyslogConfig          *SyslogConfig          `json:"syslog_config,omitempty"`
+	ElasticConfig         *ElasticConfig         `json:"elastic_config,omitempty"`
+	AzureSentinelConfig   *AzureSentinelConfig   `json:"azure_sentinel_config,omitempty"`
+	GoogleChronicleConfig *GoogleChronicleConfig `json:"google_chronicle_config,omitempty"`
+	SumoLogicConfig       *SumoLogicConfig       `json:"sumo_logic_config,omitempty"`
+	DatadogConfig         *DatadogConfig         `json:"datadog_config,omitempty"`
 }
 
 func newSiemConfig(d *schema.ResourceData) *SiemConfig {
@@ -116,6 +224,11 @@ func newSiemConfig(d *schema.ResourceData) *SiemConfig {
 	res.S3Config = newS3Config(d.Get("s3_config").([]interface{}))
 	res.SplunkHttpConfig = newSplunkHttpConfig(d.Get("splunk_http_config").([]interface{}))
 	res.SyslogConfig = newSyslogConfig(d.Get("syslog_config").([]interface{}))
+	res.ElasticConfig = newElasticConfig(d.Get("elastic_config").([]interface{}))
+	res.AzureSentinelConfig = newAzureSentinelConfig(d.Get("azure_sentinel_config").([]interface{}))
+	res.GoogleChronicleConfig = newGoogleChronicleConfig(d.Get("google_chronicle_config").([]interface{}))
+	res.SumoLogicConfig = newSumoLogicConfig(d.Get("sumo_logic_config").([]interface{}))
+	res.DatadogConfig = newDatadogConfig(d.Get("datadog_config").([]interface{}))
 	return res
 }
 
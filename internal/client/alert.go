@@ -48,19 +48,20 @@ func newThresholdCondition(d *schema.ResourceData) *ThresholdCondition {
 }
 
 type Alert struct {
-	ID                 string              `json:"id,omitempty"`
-	Name               string              `json:"name,omitempty"`
-	Description        string              `json:"description"`
-	Channels           []string            `json:"channels"`
-	Enabled            bool                `json:"enabled"`
-	GroupBy            *string             `json:"group_by"`
-	NotifyMessage      string              `json:"notify_message"`
-	QueryText          string              `json:"query_text"`
-	SourceType         string              `json:"source_type"`
-	SpikeCondition     *SpikeCondition     `json:"spike_condition,omitempty"`
-	ThresholdCondition *ThresholdCondition `json:"threshold_condition,omitempty"`
-	Type               string              `json:"type,omitempty"`
-	Window             int                 `json:"window"`
+	ID                   string              `json:"id,omitempty"`
+	Name                 string              `json:"name,omitempty"`
+	Description          string              `json:"description"`
+	Channels             []string            `json:"channels"`
+	Enabled              bool                `json:"enabled"`
+	GroupBy              *string             `json:"group_by"`
+	NotifyMessage        string              `json:"notify_message"`
+	QueryText            string              `json:"query_text"`
+	SourceType           string              `json:"source_type"`
+	SpikeCondition       *SpikeCondition     `json:"spike_condition,omitempty"`
+	SuppressionSchedules []string            `json:"suppression_schedules"`
+	ThresholdCondition   *ThresholdCondition `json:"threshold_condition,omitempty"`
+	Type                 string              `json:"type,omitempty"`
+	Window               int                 `json:"window"`
 }
 
 func NewAlert(d *schema.ResourceData) *Alert {
@@ -81,6 +82,7 @@ func NewAlert(d *schema.ResourceData) *Alert {
 	res.QueryText = d.Get("query_text").(string)
 	res.SourceType = d.Get("source_type").(string)
 	res.SpikeCondition = newSpikeCondition(d)
+	res.SuppressionSchedules = ConfigToStringSlice("suppression_schedules", d)
 	res.ThresholdCondition = newThresholdCondition(d)
 	res.Window = d.Get("window").(int)
 	return res
@@ -138,3 +140,34 @@ func DeleteAlert(ctx context.Context, c *Client, aID string) (*Alert, error) {
 	}
 	return parseAlert(resp)
 }
+
+// AlertQuery is a query of the logs matching an alert's query_text, without creating the alert.
+type AlertQuery struct {
+	GroupBy    *string `json:"group_by"`
+	QueryText  string  `json:"query_text"`
+	SourceType string  `json:"source_type"`
+	Window     int     `json:"window"`
+}
+
+type AlertQueryResult struct {
+	Hits int `json:"hits"`
+}
+
+// DryRunAlertQuery returns the number of logs in the query's window matching it.
+func DryRunAlertQuery(ctx context.Context, c *Client, q *AlertQuery) (*AlertQueryResult, error) {
+	url := fmt.Sprintf("%s/%s/dry_run", c.BaseURL, alertEndpoint)
+	body, err := json.Marshal(q)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert alert query to json: %v", err)
+	}
+	resp, err := c.Post(ctx, url, body)
+	if err != nil {
+		return nil, err
+	}
+	res := &AlertQueryResult{}
+	err = json.Unmarshal(resp, res)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse alert query response: %v", err)
+	}
+	return res, nil
+}
@@ -10,8 +10,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"os/user"
-	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -25,17 +25,17 @@ const (
 	oauthURL                  string = "/v1/oauth/token"
 	maxIdleConnections        int    = 10
 	requestTimeout            int    = 60
-	configPath                string = ".pfptmeta/credentials.json"
 	grantType                 string = "client_credentials"
 	eventuallyConsistentSleep        = time.Millisecond * 300
 )
 
-type Config struct {
-	APIKey       string `json:"api_key"`
-	APISecret    string `json:"api_secret"`
-	OrgShortname string `json:"org_shortname"`
-	Realm        string `json:"realm"`
-}
+const (
+	// Pre-issued tokens are reloaded at this interval, so rotated token files are picked up.
+	staticTokenLifetime int64 = 300
+	// Tokens are refreshed once less than a fifth of their lifetime, capped at maxTokenRefreshMargin seconds, is left.
+	// This leaves room for clock skew and for long-running requests started just before the token expires.
+	maxTokenRefreshMargin int64 = 60
+)
 
 type Token struct {
 	Token     string `json:"access_token"`
@@ -49,6 +49,9 @@ type Credentials struct {
 	ClientID     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
 	Realm        string `json:"-"`
+	// AccessToken and AccessTokenFile hold a pre-issued bearer token, in which case no token is requested from the API.
+	AccessToken     string `json:"-"`
+	AccessTokenFile string `json:"-"`
 }
 
 type ErrorResponse struct {
@@ -58,6 +61,8 @@ type ErrorResponse struct {
 	Status int    `json:"status"`
 	Title  string `json:"title"`
 	Type   string `json:"type"`
+	// FieldErrors are the validation errors of specific fields in the request body, if the API reported any.
+	FieldErrors []FieldError `json:"-"`
 }
 
 func (err *ErrorResponse) Error() string {
@@ -65,55 +70,6 @@ func (err *ErrorResponse) Error() string {
 		err.Method, err.URL, err.Status, err.Title, err.Detail)
 }
 
-func newCredentials(d *schema.ResourceData) (*Credentials, error) {
-	credentials := &Credentials{GrantType: grantType}
-	apiKey, haveAPIKey := d.GetOk("api_key")
-	apiSecret, haveAPISecret := d.GetOk("api_secret")
-	org, haveOrg := d.GetOk("org_shortname")
-	realm, haveRealm := d.GetOk("realm")
-	// If one is set
-	if haveAPIKey || haveAPISecret || haveOrg {
-		// but not all are set
-		if !(haveAPIKey && haveAPISecret && haveOrg) {
-			return nil, errors.New("please provide an api_key, api_secret and org shortname")
-		}
-		credentials.ClientID = apiKey.(string)
-		credentials.ClientSecret = apiSecret.(string)
-		credentials.Scope = fmt.Sprintf("org:%s", org)
-		if haveRealm {
-			credentials.Realm = realm.(string)
-		}
-		return credentials, nil
-	}
-	return parseCredentialsFile(credentials)
-}
-
-func parseCredentialsFile(credentials *Credentials) (*Credentials, error) {
-	usr, err := user.Current()
-	if err != nil {
-		return nil, fmt.Errorf("could not find current user's name: %v", err)
-	}
-	path := filepath.Join(usr.HomeDir, configPath)
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("could not open credentials file %s: %v", path, err)
-	}
-	configBytes, err := ioutil.ReadAll(file)
-	if err != nil {
-		return nil, fmt.Errorf("could not read credentials file %s: %v", path, err)
-	}
-	var config Config
-	err = json.Unmarshal(configBytes, &config)
-	if err != nil {
-		return nil, fmt.Errorf("could not parse credentials file %s: %v", path, err)
-	}
-	credentials.ClientID = config.APIKey
-	credentials.ClientSecret = config.APISecret
-	credentials.Scope = fmt.Sprintf("org:%s", config.OrgShortname)
-	credentials.Realm = config.Realm
-	return credentials, nil
-}
-
 type Client struct {
 	Credentials       *Credentials
 	Token             *Token
@@ -121,6 +77,60 @@ type Client struct {
 	BaseURL           string
 	TokenCreationTime int64
 	UserAgent         string
+	Tracer            *Tracer
+
+	orgClients     map[string]*Client
+	orgClientsLock sync.Mutex
+	// tokenLock makes sure only one token request is in flight when terraform executes requests in parallel.
+	tokenLock sync.Mutex
+}
+
+type skipConsistencySleepKey struct{}
+
+// WithoutConsistencySleep returns a context whose writes don't sleep until the API is consistent,
+// for callers which send many writes in a row and call WaitUntilConsistent once after the last one.
+func WithoutConsistencySleep(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipConsistencySleepKey{}, true)
+}
+
+// WaitUntilConsistent sleeps until the writes sent before are consistent in all the API's database instances.
+func WaitUntilConsistent() {
+	time.Sleep(eventuallyConsistentSleep)
+}
+
+// ForOrg returns a client that operates on the org with the given shortname.
+// The returned client authenticates with c's credentials, so the API key must have access to that org,
+// i.e. it should belong to a parent org of an MSP hierarchy.
+// Clients are cached per org so each org's token is requested only once.
+// A pre-issued access token is already scoped to the provider's org, so it can't be used for other orgs.
+func (c *Client) ForOrg(orgShortname string) (*Client, error) {
+	scope := orgScope(orgShortname)
+	if orgShortname == "" || c.Credentials.Scope == scope {
+		return c, nil
+	}
+	if c.Credentials.AccessToken != "" || c.Credentials.AccessTokenFile != "" {
+		return nil, fmt.Errorf("cannot operate on org %s with a pre-issued access token, "+
+			"configure an api_key and api_secret or a credential_process which returns them instead", orgShortname)
+	}
+	c.orgClientsLock.Lock()
+	defer c.orgClientsLock.Unlock()
+	if orgClient, ok := c.orgClients[orgShortname]; ok {
+		return orgClient, nil
+	}
+	credentials := *c.Credentials
+	credentials.Scope = scope
+	orgClient := &Client{
+		Credentials: &credentials,
+		HTTP:        c.HTTP,
+		BaseURL:     c.BaseURL,
+		UserAgent:   c.UserAgent,
+		Tracer:      c.Tracer,
+	}
+	if c.orgClients == nil {
+		c.orgClients = make(map[string]*Client)
+	}
+	c.orgClients[orgShortname] = orgClient
+	return orgClient, nil
 }
 
 func parseHttpError(resp *http.Response) error {
@@ -136,6 +146,7 @@ func parseHttpError(resp *http.Response) error {
 	}
 	errorResponse.URL = resp.Request.URL.String()
 	errorResponse.Method = resp.Request.Method
+	errorResponse.FieldErrors = parseFieldErrors(body, errorResponse.Detail)
 	return errorResponse
 }
 
@@ -160,7 +171,7 @@ func errorHandler(resp *http.Response, err error, _ int) (*http.Response, error)
 	return resp, err
 }
 
-func NewClient(ctx context.Context, d *schema.ResourceData, userAgent string) (*Client, error) {
+func NewClient(ctx context.Context, d *schema.ResourceData, userAgent, version string) (*Client, error) {
 	client := &Client{
 		HTTP:      retryablehttp.NewClient(),
 		UserAgent: userAgent,
@@ -171,8 +182,14 @@ func NewClient(ctx context.Context, d *schema.ResourceData, userAgent string) (*
 	}
 	client.HTTP.CheckRetry = RetryPolicy
 	client.HTTP.ErrorHandler = errorHandler
+	client.HTTP.RequestLogHook = countAttempts
+	client.Tracer = &Tracer{
+		LogBodies: d.Get("log_request_bodies").(bool),
+		HARPath:   d.Get("har_file").(string),
+		Version:   version,
+	}
 
-	credentials, err := newCredentials(d)
+	credentials, err := newCredentials(ctx, d)
 	if err != nil {
 		return nil, fmt.Errorf("could not find credentials: %v", err)
 	}
@@ -198,6 +215,9 @@ func getBaseURL(credentials *Credentials) string {
 }
 
 func (c *Client) tokenRequest(ctx context.Context) error {
+	if c.Credentials.AccessToken != "" || c.Credentials.AccessTokenFile != "" {
+		return c.loadStaticToken()
+	}
 	jsonData, err := json.Marshal(c.Credentials)
 	if err != nil {
 		return fmt.Errorf("could not convert credentials to json: %v", err)
@@ -212,7 +232,7 @@ func (c *Client) tokenRequest(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("error while trying to create access token retryable request: %v", err)
 	}
-	resp, err := c.HTTP.Do(retryableRequest)
+	resp, err := c.do(retryableRequest)
 	if err != nil {
 		return fmt.Errorf("error while trying to create access token: %v", err)
 	}
@@ -234,15 +254,75 @@ func (c *Client) tokenRequest(ctx context.Context) error {
 	return nil
 }
 
-func (c *Client) SendRequest(r *http.Request) ([]byte, error) {
-	now := time.Now().Unix()
-	if c.Token == nil || c.TokenCreationTime+c.Token.Expiry-now < 30 {
-		err := c.tokenRequest(r.Context())
+func (c *Client) loadStaticToken() error {
+	token := c.Credentials.AccessToken
+	if c.Credentials.AccessTokenFile != "" {
+		tokenBytes, err := ioutil.ReadFile(c.Credentials.AccessTokenFile)
+		if err != nil {
+			return fmt.Errorf("could not read access token file %s: %v", c.Credentials.AccessTokenFile, err)
+		}
+		token = strings.TrimSpace(string(tokenBytes))
+	}
+	if token == "" {
+		return errors.New("access token is empty")
+	}
+	c.Token = &Token{Token: token, Expiry: staticTokenLifetime, TokenType: "Bearer"}
+	c.TokenCreationTime = time.Now().Unix()
+	return nil
+}
+
+func (c *Client) tokenExpired() bool {
+	if c.Token == nil {
+		return true
+	}
+	margin := c.Token.Expiry / 5
+	if margin > maxTokenRefreshMargin {
+		margin = maxTokenRefreshMargin
+	}
+	return c.TokenCreationTime+c.Token.Expiry-time.Now().Unix() < margin
+}
+
+// validToken returns the current token, refreshing it first if it is about to expire or if it is the rejected token.
+// Concurrent callers wait for a single token request instead of each requesting a token of their own.
+func (c *Client) validToken(ctx context.Context, rejected *Token) (*Token, error) {
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+	if c.tokenExpired() || (rejected != nil && c.Token == rejected) {
+		err := c.tokenRequest(ctx)
 		if err != nil {
 			return nil, err
 		}
 	}
-	r.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.Token.Token))
+	return c.Token, nil
+}
+
+// do executes the request and traces it.
+// The response body is buffered, so it can be traced and still be read by the caller.
+func (c *Client) do(r *retryablehttp.Request) (*http.Response, error) {
+	r, attempts := withAttemptsCounter(r)
+	start := time.Now()
+	resp, err := c.HTTP.Do(r)
+	var body []byte
+	if resp != nil && resp.Body != nil {
+		var readErr error
+		body, readErr = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			c.Tracer.trace(r.Context(), r, resp, nil, start, *attempts, readErr)
+			return nil, fmt.Errorf("could not read response of %s request to %s: %v", r.Method, r.URL, readErr)
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	c.Tracer.trace(r.Context(), r, resp, body, start, *attempts, err)
+	return resp, err
+}
+
+func (c *Client) SendRequest(r *http.Request) ([]byte, error) {
+	token, err := c.validToken(r.Context(), nil)
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.Token))
 	r.Header.Add("User-Agent", c.UserAgent)
 	switch r.Method {
 	case http.MethodPost, http.MethodPut:
@@ -254,22 +334,35 @@ func (c *Client) SendRequest(r *http.Request) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.HTTP.Do(retryableRequest)
+	resp, err := c.do(retryableRequest)
+	// The token might have been revoked or expired earlier than expected due to clock skew,
+	// so re-authenticate and retry the request once.
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		token, err = c.validToken(r.Context(), token)
+		if err != nil {
+			return nil, err
+		}
+		retryableRequest.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.Token))
+		resp, err = c.do(retryableRequest)
+	}
 	if err != nil {
 		if resp == nil {
 			return nil, fmt.Errorf("failed to execute %s request to %s: %v", r.Method, r.URL, err)
 		} else {
-			return nil, parseHttpError(resp)
+			return nil, recordError(r.Context(), parseHttpError(resp))
 		}
 	}
 	if resp.StatusCode < http.StatusOK || resp.StatusCode > http.StatusIMUsed {
-		return nil, parseHttpError(resp)
+		return nil, recordError(r.Context(), parseHttpError(resp))
 	}
 	// Sometimes after writing it can take up to 100 milliseconds for the resource to actually be consistent in all documentdb server instances.
 	// To make sure the next read will be consistent we will sleep after writing finishes.
 	switch r.Method {
 	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
-		time.Sleep(eventuallyConsistentSleep)
+		if skip, _ := r.Context().Value(skipConsistencySleepKey{}).(bool); !skip {
+			WaitUntilConsistent()
+		}
 	}
 	defer resp.Body.Close()
 	return ioutil.ReadAll(resp.Body)
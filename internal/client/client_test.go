@@ -12,6 +12,9 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -51,6 +54,7 @@ func TestParseHttpError(t *testing.T) {
 		403,
 		"title",
 		"type",
+		nil,
 	}
 	endpoint, _ := url.Parse("https://example.com")
 	bytesRes, _ := json.Marshal(errorResponse)
@@ -65,6 +69,49 @@ func TestParseHttpError(t *testing.T) {
 	assert.EqualError(t, formattedError, "POST request to https://example.com failed with status code 403: title - error details")
 }
 
+func TestParseFieldErrors(t *testing.T) {
+	cases := map[string]struct {
+		Body     string
+		Detail   string
+		Expected []FieldError
+	}{
+		"list": {
+			Body: `{"errors": [{"field": "webhook_config.auth[0].token_url", "message": "invalid url"}]}`,
+			Expected: []FieldError{
+				{Field: "webhook_config.auth.0.token_url", Message: "invalid url"},
+			},
+		},
+		"pydantic-location": {
+			Body: `{"errors": [{"loc": ["body", "mapped_subnets", 1], "msg": "invalid network"}]}`,
+			Expected: []FieldError{
+				{Field: "mapped_subnets.1", Message: "invalid network"},
+			},
+		},
+		"mapping": {
+			Body: `{"errors": {"/email_config/recipients/0": ["invalid email"]}}`,
+			Expected: []FieldError{
+				{Field: "email_config.recipients.0", Message: "invalid email"},
+			},
+		},
+		"detail": {
+			Body:   `{"detail": "'abc' is not a 'email' - 'email_config.recipients.0'"}`,
+			Detail: "'abc' is not a 'email' - 'email_config.recipients.0'",
+			Expected: []FieldError{
+				{Field: "email_config.recipients.0", Message: "'abc' is not a 'email'"},
+			},
+		},
+		"no-field": {
+			Body:   `{"detail": "name already exists"}`,
+			Detail: "name already exists",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, parseFieldErrors([]byte(tc.Body), tc.Detail))
+		})
+	}
+}
+
 func TestSendRequest(t *testing.T) {
 	server := configureServer(t)
 	client := &Client{
@@ -102,7 +149,8 @@ func TestSendRequest(t *testing.T) {
 		assert.Equal(t, "token-2", client.Token.Token)
 	})
 	t.Run("check-with-timeout-context", func(t *testing.T) {
-		ctx, _ := context.WithTimeout(context.Background(), time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
 		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/context", nil)
 		resp, err := client.SendRequest(req)
 		assert.Contains(t, err.Error(), "context deadline exceeded")
@@ -141,6 +189,184 @@ func TestRetry(t *testing.T) {
 	assert.Equal(t, 7, retryCounter)
 }
 
+func TestForOrg(t *testing.T) {
+	scopes := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.String() {
+		case "/v1/oauth/token":
+			credentials := &Credentials{}
+			body, _ := io.ReadAll(req.Body)
+			assert.Nil(t, json.Unmarshal(body, credentials))
+			scopes <- credentials.Scope
+			token := &Token{
+				Token:     credentials.Scope,
+				Expiry:    300,
+				TokenType: "access token",
+			}
+			response, _ := json.Marshal(token)
+			rw.Write(response)
+		case "/v1/test":
+			rw.Write([]byte(req.Header.Get("Authorization")))
+		}
+	}))
+	defer server.Close()
+	client := &Client{
+		HTTP:        retryablehttp.NewClient(),
+		BaseURL:     server.URL,
+		Credentials: &Credentials{ClientID: "key", ClientSecret: "secret", Scope: "org:parent"},
+	}
+	forOrg := func(org string) *Client {
+		orgClient, err := client.ForOrg(org)
+		assert.Nil(t, err)
+		return orgClient
+	}
+	t.Run("same-org-returns-same-client", func(t *testing.T) {
+		assert.Same(t, client, forOrg(""))
+		assert.Same(t, client, forOrg("parent"))
+	})
+	t.Run("sub-org-uses-scoped-token", func(t *testing.T) {
+		subOrgClient := forOrg("child")
+		assert.NotSame(t, client, subOrgClient)
+		assert.Same(t, subOrgClient, forOrg("child"))
+		assert.Equal(t, "org:parent", client.Credentials.Scope)
+		assert.Equal(t, "key", subOrgClient.Credentials.ClientID)
+		assert.Equal(t, "secret", subOrgClient.Credentials.ClientSecret)
+
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/test", nil)
+		resp, err := subOrgClient.SendRequest(req)
+		assert.Nil(t, err)
+		assert.Equal(t, "org:child", <-scopes)
+		assert.Equal(t, "Bearer org:child", string(resp))
+		assert.Nil(t, client.Token)
+	})
+	t.Run("pre-issued-token-is-rejected", func(t *testing.T) {
+		for _, credentials := range []*Credentials{
+			{AccessToken: "token", Scope: "org:parent"},
+			{AccessTokenFile: "/run/secrets/token", Scope: "org:parent"},
+		} {
+			tokenClient := &Client{HTTP: client.HTTP, BaseURL: server.URL, Credentials: credentials}
+			orgClient, err := tokenClient.ForOrg("child")
+			assert.Nil(t, orgClient)
+			assert.EqualError(t, err, "cannot operate on org child with a pre-issued access token, "+
+				"configure an api_key and api_secret or a credential_process which returns them instead")
+			orgClient, err = tokenClient.ForOrg("parent")
+			assert.Nil(t, err)
+			assert.Same(t, tokenClient, orgClient)
+		}
+	})
+}
+
+func configureTokenServer(t *testing.T, tokenRequests *int32, protected func(token string) bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.String() {
+		case "/v1/oauth/token":
+			n := atomic.AddInt32(tokenRequests, 1)
+			// Making sure concurrent requests are waiting for the same token request
+			time.Sleep(time.Millisecond * 50)
+			token := &Token{
+				Token:     fmt.Sprintf("token-%d", n+1),
+				Expiry:    300,
+				TokenType: "access token",
+			}
+			response, _ := json.Marshal(token)
+			rw.Write(response)
+		case "/v1/protected":
+			token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if !protected(token) {
+				errorResponse := &ErrorResponse{Status: 401, Title: "Unauthorized", Detail: "invalid token"}
+				bytesRes, _ := json.Marshal(errorResponse)
+				rw.WriteHeader(http.StatusUnauthorized)
+				rw.Write(bytesRes)
+				return
+			}
+			rw.Write([]byte(token))
+		}
+	}))
+}
+
+func newTestClient(server *httptest.Server, token string, tokenCreationTime int64) *Client {
+	return &Client{
+		HTTP:              retryablehttp.NewClient(),
+		BaseURL:           server.URL,
+		Credentials:       &Credentials{},
+		Token:             &Token{Token: token, Expiry: 300},
+		TokenCreationTime: tokenCreationTime,
+	}
+}
+
+func sendConcurrently(t *testing.T, c *Client, url string, n int) []string {
+	var wg sync.WaitGroup
+	responses := make([]string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			resp, err := c.SendRequest(req)
+			assert.Nil(t, err)
+			responses[i] = string(resp)
+		}(i)
+	}
+	wg.Wait()
+	return responses
+}
+
+func TestConcurrentTokenRefresh(t *testing.T) {
+	var tokenRequests int32
+	server := configureTokenServer(t, &tokenRequests, func(token string) bool { return true })
+	defer server.Close()
+
+	t.Run("refresh-expired-token-once", func(t *testing.T) {
+		client := newTestClient(server, "token-1", time.Now().Unix()-300)
+		responses := sendConcurrently(t, client, server.URL+"/v1/protected", 20)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+		for _, resp := range responses {
+			assert.Equal(t, "token-2", resp)
+		}
+	})
+	t.Run("refresh-before-expiration", func(t *testing.T) {
+		atomic.StoreInt32(&tokenRequests, 0)
+		// 50 seconds left out of 300 - within the refresh margin
+		client := newTestClient(server, "token-1", time.Now().Unix()-250)
+		sendConcurrently(t, client, server.URL+"/v1/protected", 5)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+	})
+	t.Run("valid-token-reused", func(t *testing.T) {
+		atomic.StoreInt32(&tokenRequests, 0)
+		client := newTestClient(server, "token-1", time.Now().Unix())
+		sendConcurrently(t, client, server.URL+"/v1/protected", 5)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&tokenRequests))
+	})
+}
+
+func TestUnauthorizedRecovery(t *testing.T) {
+	t.Run("revoked-token-refreshed-once", func(t *testing.T) {
+		var tokenRequests int32
+		server := configureTokenServer(t, &tokenRequests, func(token string) bool { return token != "revoked" })
+		defer server.Close()
+		client := newTestClient(server, "revoked", time.Now().Unix())
+		responses := sendConcurrently(t, client, server.URL+"/v1/protected", 10)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+		for _, resp := range responses {
+			assert.Equal(t, "token-2", resp)
+		}
+	})
+	t.Run("retried-only-once", func(t *testing.T) {
+		var tokenRequests int32
+		server := configureTokenServer(t, &tokenRequests, func(token string) bool { return false })
+		defer server.Close()
+		client := newTestClient(server, "revoked", time.Now().Unix())
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/protected", nil)
+		resp, err := client.SendRequest(req)
+		assert.Nil(t, resp)
+		errResponse, ok := err.(*ErrorResponse)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusUnauthorized, errResponse.Status)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+	})
+}
+
 func configureServer(t *testing.T) *httptest.Server {
 	tokenCounter := 1
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -191,3 +417,28 @@ func configureServer(t *testing.T) *httptest.Server {
 	}))
 	return server
 }
+
+func TestWithoutConsistencySleep(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	c := &Client{
+		HTTP:        retryablehttp.NewClient(),
+		BaseURL:     server.URL,
+		Credentials: &Credentials{AccessToken: "token"},
+	}
+	start := time.Now()
+	_, err := c.Put(context.Background(), server.URL+"/v1/test", []byte(`{}`))
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), eventuallyConsistentSleep)
+
+	start = time.Now()
+	ctx := WithoutConsistencySleep(context.Background())
+	for i := 0; i < 3; i++ {
+		_, err = c.Put(ctx, server.URL+"/v1/test", []byte(`{}`))
+		assert.NoError(t, err)
+	}
+	assert.Less(t, time.Since(start), eventuallyConsistentSleep)
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	configPath     string = ".pfptmeta/credentials.json"
+	defaultProfile string = "default"
+)
+
+// Config is the format of a single set of credentials, either as a profile in the credentials file
+// or as the output of a credential_process command.
+type Config struct {
+	APIKey            string `json:"api_key"`
+	APISecret         string `json:"api_secret"`
+	OrgShortname      string `json:"org_shortname"`
+	Realm             string `json:"realm"`
+	AccessToken       string `json:"access_token,omitempty"`
+	AccessTokenFile   string `json:"access_token_file,omitempty"`
+	CredentialProcess string `json:"credential_process,omitempty"`
+}
+
+func orgScope(orgShortname string) string {
+	return fmt.Sprintf("org:%s", orgShortname)
+}
+
+// newCredentials resolves the provider's credentials from the first configured source, in the following order:
+// a pre-issued access token, api_key/api_secret/org_shortname, a credential_process command
+// and finally a profile in the credentials file.
+func newCredentials(ctx context.Context, d *schema.ResourceData) (*Credentials, error) {
+	credentials := &Credentials{GrantType: grantType}
+	if realm, haveRealm := d.GetOk("realm"); haveRealm {
+		credentials.Realm = realm.(string)
+	}
+	accessToken, haveAccessToken := d.GetOk("access_token")
+	accessTokenFile, haveAccessTokenFile := d.GetOk("access_token_file")
+	if haveAccessToken || haveAccessTokenFile {
+		credentials.AccessToken = accessToken.(string)
+		credentials.AccessTokenFile = accessTokenFile.(string)
+		if org, haveOrg := d.GetOk("org_shortname"); haveOrg {
+			credentials.Scope = orgScope(org.(string))
+		}
+		return credentials, nil
+	}
+	apiKey, haveAPIKey := d.GetOk("api_key")
+	apiSecret, haveAPISecret := d.GetOk("api_secret")
+	org, haveOrg := d.GetOk("org_shortname")
+	// If one is set
+	if haveAPIKey || haveAPISecret || haveOrg {
+		// but not all are set
+		if !(haveAPIKey && haveAPISecret && haveOrg) {
+			return nil, errors.New("please provide an api_key, api_secret and org shortname")
+		}
+		credentials.ClientID = apiKey.(string)
+		credentials.ClientSecret = apiSecret.(string)
+		credentials.Scope = orgScope(org.(string))
+		return credentials, nil
+	}
+	if process, haveProcess := d.GetOk("credential_process"); haveProcess {
+		config, err := runCredentialProcess(ctx, process.(string))
+		if err != nil {
+			return nil, err
+		}
+		return configToCredentials(credentials, config), nil
+	}
+	path := d.Get("credentials_file").(string)
+	profile := d.Get("profile").(string)
+	config, err := parseCredentialsFile(path, profile)
+	if err != nil {
+		return nil, err
+	}
+	if config.CredentialProcess != "" {
+		config, err = runCredentialProcess(ctx, config.CredentialProcess)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return configToCredentials(credentials, config), nil
+}
+
+func configToCredentials(credentials *Credentials, config *Config) *Credentials {
+	credentials.ClientID = config.APIKey
+	credentials.ClientSecret = config.APISecret
+	credentials.AccessToken = config.AccessToken
+	credentials.AccessTokenFile = config.AccessTokenFile
+	if config.OrgShortname != "" {
+		credentials.Scope = orgScope(config.OrgShortname)
+	}
+	if config.Realm != "" && credentials.Realm == "" {
+		credentials.Realm = config.Realm
+	}
+	return credentials
+}
+
+// parseCredentialsFile reads the given profile from the credentials file.
+// The file either holds a single set of credentials, which is used as the default profile,
+// or an object mapping profile names to credentials.
+func parseCredentialsFile(path, profile string) (*Config, error) {
+	if path == "" {
+		usr, err := user.Current()
+		if err != nil {
+			return nil, fmt.Errorf("could not find current user's name: %v", err)
+		}
+		path = filepath.Join(usr.HomeDir, configPath)
+	}
+	if profile == "" {
+		profile = defaultProfile
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open credentials file %s: %v", path, err)
+	}
+	defer file.Close()
+	configBytes, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read credentials file %s: %v", path, err)
+	}
+	var profiles map[string]json.RawMessage
+	err = json.Unmarshal(configBytes, &profiles)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse credentials file %s: %v", path, err)
+	}
+	if isSingleProfile(profiles) {
+		if profile != defaultProfile {
+			return nil, fmt.Errorf("could not find profile \"%s\" in credentials file %s", profile, path)
+		}
+		profiles = map[string]json.RawMessage{defaultProfile: configBytes}
+	}
+	rawConfig, ok := profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("could not find profile \"%s\" in credentials file %s", profile, path)
+	}
+	config := &Config{}
+	err = json.Unmarshal(rawConfig, config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse profile \"%s\" in credentials file %s: %v", profile, path, err)
+	}
+	return config, nil
+}
+
+func isSingleProfile(profiles map[string]json.RawMessage) bool {
+	for _, key := range []string{"api_key", "access_token", "access_token_file", "credential_process"} {
+		if _, ok := profiles[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// runCredentialProcess executes an external command which prints the credentials to stdout in the same json format
+// used by the credentials file, i.e. {"api_key": "...", "api_secret": "...", "org_shortname": "..."}
+// or {"access_token": "...", "org_shortname": "..."}.
+func runCredentialProcess(ctx context.Context, process string) (*Config, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", process)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", process)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = os.Environ()
+	err := cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("credential_process failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	config := &Config{}
+	err = json.Unmarshal(stdout.Bytes(), config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse credential_process output: %v", err)
+	}
+	if config.CredentialProcess != "" {
+		return nil, errors.New("credential_process output cannot contain another credential_process")
+	}
+	if config.AccessToken == "" && config.AccessTokenFile == "" && (config.APIKey == "" || config.APISecret == "" || config.OrgShortname == "") {
+		return nil, errors.New("credential_process output should contain either an access_token or an api_key, api_secret and org_shortname")
+	}
+	return config, nil
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// validationDetailPattern matches the detail of schema validation errors, which ends with the path of the invalid field,
+// e.g. "'abc' is not a 'email' - 'email_config.recipients.0'"
+var validationDetailPattern = regexp.MustCompile(`^(.+) - '([\w\-.\[\]]+)'$`)
+
+// FieldError is a validation error of a specific field in the request body.
+// Field is the path of the field in the request, with nested fields and list indexes separated by dots,
+// e.g. "webhook_config.auth.oauth2_config.token_url" or "mapped_subnets.1".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// parseFieldErrors extracts the field errors from an error response, which may list them under "errors"
+// either as a list of objects or as a mapping of fields to messages,
+// or may describe a single invalid field in its detail.
+func parseFieldErrors(body []byte, detail string) []FieldError {
+	var res []FieldError
+	raw := &struct {
+		Errors json.RawMessage `json:"errors"`
+	}{}
+	if err := json.Unmarshal(body, raw); err == nil && len(raw.Errors) > 0 {
+		var list []map[string]interface{}
+		var mapping map[string]interface{}
+		if err := json.Unmarshal(raw.Errors, &list); err == nil {
+			for _, e := range list {
+				field := fieldName(firstOf(e, "field", "path", "loc", "name"))
+				message := fmt.Sprint(firstOf(e, "message", "msg", "detail"))
+				if field != "" {
+					res = append(res, FieldError{Field: field, Message: message})
+				}
+			}
+		} else if err := json.Unmarshal(raw.Errors, &mapping); err == nil {
+			for field, messages := range mapping {
+				switch m := messages.(type) {
+				case []interface{}:
+					for _, message := range m {
+						res = append(res, FieldError{Field: fieldName(field), Message: fmt.Sprint(message)})
+					}
+				default:
+					res = append(res, FieldError{Field: fieldName(field), Message: fmt.Sprint(m)})
+				}
+			}
+		}
+	}
+	if len(res) == 0 {
+		if match := validationDetailPattern.FindStringSubmatch(detail); match != nil {
+			res = append(res, FieldError{Field: fieldName(match[2]), Message: match[1]})
+		}
+	}
+	return res
+}
+
+func firstOf(m map[string]interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		if v, ok := m[key]; ok && v != nil {
+			return v
+		}
+	}
+	return ""
+}
+
+// fieldName converts the different field path formats, i.e. "a.b[0]", "/a/b/0" and ["body", "a", "b", 0],
+// to the "a.b.0" format.
+func fieldName(field interface{}) string {
+	var parts []string
+	switch f := field.(type) {
+	case []interface{}:
+		for _, part := range f {
+			parts = append(parts, fmt.Sprint(part))
+		}
+		// pydantic style locations start with the request part which the field belongs to
+		if len(parts) > 0 && parts[0] == "body" {
+			parts = parts[1:]
+		}
+	default:
+		s := strings.NewReplacer("[", ".", "]", "", "/", ".").Replace(fmt.Sprint(f))
+		parts = strings.Split(strings.Trim(s, "."), ".")
+	}
+	return strings.Join(parts, ".")
+}
+
+type errorRecorderKey struct{}
+
+// ErrorRecorder collects the API errors returned to requests sent with a context created by WithErrorRecorder,
+// so callers that only get the error's message, such as diag.FromErr, can still look up its field errors.
+type ErrorRecorder struct {
+	lock   sync.Mutex
+	errors []*ErrorResponse
+}
+
+func WithErrorRecorder(ctx context.Context) (context.Context, *ErrorRecorder) {
+	recorder := &ErrorRecorder{}
+	return context.WithValue(ctx, errorRecorderKey{}, recorder), recorder
+}
+
+// Find returns the recorded error with the given message.
+func (r *ErrorRecorder) Find(message string) *ErrorResponse {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, e := range r.errors {
+		if e.Error() == message || strings.Contains(message, e.Error()) {
+			return e
+		}
+	}
+	return nil
+}
+
+func recordError(ctx context.Context, err error) error {
+	errResponse, ok := err.(*ErrorResponse)
+	if !ok {
+		return err
+	}
+	if recorder, ok := ctx.Value(errorRecorderKey{}).(*ErrorRecorder); ok {
+		recorder.lock.Lock()
+		recorder.errors = append(recorder.errors, errResponse)
+		recorder.lock.Unlock()
+	}
+	return err
+}
@@ -11,13 +11,14 @@ const (
 )
 
 type Location struct {
-	City      string  `json:"city"`
-	Country   string  `json:"country"`
-	Latitude  float32 `json:"latitude"`
-	Longitude float32 `json:"longitude"`
-	Name      string  `json:"name"`
-	State     string  `json:"state"`
-	Status    string  `json:"status"`
+	City         string  `json:"city"`
+	Country      string  `json:"country"`
+	Latitude     float32 `json:"latitude"`
+	Longitude    float32 `json:"longitude"`
+	Name         string  `json:"name"`
+	State        string  `json:"state"`
+	Status       string  `json:"status"`
+	DedicatedIps bool    `json:"dedicated_ips"`
 }
 
 func GetLocation(ctx context.Context, c *Client, lName string) (*Location, error) {
@@ -33,3 +34,17 @@ func GetLocation(ctx context.Context, c *Client, lName string) (*Location, error
 	}
 	return location, nil
 }
+
+func ListLocations(ctx context.Context, c *Client) ([]Location, error) {
+	url := fmt.Sprintf("%s/%s", c.BaseURL, locationsEndpoint)
+	resp, err := c.Get(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not list locations: %v", err)
+	}
+	var locations []Location
+	err = json.Unmarshal(resp, &locations)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse locations response: %v", err)
+	}
+	return locations, nil
+}
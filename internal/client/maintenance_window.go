@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const maintenanceWindowEndpoint = "v1/maintenance_windows"
+
+type MaintenanceWindow struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description"`
+	Alerts      []string `json:"alerts"`
+	StartTime   string   `json:"start_time,omitempty"`
+	EndTime     string   `json:"end_time"`
+	Status      string   `json:"status,omitempty"`
+}
+
+func NewMaintenanceWindow(d *schema.ResourceData) *MaintenanceWindow {
+	res := &MaintenanceWindow{}
+	if d.HasChange("name") {
+		res.Name = d.Get("name").(string)
+	}
+	res.Description = d.Get("description").(string)
+	res.Alerts = ResourceTypeSetToStringSlice(d.Get("alerts").(*schema.Set))
+	res.StartTime = d.Get("start_time").(string)
+	res.EndTime = d.Get("end_time").(string)
+	return res
+}
+
+func parseMaintenanceWindow(resp []byte) (*MaintenanceWindow, error) {
+	mw := &MaintenanceWindow{}
+	err := json.Unmarshal(resp, mw)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse maintenance window response: %v", err)
+	}
+	return mw, nil
+}
+
+func CreateMaintenanceWindow(ctx context.Context, c *Client, mw *MaintenanceWindow) (*MaintenanceWindow, error) {
+	url := fmt.Sprintf("%s/%s", c.BaseURL, maintenanceWindowEndpoint)
+	body, err := json.Marshal(mw)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert maintenance window to json: %v", err)
+	}
+	resp, err := c.Post(ctx, url, body)
+	if err != nil {
+		return nil, err
+	}
+	return parseMaintenanceWindow(resp)
+}
+
+func UpdateMaintenanceWindow(ctx context.Context, c *Client, mwID string, mw *MaintenanceWindow) (*MaintenanceWindow, error) {
+	url := fmt.Sprintf("%s/%s/%s", c.BaseURL, maintenanceWindowEndpoint, mwID)
+	body, err := json.Marshal(mw)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert maintenance window to json: %v", err)
+	}
+	resp, err := c.Patch(ctx, url, body)
+	if err != nil {
+		return nil, err
+	}
+	return parseMaintenanceWindow(resp)
+}
+
+func GetMaintenanceWindow(ctx context.Context, c *Client, mwID string) (*MaintenanceWindow, error) {
+	url := fmt.Sprintf("%s/%s/%s", c.BaseURL, maintenanceWindowEndpoint, mwID)
+	resp, err := c.Get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseMaintenanceWindow(resp)
+}
+
+func DeleteMaintenanceWindow(ctx context.Context, c *Client, mwID string) (*MaintenanceWindow, error) {
+	url := fmt.Sprintf("%s/%s/%s", c.BaseURL, maintenanceWindowEndpoint, mwID)
+	resp, err := c.Delete(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseMaintenanceWindow(resp)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	u "net/url"
 )
 
 type MappedDomain struct {
@@ -56,3 +57,20 @@ func DeleteMappedDomain(ctx context.Context, c *Client, neID, name string) error
 	}
 	return nil
 }
+
+// ListMappedDomains returns the mapped domains of the network element, which are part of the expanded network element.
+func ListMappedDomains(ctx context.Context, c *Client, neID string) ([]MappedDomain, error) {
+	url := fmt.Sprintf("%s/%s/%s", c.BaseURL, networkElementsEndpoint, neID)
+	resp, err := c.Get(ctx, url, u.Values{"expand": {"true"}})
+	if err != nil {
+		return nil, err
+	}
+	ne := &struct {
+		MappedDomains []MappedDomain `json:"mapped_domains"`
+	}{}
+	err = json.Unmarshal(resp, ne)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse network element response: %v", err)
+	}
+	return ne.MappedDomains, nil
+}
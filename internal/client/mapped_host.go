@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	u "net/url"
 )
 
 type MappedHost struct {
@@ -56,3 +57,20 @@ func DeleteMappedHost(ctx context.Context, c *Client, neID, name string) error {
 	}
 	return nil
 }
+
+// ListMappedHosts returns the mapped hosts of the network element, which are part of the expanded network element.
+func ListMappedHosts(ctx context.Context, c *Client, neID string) ([]MappedHost, error) {
+	url := fmt.Sprintf("%s/%s/%s", c.BaseURL, networkElementsEndpoint, neID)
+	resp, err := c.Get(ctx, url, u.Values{"expand": {"true"}})
+	if err != nil {
+		return nil, err
+	}
+	ne := &struct {
+		MappedHosts []MappedHost `json:"mapped_hosts"`
+	}{}
+	err = json.Unmarshal(resp, ne)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse network element response: %v", err)
+	}
+	return ne.MappedHosts, nil
+}
@@ -13,13 +13,14 @@ const (
 )
 
 type Metaport struct {
-	ID                   string   `json:"id,omitempty"`
-	Name                 string   `json:"name,omitempty"`
-	Description          string   `json:"description,omitempty"`
-	Enabled              *bool    `json:"enabled,omitempty"`
-	AllowSupport         *bool    `json:"allow_support,omitempty"`
-	MappedElements       []string `json:"mapped_elements"`
-	NotificationChannels []string `json:"notification_channels"`
+	ID                               string   `json:"id,omitempty"`
+	Name                             string   `json:"name,omitempty"`
+	Description                      string   `json:"description,omitempty"`
+	Enabled                          *bool    `json:"enabled,omitempty"`
+	AllowSupport                     *bool    `json:"allow_support,omitempty"`
+	MappedElements                   []string `json:"mapped_elements"`
+	NotificationChannels             []string `json:"notification_channels"`
+	NotificationSuppressionSchedules []string `json:"notification_suppression_schedules"`
 }
 
 func NewMetaport(d *schema.ResourceData) *Metaport {
@@ -40,6 +41,7 @@ func NewMetaport(d *schema.ResourceData) *Metaport {
 	mes := d.Get("mapped_elements")
 	res.MappedElements = ResourceTypeSetToStringSlice(mes.(*schema.Set))
 	res.NotificationChannels = ConfigToStringSlice("notification_channels", d)
+	res.NotificationSuppressionSchedules = ConfigToStringSlice("notification_suppression_schedules", d)
 
 	return res
 }
@@ -75,7 +77,7 @@ func GetMetaport(ctx context.Context, c *Client, mId string) (*Metaport, error)
 	return parseMetaport(resp)
 }
 
-func GetMetaportByName(ctx context.Context, c *Client, name string) (*Metaport, error) {
+func ListMetaports(ctx context.Context, c *Client) ([]Metaport, error) {
 	url := fmt.Sprintf("%s/%s", c.BaseURL, metaportEndpoint)
 	resp, err := c.Get(ctx, url, u.Values{"expand": {"true"}})
 	if err != nil {
@@ -86,6 +88,14 @@ func GetMetaportByName(ctx context.Context, c *Client, name string) (*Metaport,
 	if err != nil {
 		return nil, fmt.Errorf("could not parse metaport response: %v", err)
 	}
+	return respBody, nil
+}
+
+func GetMetaportByName(ctx context.Context, c *Client, name string) (*Metaport, error) {
+	respBody, err := ListMetaports(ctx, c)
+	if err != nil {
+		return nil, err
+	}
 	var nameMatch []Metaport
 	for _, m := range respBody {
 		if m.Name == name {
@@ -153,3 +163,23 @@ func RemoveMappedElementsFromMetaport(ctx context.Context, c *Client, mID string
 	}
 	return parseMetaport(resp)
 }
+
+// MetaportOTAC is a one-time activation code with which a MetaPort instance enrolls as the MetaPort.
+type MetaportOTAC struct {
+	Secret    string `json:"secret"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+func GenerateMetaportOTAC(ctx context.Context, c *Client, mID string) (*MetaportOTAC, error) {
+	url := fmt.Sprintf("%s/%s/%s/otac", c.BaseURL, metaportEndpoint, mID)
+	resp, err := c.Post(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	otac := &MetaportOTAC{}
+	err = json.Unmarshal(resp, otac)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse metaport otac response: %v", err)
+	}
+	return otac, nil
+}
@@ -67,7 +67,7 @@ func GetMetaportCluster(ctx context.Context, c *Client, mId string) (*MetaportCl
 	return parseMetaportCluster(resp)
 }
 
-func GetMetaportClustertByName(ctx context.Context, c *Client, name string) (*MetaportCluster, error) {
+func ListMetaportClusters(ctx context.Context, c *Client) ([]MetaportCluster, error) {
 	url := fmt.Sprintf("%s/%s", c.BaseURL, metaportClusterEndpoint)
 	resp, err := c.Get(ctx, url, u.Values{"expand": {"true"}})
 	if err != nil {
@@ -78,6 +78,14 @@ func GetMetaportClustertByName(ctx context.Context, c *Client, name string) (*Me
 	if err != nil {
 		return nil, fmt.Errorf("could not parse metaport cluster response: %v", err)
 	}
+	return respBody, nil
+}
+
+func GetMetaportClustertByName(ctx context.Context, c *Client, name string) (*MetaportCluster, error) {
+	respBody, err := ListMetaportClusters(ctx, c)
+	if err != nil {
+		return nil, err
+	}
 	var nameMatch []MetaportCluster
 	for _, m := range respBody {
 		if m.Name == name {
@@ -135,3 +135,17 @@ func DeleteMetaportFailover(ctx context.Context, c *Client, mcID string) (*Metap
 	}
 	return parseMetaportFailover(resp)
 }
+
+// SwitchMetaportFailover manually activates one of the clusters of the failover.
+func SwitchMetaportFailover(ctx context.Context, c *Client, mfID, clusterID string) (*MetaportFailover, error) {
+	url := fmt.Sprintf("%s/%s/%s/switch", c.BaseURL, metaportFailoverEndpoint, mfID)
+	body, err := json.Marshal(map[string]string{"active_cluster": clusterID})
+	if err != nil {
+		return nil, fmt.Errorf("could not convert metaport failover switch to json: %v", err)
+	}
+	resp, err := c.Post(ctx, url, body)
+	if err != nil {
+		return nil, err
+	}
+	return parseMetaportFailover(resp)
+}
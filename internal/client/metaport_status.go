@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// MetaportConnected is the connection state of a MetaPort with an instance connected to at least one POP.
+const MetaportConnected = "connected"
+
+// MetaportStatus is the runtime status of the MetaPort instance.
+type MetaportStatus struct {
+	ID              string   `json:"id"`
+	ConnectionState string   `json:"connection_state"`
+	Version         string   `json:"version"`
+	LastSeen        string   `json:"last_seen"`
+	PublicIp        string   `json:"public_ip"`
+	ConnectedPops   []string `json:"connected_pops"`
+}
+
+func GetMetaportStatus(ctx context.Context, c *Client, mID string) (*MetaportStatus, error) {
+	url := fmt.Sprintf("%s/%s/%s/status", c.BaseURL, metaportEndpoint, mID)
+	resp, err := c.Get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &MetaportStatus{}
+	err = json.Unmarshal(resp, s)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse metaport status response: %v", err)
+	}
+	if s.ID == "" {
+		s.ID = mID
+	}
+	return s, nil
+}
+
+// GetMetaportClusterStatus returns the statuses of all the MetaPorts of the cluster.
+func GetMetaportClusterStatus(ctx context.Context, c *Client, mcID string) ([]*MetaportStatus, error) {
+	mc, err := GetMetaportCluster(ctx, c, mcID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*MetaportStatus, len(mc.Metaports))
+	for i, mID := range mc.Metaports {
+		res[i], err = GetMetaportStatus(ctx, c, mID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
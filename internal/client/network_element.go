@@ -107,6 +107,20 @@ func GetNetworkElement(ctx context.Context, c *Client, neID string) (*NetworkEle
 	return parseNetworkElement(resp)
 }
 
+func ListNetworkElements(ctx context.Context, c *Client) ([]NetworkElementResponse, error) {
+	url := fmt.Sprintf("%s/%s", c.BaseURL, networkElementsEndpoint)
+	resp, err := c.Get(ctx, url, u.Values{"expand": {"true"}})
+	if err != nil {
+		return nil, err
+	}
+	var networkElements []NetworkElementResponse
+	err = json.Unmarshal(resp, &networkElements)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse network elements response: %v", err)
+	}
+	return networkElements, nil
+}
+
 func DeleteNetworkElement(ctx context.Context, c *Client, neID string) (*NetworkElementResponse, error) {
 	url := fmt.Sprintf("%s/%s/%s", c.BaseURL, networkElementsEndpoint, neID)
 	resp, err := c.Delete(ctx, url, nil)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -30,7 +32,7 @@ func newEmailConfig(d *schema.ResourceData) *EmailConfig {
 }
 
 type PagerdutyConfig struct {
-	ApiKey string `json:"api_key"`
+	ApiKey string `json:"api_key,omitempty"`
 }
 
 func newPagerdutyConfig(d *schema.ResourceData) *PagerdutyConfig {
@@ -46,7 +48,7 @@ func newPagerdutyConfig(d *schema.ResourceData) *PagerdutyConfig {
 
 type SlackConfig struct {
 	Channel string `json:"channel"`
-	Url     string `json:"url"`
+	Url     string `json:"url,omitempty"`
 }
 
 func newSlackConfig(d *schema.ResourceData) *SlackConfig {
@@ -63,7 +65,7 @@ func newSlackConfig(d *schema.ResourceData) *SlackConfig {
 
 type Oauth2Config struct {
 	ClientId     string `json:"client_id"`
-	ClientSecret string `json:"client_secret"`
+	ClientSecret string `json:"client_secret,omitempty"`
 	TokenUrl     string `json:"token_url"`
 }
 
@@ -87,15 +89,19 @@ func NewWebhookConfig(d *schema.ResourceData) *WebhookConfig {
 	}
 	conf := whConf[0].(map[string]interface{})
 	res.CustomPayload = conf["custom_payload"].(string)
-	headers := conf["headers"].([]interface{})
-	res.Headers = make([]string, len(headers))
-	for i, val := range headers {
-		res.Headers[i] = val.(string)
+	headers := conf["headers"].(map[string]interface{})
+	res.Headers = make([]string, 0, len(headers))
+	for name, value := range headers {
+		res.Headers = append(res.Headers, fmt.Sprintf("%s:%s", name, value.(string)))
 	}
+	sort.Strings(res.Headers)
 	res.Method = conf["method"].(string)
 	res.Url = conf["url"].(string)
-	auth := conf["auth"].([]interface{})[0].(map[string]interface{})
-	auth2Conf := auth["oauth2_config"].([]interface{})[0].(map[string]interface{})
+	auth := conf["auth"].([]interface{})
+	if len(auth) == 0 || auth[0] == nil {
+		return res
+	}
+	auth2Conf := auth[0].(map[string]interface{})["oauth2_config"].([]interface{})[0].(map[string]interface{})
 	res.Auth = &Auth{
 		Oauth2Config: Oauth2Config{
 			ClientId:     auth2Conf["client_id"].(string),
@@ -116,6 +122,7 @@ type NotificationChannel struct {
 	PagerdutyConfig *PagerdutyConfig `json:"pagerduty_config,omitempty"`
 	SlackConfig     *SlackConfig     `json:"slack_config,omitempty"`
 	WebhookConfig   *WebhookConfig   `json:"webhook_config,omitempty"`
+	SyslogConfig    *SyslogConfig    `json:"syslog_config,omitempty"`
 }
 
 func NewNotificationChannel(d *schema.ResourceData) *NotificationChannel {
@@ -129,6 +136,14 @@ func NewNotificationChannel(d *schema.ResourceData) *NotificationChannel {
 	res.PagerdutyConfig = newPagerdutyConfig(d)
 	res.SlackConfig = newSlackConfig(d)
 	res.WebhookConfig = NewWebhookConfig(d)
+	res.SyslogConfig = newSyslogConfig(d.Get("syslog_config").([]interface{}))
+	for _, preset := range []func(*schema.ResourceData) *WebhookConfig{
+		newTeamsWebhookConfig, newOpsgenieWebhookConfig, newServiceNowWebhookConfig,
+	} {
+		if res.WebhookConfig == nil {
+			res.WebhookConfig = preset(d)
+		}
+	}
 	return res
 }
 
@@ -184,3 +199,23 @@ func DeleteNotificationChannel(ctx context.Context, c *Client, ncID string) (*No
 	}
 	return parseNotificationChannel(resp)
 }
+
+// NotificationTestResult is the result of sending a test notification through a channel.
+type NotificationTestResult struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+func TestNotificationChannel(ctx context.Context, c *Client, ncID string) (*NotificationTestResult, error) {
+	url := fmt.Sprintf("%s/%s/%s/test", c.BaseURL, notificationChannelEndpoint, ncID)
+	resp, err := c.Post(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := &NotificationTestResult{}
+	err = json.Unmarshal(resp, res)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse notification test response: %v", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The Teams, Opsgenie and ServiceNow channels are not native notification channel types of the API.
+// They are presets of webhook channels, with the url, headers and payload of each service's alert API.
+
+const (
+	opsgenieURL        = "https://api.opsgenie.com/v2/alerts"
+	opsgenieEUURL      = "https://api.eu.opsgenie.com/v2/alerts"
+	serviceNowTableAPI = "/api/now/table/"
+)
+
+func presetConfig(d *schema.ResourceData, key string) map[string]interface{} {
+	conf := d.Get(key).([]interface{})
+	if len(conf) == 0 || conf[0] == nil {
+		return nil
+	}
+	return conf[0].(map[string]interface{})
+}
+
+func presetPayload(payload map[string]interface{}) string {
+	body, _ := json.Marshal(payload)
+	return string(body)
+}
+
+func newTeamsWebhookConfig(d *schema.ResourceData) *WebhookConfig {
+	conf := presetConfig(d, "teams_config")
+	if conf == nil {
+		return nil
+	}
+	payload := map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "http://schema.org/extensions",
+		"themeColor": "D70000",
+		"summary":    "{{ alert_name }}",
+		"title":      "{{ alert_name }}",
+		"text":       "{{ notify_message }}",
+		"sections": []interface{}{
+			map[string]interface{}{
+				"facts": []interface{}{
+					map[string]string{"name": "Source", "value": "{{ source_type }}"},
+					map[string]string{"name": "Group", "value": "{{ group_value }}"},
+					map[string]string{"name": "Hits", "value": "{{ hits }}"},
+					map[string]string{"name": "Triggered at", "value": "{{ triggered_at }}"},
+				},
+			},
+		},
+		"potentialAction": []interface{}{
+			map[string]interface{}{
+				"@type":   "OpenUri",
+				"name":    "View alert",
+				"targets": []interface{}{map[string]string{"os": "default", "uri": "{{ console_url }}"}},
+			},
+		},
+	}
+	return &WebhookConfig{
+		CustomPayload: presetPayload(payload),
+		Method:        "POST",
+		Url:           conf["url"].(string),
+	}
+}
+
+func newOpsgenieWebhookConfig(d *schema.ResourceData) *WebhookConfig {
+	conf := presetConfig(d, "opsgenie_config")
+	if conf == nil {
+		return nil
+	}
+	payload := map[string]interface{}{
+		"message":     "{{ alert_name }}",
+		"alias":       "{{ alert_id }}-{{ group_value }}",
+		"description": "{{ notify_message }}",
+		"priority":    conf["priority"].(string),
+		"source":      "Proofpoint Meta",
+		"details": map[string]string{
+			"source_type": "{{ source_type }}",
+			"group_by":    "{{ group_by }}",
+			"group_value": "{{ group_value }}",
+			"hits":        "{{ hits }}",
+			"console_url": "{{ console_url }}",
+		},
+	}
+	if team := conf["team"].(string); team != "" {
+		payload["responders"] = []interface{}{map[string]string{"name": team, "type": "team"}}
+	}
+	if tags := conf["tags"].([]interface{}); len(tags) > 0 {
+		payload["tags"] = tags
+	}
+	url := opsgenieURL
+	if conf["region"].(string) == "EU" {
+		url = opsgenieEUURL
+	}
+	return &WebhookConfig{
+		CustomPayload: presetPayload(payload),
+		Headers:       []string{OpsgenieAuthHeader(conf["api_key"].(string))},
+		Method:        "POST",
+		Url:           url,
+	}
+}
+
+func newServiceNowWebhookConfig(d *schema.ResourceData) *WebhookConfig {
+	conf := presetConfig(d, "servicenow_config")
+	if conf == nil {
+		return nil
+	}
+	payload := map[string]interface{}{
+		"short_description": "{{ alert_name }}",
+		"description":       "{{ notify_message }}\nSource: {{ source_type }}\nGroup: {{ group_value }}\nHits: {{ hits }}\n{{ console_url }}",
+		"correlation_id":    "{{ alert_id }}-{{ group_value }}",
+		"urgency":           conf["urgency"].(int),
+		"impact":            conf["impact"].(int),
+	}
+	if group := conf["assignment_group"].(string); group != "" {
+		payload["assignment_group"] = group
+	}
+	return &WebhookConfig{
+		CustomPayload: presetPayload(payload),
+		Headers: []string{
+			"Accept:application/json",
+			ServiceNowAuthHeader(conf["username"].(string), conf["password"].(string)),
+		},
+		Method: "POST",
+		Url:    strings.TrimSuffix(conf["instance_url"].(string), "/") + serviceNowTableAPI + conf["table"].(string),
+	}
+}
+
+// OpsgenieAuthHeader returns the header which authenticates requests to the Opsgenie API with the API key.
+func OpsgenieAuthHeader(apiKey string) string {
+	return fmt.Sprintf("Authorization:GenieKey %s", apiKey)
+}
+
+// ServiceNowAuthHeader returns the header which authenticates requests to the ServiceNow API with basic authentication.
+func ServiceNowAuthHeader(username, password string) string {
+	credentials := fmt.Sprintf("%s:%s", username, password)
+	return fmt.Sprintf("Authorization:Basic %s", base64.StdEncoding.EncodeToString([]byte(credentials)))
+}
+
+// SetAuthHeader replaces the Authorization header of the webhook.
+func (w *WebhookConfig) SetAuthHeader(header string) {
+	for i, h := range w.Headers {
+		if strings.HasPrefix(h, "Authorization:") {
+			w.Headers[i] = header
+			return
+		}
+	}
+	w.Headers = append(w.Headers, header)
+}
+
+// ParseServiceNowURL returns the instance url and table of a ServiceNow webhook url.
+func ParseServiceNowURL(url string) (string, string) {
+	i := strings.Index(url, serviceNowTableAPI)
+	if i == -1 {
+		return url, ""
+	}
+	return url[:i], url[i+len(serviceNowTableAPI):]
+}
+
+// OpsgenieRegion returns the region of an Opsgenie webhook url.
+func OpsgenieRegion(url string) string {
+	if url == opsgenieEUURL {
+		return "EU"
+	}
+	return "US"
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net"
+	"sort"
+)
+
+// MappedSubnet is a CIDR of a mapped subnet network element,
+// with the MetaPorts and MetaPort clusters which the element is mapped to.
+type MappedSubnet struct {
+	NetworkElementID   string
+	NetworkElementName string
+	Cidr               *net.IPNet
+	Metaports          []string
+	MetaportClusters   []string
+}
+
+func (s MappedSubnet) prefixLen() int {
+	ones, _ := s.Cidr.Mask.Size()
+	return ones
+}
+
+// contains returns whether the subnet contains all the addresses of other.
+func (s MappedSubnet) contains(other MappedSubnet) bool {
+	return len(s.Cidr.IP) == len(other.Cidr.IP) && s.prefixLen() <= other.prefixLen() && s.Cidr.Contains(other.Cidr.IP)
+}
+
+// SubnetOverlap is a pair of mapped subnets of different network elements, the first of which contains the second.
+type SubnetOverlap struct {
+	Subnet      MappedSubnet
+	Overlapping MappedSubnet
+}
+
+// Equal returns whether both subnets are the same CIDR, in which case neither of them is more specific.
+func (o SubnetOverlap) Equal() bool {
+	return o.Subnet.prefixLen() == o.Overlapping.prefixLen()
+}
+
+// overlap returns the overlap of a and b, if the two subnets overlap.
+// Two CIDRs either don't overlap at all or one of them contains the other.
+func overlap(a, b MappedSubnet) (SubnetOverlap, bool) {
+	if a.contains(b) {
+		return SubnetOverlap{Subnet: a, Overlapping: b}, true
+	}
+	if b.contains(a) {
+		return SubnetOverlap{Subnet: b, Overlapping: a}, true
+	}
+	return SubnetOverlap{}, false
+}
+
+// RoutingTable holds the mapped subnets of all the network elements, ordered by address and prefix length.
+type RoutingTable []MappedSubnet
+
+// NewRoutingTable builds the routing table of the network elements and the MetaPorts and clusters they are mapped to.
+func NewRoutingTable(nes []NetworkElementResponse, mps []Metaport, mcs []MetaportCluster) RoutingTable {
+	metaports := make(map[string][]string)
+	for _, mp := range mps {
+		for _, me := range mp.MappedElements {
+			metaports[me] = append(metaports[me], mp.ID)
+		}
+	}
+	clusters := make(map[string][]string)
+	for _, mc := range mcs {
+		for _, me := range mc.MappedElements {
+			clusters[me] = append(clusters[me], mc.ID)
+		}
+	}
+	var rt RoutingTable
+	for _, ne := range nes {
+		for _, cidr := range ne.MappedSubnets {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				log.Printf("[WARN] Ignoring mapped subnet %s of network element %s: %v", cidr, ne.ID, err)
+				continue
+			}
+			rt = append(rt, MappedSubnet{
+				NetworkElementID:   ne.ID,
+				NetworkElementName: ne.Name,
+				Cidr:               ipNet,
+				Metaports:          metaports[ne.ID],
+				MetaportClusters:   clusters[ne.ID],
+			})
+		}
+	}
+	sort.SliceStable(rt, func(i, j int) bool {
+		if c := bytes.Compare(rt[i].Cidr.IP.To16(), rt[j].Cidr.IP.To16()); c != 0 {
+			return c < 0
+		}
+		if rt[i].prefixLen() != rt[j].prefixLen() {
+			return rt[i].prefixLen() < rt[j].prefixLen()
+		}
+		return rt[i].NetworkElementID < rt[j].NetworkElementID
+	})
+	return rt
+}
+
+// LoadRoutingTable loads all the network elements, MetaPorts and MetaPort clusters and builds their routing table.
+func LoadRoutingTable(ctx context.Context, c *Client) (RoutingTable, error) {
+	nes, err := ListNetworkElements(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	mps, err := ListMetaports(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	mcs, err := ListMetaportClusters(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return NewRoutingTable(nes, mps, mcs), nil
+}
+
+// Overlaps returns the overlapping mapped subnets of different network elements.
+func (rt RoutingTable) Overlaps() []SubnetOverlap {
+	var res []SubnetOverlap
+	for i := range rt {
+		for j := i + 1; j < len(rt); j++ {
+			if rt[i].NetworkElementID == rt[j].NetworkElementID {
+				continue
+			}
+			if o, ok := overlap(rt[i], rt[j]); ok {
+				res = append(res, o)
+			}
+		}
+	}
+	return res
+}
+
+// OverlapsWith returns the overlaps of the given subnets with the mapped subnets of the other network elements.
+func (rt RoutingTable) OverlapsWith(subnets []MappedSubnet) []SubnetOverlap {
+	var res []SubnetOverlap
+	for _, subnet := range subnets {
+		for _, other := range rt {
+			if subnet.NetworkElementID != "" && other.NetworkElementID == subnet.NetworkElementID {
+				continue
+			}
+			if o, ok := overlap(subnet, other); ok {
+				res = append(res, o)
+			}
+		}
+	}
+	return res
+}
+
+// Route returns the mapped subnets which contain the IP, the most specific first, as traffic to the IP is routed by
+// the longest matching prefix.
+func (rt RoutingTable) Route(ip net.IP) []MappedSubnet {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	var res []MappedSubnet
+	for _, s := range rt {
+		if len(s.Cidr.IP) == len(ip) && s.Cidr.Contains(ip) {
+			res = append(res, s)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].prefixLen() > res[j].prefixLen()
+	})
+	return res
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+)
+
+func testRoutingTable() RoutingTable {
+	return NewRoutingTable(
+		[]NetworkElementResponse{
+			{ID: "ne-1", NetworkElementBody: NetworkElementBody{Name: "default", MappedSubnets: []string{"0.0.0.0/0"}}},
+			{ID: "ne-2", NetworkElementBody: NetworkElementBody{Name: "office", MappedSubnets: []string{"10.20.0.0/16", "10.30.0.0/16"}}},
+			{ID: "ne-3", NetworkElementBody: NetworkElementBody{Name: "lab", MappedSubnets: []string{"10.20.0.0/16", "2001:db8::/32"}}},
+			{ID: "ne-4", NetworkElementBody: NetworkElementBody{Name: "service", MappedService: "service.com"}},
+			{ID: "ne-5", NetworkElementBody: NetworkElementBody{Name: "lab-v6", MappedSubnets: []string{"2001:0db8:0001::/48"}}},
+		},
+		[]Metaport{{ID: "mp-1", MappedElements: []string{"ne-1", "ne-2"}}, {ID: "mp-2", MappedElements: []string{"ne-3"}}},
+		[]MetaportCluster{{ID: "mpc-1", MappedElements: []string{"ne-2", "ne-4"}}},
+	)
+}
+
+func overlapStrings(overlaps []SubnetOverlap) [][2]string {
+	res := make([][2]string, len(overlaps))
+	for i, o := range overlaps {
+		res[i] = [2]string{o.Subnet.NetworkElementID + " " + o.Subnet.Cidr.String(), o.Overlapping.NetworkElementID + " " + o.Overlapping.Cidr.String()}
+	}
+	return res
+}
+
+func TestRoutingTableOverlaps(t *testing.T) {
+	overlaps := testRoutingTable().Overlaps()
+	assert.Equal(t, [][2]string{
+		{"ne-1 0.0.0.0/0", "ne-2 10.20.0.0/16"},
+		{"ne-1 0.0.0.0/0", "ne-3 10.20.0.0/16"},
+		{"ne-1 0.0.0.0/0", "ne-2 10.30.0.0/16"},
+		{"ne-2 10.20.0.0/16", "ne-3 10.20.0.0/16"},
+		{"ne-3 2001:db8::/32", "ne-5 2001:db8:1::/48"},
+	}, overlapStrings(overlaps))
+	assert.False(t, overlaps[0].Equal())
+	assert.True(t, overlaps[3].Equal())
+}
+
+func TestRoutingTableOverlapsWith(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("10.30.1.0/24")
+	_, ipv6Cidr, _ := net.ParseCIDR("2001:db8:1::/48")
+	overlaps := testRoutingTable().OverlapsWith([]MappedSubnet{{NetworkElementID: "ne-2", Cidr: cidr}, {Cidr: ipv6Cidr}})
+	assert.Equal(t, [][2]string{
+		{"ne-1 0.0.0.0/0", "ne-2 10.30.1.0/24"},
+		{"ne-3 2001:db8::/32", " 2001:db8:1::/48"},
+		{" 2001:db8:1::/48", "ne-5 2001:db8:1::/48"},
+	}, overlapStrings(overlaps))
+}
+
+func TestRoutingTableRoute(t *testing.T) {
+	cases := map[string]struct {
+		IP       string
+		Elements []string
+	}{
+		"most-specific-first": {IP: "10.30.1.1", Elements: []string{"ne-2", "ne-1"}},
+		"ambiguous":           {IP: "10.20.1.1", Elements: []string{"ne-2", "ne-3", "ne-1"}},
+		"default":             {IP: "192.0.2.1", Elements: []string{"ne-1"}},
+		"ipv6":                {IP: "2001:db8::1", Elements: []string{"ne-3"}},
+		"ipv6-most-specific":  {IP: "2001:db8:1::1", Elements: []string{"ne-5", "ne-3"}},
+		"ipv4-mapped-ipv6":    {IP: "::ffff:10.30.1.1", Elements: []string{"ne-2", "ne-1"}},
+		"no-route":            {IP: "2001:db9::1", Elements: []string{}},
+	}
+	rt := testRoutingTable()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			elements := []string{}
+			for _, s := range rt.Route(net.ParseIP(tc.IP)) {
+				elements = append(elements, s.NetworkElementID)
+			}
+			assert.Equal(t, tc.Elements, elements)
+		})
+	}
+	routes := rt.Route(net.ParseIP("10.30.1.1"))
+	assert.Equal(t, []string{"mp-1"}, routes[0].Metaports)
+	assert.Equal(t, []string{"mpc-1"}, routes[0].MetaportClusters)
+}
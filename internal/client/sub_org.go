@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const subOrgsEndpoint string = "v1/sub_orgs"
+
+type SubOrg struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Shortname   string `json:"shortname,omitempty"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	ParentOrgID string `json:"parent_org_id,omitempty"`
+}
+
+func NewSubOrg(d *schema.ResourceData) *SubOrg {
+	res := &SubOrg{}
+	if d.HasChange("name") {
+		res.Name = d.Get("name").(string)
+	}
+	if d.HasChange("shortname") {
+		res.Shortname = d.Get("shortname").(string)
+	}
+	res.Description = d.Get("description").(string)
+	res.Enabled = d.Get("enabled").(bool)
+	return res
+}
+
+func parseSubOrg(resp []byte) (*SubOrg, error) {
+	so := &SubOrg{}
+	err := json.Unmarshal(resp, so)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse sub org response: %v", err)
+	}
+	return so, nil
+}
+
+func CreateSubOrg(ctx context.Context, c *Client, so *SubOrg) (*SubOrg, error) {
+	soUrl := fmt.Sprintf("%s/%s", c.BaseURL, subOrgsEndpoint)
+	body, err := json.Marshal(so)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert sub org to json: %v", err)
+	}
+	resp, err := c.Post(ctx, soUrl, body)
+	if err != nil {
+		return nil, err
+	}
+	return parseSubOrg(resp)
+}
+
+func UpdateSubOrg(ctx context.Context, c *Client, soID string, so *SubOrg) (*SubOrg, error) {
+	soUrl := fmt.Sprintf("%s/%s/%s", c.BaseURL, subOrgsEndpoint, soID)
+	body, err := json.Marshal(so)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert sub org to json: %v", err)
+	}
+	resp, err := c.Patch(ctx, soUrl, body)
+	if err != nil {
+		return nil, err
+	}
+	return parseSubOrg(resp)
+}
+
+func GetSubOrgByID(ctx context.Context, c *Client, soID string) (*SubOrg, error) {
+	url := fmt.Sprintf("%s/%s/%s", c.BaseURL, subOrgsEndpoint, soID)
+	resp, err := c.Get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseSubOrg(resp)
+}
+
+func ListSubOrgs(ctx context.Context, c *Client) ([]SubOrg, error) {
+	url := fmt.Sprintf("%s/%s", c.BaseURL, subOrgsEndpoint)
+	resp, err := c.Get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	var subOrgs []SubOrg
+	err = json.Unmarshal(resp, &subOrgs)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse sub orgs response: %v", err)
+	}
+	return subOrgs, nil
+}
+
+func GetSubOrgByShortname(ctx context.Context, c *Client, shortname string) (*SubOrg, error) {
+	subOrgs, err := ListSubOrgs(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	for _, so := range subOrgs {
+		if so.Shortname == shortname {
+			return &so, nil
+		}
+	}
+	return nil, nil
+}
+
+func DeleteSubOrg(ctx context.Context, c *Client, soID string) (*SubOrg, error) {
+	url := fmt.Sprintf("%s/%s/%s", c.BaseURL, subOrgsEndpoint, soID)
+	resp, err := c.Delete(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseSubOrg(resp)
+}
@@ -0,0 +1,315 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	redacted          = "REDACTED"
+	maxLoggedBodySize = 64 * 1024
+)
+
+// sensitiveKeys are json keys whose values are redacted from traced request and response bodies,
+// e.g. the api_secret and client_secret of the token request, webhook oauth2 client secrets,
+// pagerduty API keys, splunk tokens, Azure Sentinel shared keys and Google Chronicle credentials.
+// Webhook headers are redacted as a whole, since they often carry credentials, e.g. the headers of the Opsgenie
+// and ServiceNow notification channels.
+var sensitiveKeys = []string{
+	"api_secret", "client_secret", "api_key", "token", "access_token", "refresh_token", "password", "secret", "psk",
+	"shared_key", "credentials", "headers",
+}
+
+// sensitiveNestedKeys are json keys whose values are redacted only within the object of the parent key,
+// e.g. the URLs of Slack and webhook notification channels embed the credentials of Slack, Teams and other webhooks,
+// and Sumo Logic collector URLs embed the collector token, whereas other URLs are kept for debugging.
+var sensitiveNestedKeys = map[string][]string{
+	"slack_config":      {"url"},
+	"sumo_logic_config": {"url"},
+	"webhook_config":    {"url"},
+}
+
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+type attemptsKey struct{}
+
+// Tracer logs every request the client sends with tflog, so it shows up according to TF_LOG.
+// Bodies are logged only when LogBodies is set, and are redacted of secrets.
+// When HARPath is set, all requests are also written to that path as an HTTP Archive (HAR) file.
+type Tracer struct {
+	LogBodies bool
+	HARPath   string
+	Version   string
+
+	harLock sync.Mutex
+	harFile *os.File
+	// harOffset is the end of the last entry in the HAR file, where the next entry is written.
+	harOffset int64
+}
+
+// countAttempts is a retryablehttp.RequestLogHook counting the attempts made for a request.
+func countAttempts(_ retryablehttp.Logger, r *http.Request, attempt int) {
+	if attempts, ok := r.Context().Value(attemptsKey{}).(*int); ok {
+		*attempts = attempt + 1
+	}
+	if attempt > 0 {
+		tflog.Debug(r.Context(), "Retrying API request", map[string]interface{}{
+			"method":  r.Method,
+			"url":     r.URL.String(),
+			"attempt": attempt + 1,
+		})
+	}
+}
+
+func withAttemptsCounter(r *retryablehttp.Request) (*retryablehttp.Request, *int) {
+	attempts := 0
+	return r.WithContext(context.WithValue(r.Context(), attemptsKey{}, &attempts)), &attempts
+}
+
+func (t *Tracer) trace(ctx context.Context, r *retryablehttp.Request, resp *http.Response, respBody []byte, start time.Time, attempts int, err error) {
+	if t == nil {
+		return
+	}
+	latency := time.Since(start)
+	fields := map[string]interface{}{
+		"method":     r.Method,
+		"url":        r.URL.String(),
+		"latency_ms": latency.Milliseconds(),
+		"attempts":   attempts,
+	}
+	reqBody, _ := r.BodyBytes()
+	if resp != nil {
+		fields["status"] = resp.StatusCode
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	if t.LogBodies {
+		if len(reqBody) > 0 {
+			fields["request_body"] = truncate(redactBody(reqBody))
+		}
+		if len(respBody) > 0 {
+			fields["response_body"] = truncate(redactBody(respBody))
+		}
+	}
+	if err != nil || resp == nil || resp.StatusCode >= http.StatusBadRequest {
+		tflog.Warn(ctx, "API request failed", fields)
+	} else {
+		tflog.Debug(ctx, "API request", fields)
+	}
+	if t.HARPath != "" {
+		harErr := t.writeHAR(r.Request, reqBody, resp, respBody, start, latency)
+		if harErr != nil {
+			tflog.Warn(ctx, "Could not write HAR file", map[string]interface{}{"path": t.HARPath, "error": harErr.Error()})
+		}
+	}
+}
+
+func truncate(body string) string {
+	if len(body) > maxLoggedBodySize {
+		return body[:maxLoggedBodySize] + "...(truncated)"
+	}
+	return body
+}
+
+// redactBody replaces the values of sensitive keys in json bodies.
+// Bodies which are not json are returned as they are, since the API always sends and receives json.
+func redactBody(body []byte) string {
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return string(body)
+	}
+	redacted, err := json.Marshal(redactValue(parsed, ""))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+func redactValue(v interface{}, parentKey string) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, nested := range val {
+			if Contains(key, sensitiveKeys) || Contains(key, sensitiveNestedKeys[parentKey]) {
+				if s, ok := nested.(string); ok && s == "" {
+					continue
+				}
+				val[key] = redacted
+				continue
+			}
+			val[key] = redactValue(nested, key)
+		}
+		return val
+	case []interface{}:
+		for i, nested := range val {
+			val[i] = redactValue(nested, parentKey)
+		}
+		return val
+	}
+	return v
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+func harHeaders(h http.Header) []harNameValue {
+	res := []harNameValue{}
+	for name, values := range h {
+		for _, value := range values {
+			if Contains(http.CanonicalHeaderKey(name), sensitiveHeaders) {
+				value = redacted
+			}
+			res = append(res, harNameValue{Name: name, Value: value})
+		}
+	}
+	return res
+}
+
+const harEntriesEnd = "\n]}}\n"
+
+// writeHAR appends the request to the HAR file. Every entry is written over the end of the entries list,
+// followed by a new end, so the file is complete even if terraform stops the provider abruptly.
+func (t *Tracer) writeHAR(r *http.Request, reqBody []byte, resp *http.Response, respBody []byte, start time.Time, latency time.Duration) error {
+	ms := float64(latency.Microseconds()) / 1000
+	entry := harEntry{
+		StartedDateTime: start.Format(time.RFC3339Nano),
+		Time:            ms,
+		Request: harRequest{
+			Method:      r.Method,
+			URL:         r.URL.String(),
+			HTTPVersion: "HTTP/1.1",
+			Headers:     harHeaders(r.Header),
+			QueryString: []harNameValue{},
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			HTTPVersion: "HTTP/1.1",
+			Headers:     []harNameValue{},
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(respBody),
+		},
+		Timings: harTimings{Send: 0, Wait: ms, Receive: 0},
+	}
+	for key, values := range r.URL.Query() {
+		for _, value := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: key, Value: value})
+		}
+	}
+	if len(reqBody) > 0 {
+		entry.Request.PostData = &harPostData{MimeType: r.Header.Get("Content-Type"), Text: redactBody(reqBody)}
+	}
+	if resp != nil {
+		entry.Response.Status = resp.StatusCode
+		entry.Response.StatusText = http.StatusText(resp.StatusCode)
+		entry.Response.Headers = harHeaders(resp.Header)
+		entry.Response.Content = harContent{
+			Size:     len(respBody),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     redactBody(respBody),
+		}
+	}
+
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("could not convert HAR entry to json: %v", err)
+	}
+
+	t.harLock.Lock()
+	defer t.harLock.Unlock()
+	separator := ",\n"
+	if t.harFile == nil {
+		if err = t.createHAR(); err != nil {
+			return err
+		}
+		separator = ""
+	}
+	chunk := append(append([]byte(separator), body...), harEntriesEnd...)
+	if _, err = t.harFile.WriteAt(chunk, t.harOffset); err != nil {
+		return fmt.Errorf("could not write HAR file: %v", err)
+	}
+	t.harOffset += int64(len(chunk) - len(harEntriesEnd))
+	return nil
+}
+
+// createHAR creates the HAR file and writes the log's header, up to the start of its entries.
+func (t *Tracer) createHAR() error {
+	creator, err := json.Marshal(map[string]string{"name": "terraform-provider-pfptmeta", "version": t.Version})
+	if err != nil {
+		return fmt.Errorf("could not convert HAR creator to json: %v", err)
+	}
+	header := fmt.Sprintf("{\"log\": {\"version\": \"1.2\", \"creator\": %s, \"entries\": [\n", creator)
+	f, err := os.OpenFile(t.HARPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("could not create HAR file: %v", err)
+	}
+	if _, err = f.WriteString(header + harEntriesEnd); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write HAR file: %v", err)
+	}
+	t.harFile = f
+	t.harOffset = int64(len(header))
+	return nil
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactBody(t *testing.T) {
+	cases := map[string]struct {
+		Body     string
+		Expected string
+	}{
+		"token-request": {
+			Body:     `{"client_id":"key","client_secret":"secret","grant_type":"client_credentials","scope":"org:org"}`,
+			Expected: `{"client_id":"key","client_secret":"REDACTED","grant_type":"client_credentials","scope":"org:org"}`,
+		},
+		"nested-webhook-secret": {
+			Body:     `{"webhook_config":{"auth":{"oauth2_config":{"client_id":"id","client_secret":"secret"}}}}`,
+			Expected: `{"webhook_config":{"auth":{"oauth2_config":{"client_id":"id","client_secret":"REDACTED"}}}}`,
+		},
+		"list-of-channels": {
+			Body:     `[{"pagerduty_config":{"api_key":"key"}},{"splunk_http_config":{"token":"token"}}]`,
+			Expected: `[{"pagerduty_config":{"api_key":"REDACTED"}},{"splunk_http_config":{"token":"REDACTED"}}]`,
+		},
+		"webhook-urls": {
+			Body:     `{"slack_config":{"url":"https://hooks.slack.com/services/T0/B0/x"},"webhook_config":{"url":"https://example.webhook.office.com/x"}}`,
+			Expected: `{"slack_config":{"url":"REDACTED"},"webhook_config":{"url":"REDACTED"}}`,
+		},
+		"siem-secrets": {
+			Body: `{"siem_config":{"azure_sentinel_config":{"shared_key":"key","workspace_id":"ws"},` +
+				`"google_chronicle_config":{"credentials":"service-account-json","customer_id":"c"},` +
+				`"sumo_logic_config":{"url":"https://endpoint1.collection.sumologic.com/receiver/v1/http/token"}}}`,
+			Expected: `{"siem_config":{"azure_sentinel_config":{"shared_key":"REDACTED","workspace_id":"ws"},` +
+				`"google_chronicle_config":{"credentials":"REDACTED","customer_id":"c"},` +
+				`"sumo_logic_config":{"url":"REDACTED"}}}`,
+		},
+		"other-urls": {
+			Body:     `{"oauth2_config":{"token_url":"https://idp.example.com/token"},"url":"https://collector.example.com"}`,
+			Expected: `{"oauth2_config":{"token_url":"https://idp.example.com/token"},"url":"https://collector.example.com"}`,
+		},
+		"empty-secret": {
+			Body:     `{"api_key":""}`,
+			Expected: `{"api_key":""}`,
+		},
+		"not-json": {
+			Body:     "ok",
+			Expected: "ok",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, redactBody([]byte(tc.Body)))
+		})
+	}
+}
+
+func TestHARFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"id":"nch-123","pagerduty_config":{"api_key":"key"}}`))
+	}))
+	defer server.Close()
+	harPath := filepath.Join(t.TempDir(), "trace.har")
+	client := &Client{
+		HTTP:              retryablehttp.NewClient(),
+		BaseURL:           server.URL,
+		Credentials:       &Credentials{},
+		Token:             &Token{Token: "secret-token", Expiry: 300},
+		TokenCreationTime: time.Now().Unix(),
+		Tracer:            &Tracer{HARPath: harPath, Version: "dev"},
+	}
+	client.HTTP.RequestLogHook = countAttempts
+	body := `{"name":"pagerduty","pagerduty_config":{"api_key":"key"}}`
+	resp, err := client.Post(context.Background(), server.URL+"/v1/notification_channels", []byte(body))
+	assert.Nil(t, err)
+	assert.Contains(t, string(resp), `"api_key":"key"`, "response returned to the caller should not be redacted")
+	_, err = client.Get(context.Background(), server.URL+"/v1/notification_channels/nch-123", nil)
+	assert.Nil(t, err)
+
+	harBytes, err := os.ReadFile(harPath)
+	if err != nil {
+		t.Fatalf("could not read HAR file: %v", err)
+	}
+	assert.NotContains(t, string(harBytes), "secret-token")
+	assert.NotContains(t, string(harBytes), `\"api_key\":\"key\"`)
+	har := &struct {
+		Log struct {
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}{}
+	assert.Nil(t, json.Unmarshal(harBytes, har))
+	if assert.Len(t, har.Log.Entries, 2) {
+		entry := har.Log.Entries[0]
+		assert.Equal(t, http.MethodPost, entry.Request.Method)
+		assert.True(t, strings.HasSuffix(entry.Request.URL, "/v1/notification_channels"))
+		assert.Equal(t, `{"name":"pagerduty","pagerduty_config":{"api_key":"REDACTED"}}`, entry.Request.PostData.Text)
+		assert.Equal(t, http.StatusOK, entry.Response.Status)
+		assert.Equal(t, `{"id":"nch-123","pagerduty_config":{"api_key":"REDACTED"}}`, entry.Response.Content.Text)
+		assert.Equal(t, http.MethodGet, har.Log.Entries[1].Request.Method)
+	}
+}
@@ -14,12 +14,32 @@ type GreTunnelConfig struct {
 	SourceIps []string `json:"source_ips"`
 }
 
+type IpsecTunnelConfig struct {
+	SourceIps    []string `json:"source_ips"`
+	IkeVersion   int      `json:"ike_version,omitempty"`
+	Psk          string   `json:"psk,omitempty"`
+	IkeProposals []string `json:"ike_proposals,omitempty"`
+	EspProposals []string `json:"esp_proposals,omitempty"`
+}
+
+// TunnelEndpoint is the Proofpoint side of the tunnel in a POP, with the inner addresses of both sides of the tunnel.
+type TunnelEndpoint struct {
+	Pop               string `json:"pop"`
+	Ip                string `json:"ip"`
+	InnerIp           string `json:"inner_ip"`
+	PeerInnerIp       string `json:"peer_inner_ip"`
+	InnerPrefixLength int    `json:"inner_prefix_length"`
+}
+
 type Tunnel struct {
-	ID          string           `json:"id,omitempty"`
-	Name        string           `json:"name,omitempty"`
-	Description string           `json:"description,omitempty"`
-	Enabled     *bool            `json:"enabled,omitempty"`
-	GreConfig   *GreTunnelConfig `json:"gre_config,omitempty"`
+	ID          string             `json:"id,omitempty"`
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enabled     *bool              `json:"enabled,omitempty"`
+	GreConfig   *GreTunnelConfig   `json:"gre_config,omitempty"`
+	IpsecConfig *IpsecTunnelConfig `json:"ipsec_config,omitempty"`
+	Pops        []string           `json:"pops,omitempty"`
+	Endpoints   []TunnelEndpoint   `json:"endpoints,omitempty"`
 }
 
 func ep(c *Client, tId *string) string {
@@ -40,12 +60,17 @@ func parseTunnel(resp []byte) (*Tunnel, error) {
 	if t.GreConfig != nil && len(t.GreConfig.SourceIps) == 0 {
 		t.GreConfig = nil
 	}
+	if t.IpsecConfig != nil && len(t.IpsecConfig.SourceIps) == 0 {
+		t.IpsecConfig = nil
+	}
 	return t, nil
 }
 
 func tunnelJsonMarshal(t *Tunnel) ([]byte, error) {
 	tcopy := *t
-	tcopy.GreConfig = nil // this field is readonly
+	// these fields are readonly
+	tcopy.GreConfig = nil
+	tcopy.Endpoints = nil
 	b, err := json.Marshal(tcopy)
 	if err != nil {
 		return nil, fmt.Errorf("could not convert tunnel to json: %v",
@@ -0,0 +1,347 @@
+package logreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4DateFormat = "20060102T150405Z"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+var sigV4AuthorizationPattern = regexp.MustCompile(
+	"^" + sigV4Algorithm + " Credential=([^/]+)/([0-9]{8})/([a-z0-9-]+)/s3/aws4_request, ?SignedHeaders=([a-z0-9;-]+), ?Signature=([0-9a-f]{64})$")
+
+// S3Object is an object stored in the S3 stand-in. Body holds the decompressed content.
+type S3Object struct {
+	Key             string
+	ContentEncoding string
+	Body            []byte
+}
+
+// S3Credentials are the AWS credentials the S3 stand-in verifies Signature Version 4 requests with.
+type S3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+}
+
+// S3Receiver is a path-style S3 compatible stub for a single bucket, as described by client.S3Config.
+// Objects must be newline delimited JSON log records, gzip compressed when Compress is set.
+type S3Receiver struct {
+	recorder
+	config      client.S3Config
+	credentials S3Credentials
+	server      *httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]S3Object
+}
+
+type s3Error struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string   `xml:"Code"`
+	Message  string   `xml:"Message"`
+	Resource string   `xml:"Resource"`
+}
+
+// NewS3Receiver starts an S3 stub serving the bucket of conf.
+func NewS3Receiver(conf client.S3Config, credentials S3Credentials) *S3Receiver {
+	r := &S3Receiver{config: conf, credentials: credentials, objects: map[string]S3Object{}}
+	r.server = httptest.NewServer(http.HandlerFunc(r.handle))
+	return r
+}
+
+// URL returns the endpoint of the stub.
+func (r *S3Receiver) URL() string {
+	return r.server.URL
+}
+
+// Config returns the S3 configuration served by the stub.
+func (r *S3Receiver) Config() *client.S3Config {
+	conf := r.config
+	return &conf
+}
+
+// Objects returns the valid objects stored so far, keyed by object key.
+func (r *S3Receiver) Objects() map[string]S3Object {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make(map[string]S3Object, len(r.objects))
+	for k, v := range r.objects {
+		res[k] = v
+	}
+	return res
+}
+
+// WaitForObjects waits until at least n valid objects were stored.
+func (r *S3Receiver) WaitForObjects(n int, timeout time.Duration) (map[string]S3Object, error) {
+	err := waitFor(n, timeout, func() int { return len(r.Objects()) })
+	return r.Objects(), err
+}
+
+// Close shuts the stub down.
+func (r *S3Receiver) Close() {
+	r.server.Close()
+}
+
+func (r *S3Receiver) handle(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		r.writeError(w, req, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+	if code, err := r.authenticate(req, body); err != nil {
+		status := http.StatusForbidden
+		if code == "XAmzContentSHA256Mismatch" || code == "AuthorizationHeaderMalformed" {
+			status = http.StatusBadRequest
+		}
+		r.writeError(w, req, status, code, err.Error())
+		return
+	}
+	bucket, key := splitS3Path(req.URL.Path)
+	if bucket != r.config.Bucket {
+		r.writeError(w, req, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+	switch {
+	case key == "" && (req.Method == http.MethodHead || req.Method == http.MethodGet):
+		w.WriteHeader(http.StatusOK)
+	case key != "" && req.Method == http.MethodPut:
+		r.putObject(w, req, key, body)
+	case key != "" && (req.Method == http.MethodGet || req.Method == http.MethodHead):
+		r.mu.Lock()
+		obj, ok := r.objects[key]
+		r.mu.Unlock()
+		if !ok {
+			r.writeError(w, req, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		if req.Method == http.MethodGet {
+			w.Write(obj.Body)
+		}
+	default:
+		r.writeError(w, req, http.StatusMethodNotAllowed, "MethodNotAllowed",
+			"The specified method is not allowed against this resource.")
+	}
+}
+
+func (r *S3Receiver) putObject(w http.ResponseWriter, req *http.Request, key string, body []byte) {
+	if !strings.HasPrefix(key, r.config.Prefix) {
+		r.writeError(w, req, http.StatusBadRequest, "InvalidArgument",
+			fmt.Sprintf("object key %q does not start with the configured prefix %q", key, r.config.Prefix))
+		return
+	}
+	content := body
+	if r.config.Compress {
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		if err == nil {
+			content, err = io.ReadAll(gz)
+		}
+		if err != nil {
+			r.writeError(w, req, http.StatusBadRequest, "InvalidArgument",
+				fmt.Sprintf("object %q is not gzip compressed: %v", key, err))
+			return
+		}
+	}
+	if err := validateLogRecords(content); err != nil {
+		r.writeError(w, req, http.StatusBadRequest, "InvalidArgument", fmt.Sprintf("object %q: %v", key, err))
+		return
+	}
+	r.mu.Lock()
+	r.objects[key] = S3Object{Key: key, ContentEncoding: req.Header.Get("Content-Encoding"), Body: content}
+	r.mu.Unlock()
+	w.Header().Set("ETag", fmt.Sprintf("\"%x\"", sha256.Sum256(body)))
+	w.WriteHeader(http.StatusOK)
+}
+
+// validateLogRecords checks that every non-empty line is a JSON object.
+func validateLogRecords(content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	records := 0
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal(text, &record); err != nil {
+			return fmt.Errorf("line %d is not a JSON log record: %v", line, err)
+		}
+		records++
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if records == 0 {
+		return fmt.Errorf("object does not contain any log records")
+	}
+	return nil
+}
+
+// authenticate verifies the AWS Signature Version 4 of the request and returns the S3 error code on failure.
+func (r *S3Receiver) authenticate(req *http.Request, body []byte) (string, error) {
+	header := req.Header.Get("Authorization")
+	if header == "" {
+		return "AccessDenied", fmt.Errorf("Access Denied")
+	}
+	match := sigV4AuthorizationPattern.FindStringSubmatch(header)
+	if match == nil {
+		return "AuthorizationHeaderMalformed", fmt.Errorf("the authorization header %q is malformed", header)
+	}
+	accessKeyID, date, region, signedHeaders, signature := match[1], match[2], match[3], match[4], match[5]
+	if accessKeyID != r.credentials.AccessKeyID {
+		return "InvalidAccessKeyId", fmt.Errorf("the AWS access key ID %q does not exist in our records", accessKeyID)
+	}
+	if region != r.credentials.Region {
+		return "AuthorizationHeaderMalformed", fmt.Errorf("the region %q is wrong, expecting %q", region, r.credentials.Region)
+	}
+	amzDate := req.Header.Get("X-Amz-Date")
+	if _, err := time.Parse(sigV4DateFormat, amzDate); err != nil || !strings.HasPrefix(amzDate, date) {
+		return "AccessDenied", fmt.Errorf("X-Amz-Date %q is missing or does not match the credential scope", amzDate)
+	}
+	headers := strings.Split(signedHeaders, ";")
+	if !client.Contains("host", headers) || !client.Contains("x-amz-date", headers) || !client.Contains("x-amz-content-sha256", headers) {
+		return "AccessDenied", fmt.Errorf("the signed headers must include host, x-amz-content-sha256 and x-amz-date")
+	}
+	payloadHash := req.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash != unsignedPayload {
+		sum := sha256.Sum256(body)
+		if payloadHash != hex.EncodeToString(sum[:]) {
+			return "XAmzContentSHA256Mismatch", fmt.Errorf("the provided x-amz-content-sha256 header does not match what was computed")
+		}
+	}
+	expected := sigV4Signature(req, headers, payloadHash, r.credentials.SecretAccessKey, date, region)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return "SignatureDoesNotMatch", fmt.Errorf("the request signature we calculated does not match the signature you provided")
+	}
+	return "", nil
+}
+
+// SignS3Request signs req with AWS Signature Version 4 for the S3 service.
+func SignS3Request(req *http.Request, body []byte, credentials S3Credentials, now time.Time) {
+	amzDate := now.UTC().Format(sigV4DateFormat)
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	headers := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	date := amzDate[:8]
+	signature := sigV4Signature(req, headers, payloadHash, credentials.SecretAccessKey, date, credentials.Region)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s/%s/s3/aws4_request, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, credentials.AccessKeyID, date, credentials.Region, strings.Join(headers, ";"), signature))
+}
+
+func sigV4Signature(req *http.Request, signedHeaders []string, payloadHash, secret, date, region string) string {
+	sort.Strings(signedHeaders)
+	var canonicalHeaders strings.Builder
+	for _, h := range signedHeaders {
+		value := req.Header.Get(h)
+		if h == "host" {
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		}
+		canonicalHeaders.WriteString(h + ":" + strings.Join(strings.Fields(value), " ") + "\n")
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm, req.Header.Get("X-Amz-Date"), scope, hex.EncodeToString(requestHash[:]),
+	}, "\n")
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	for _, part := range []string{region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalURI URI-encodes every path segment, as S3 does not normalize paths.
+func canonicalURI(path string) string {
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = uriEncode(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(query url.Values) string {
+	var pairs []string
+	for k, values := range query {
+		for _, v := range values {
+			pairs = append(pairs, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode encodes everything but the unreserved characters of RFC 3986.
+func uriEncode(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func splitS3Path(path string) (string, string) {
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.Index(path, "/"); i >= 0 {
+		return path[:i], path[i+1:]
+	}
+	return path, ""
+}
+
+func (r *S3Receiver) writeError(w http.ResponseWriter, req *http.Request, status int, code, message string) {
+	r.fail("s3: %s %s: %s: %s", req.Method, req.URL.Path, code, message)
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	if req.Method != http.MethodHead {
+		xml.NewEncoder(w).Encode(s3Error{Code: code, Message: message, Resource: req.URL.Path})
+	}
+}
@@ -0,0 +1,215 @@
+package logreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status codes of the Splunk HTTP Event Collector responses.
+const (
+	hecSuccess              = 0
+	hecTokenRequired        = 2
+	hecInvalidAuthorization = 3
+	hecInvalidToken         = 4
+	hecNoData               = 5
+	hecInvalidDataFormat    = 6
+	hecEventFieldRequired   = 12
+	hecEventFieldBlank      = 13
+	hecHealthy              = 17
+)
+
+// HECEvent is an event received by the Splunk HTTP Event Collector stand-in.
+type HECEvent struct {
+	Time       json.Number            `json:"time,omitempty"`
+	Host       string                 `json:"host,omitempty"`
+	Source     string                 `json:"source,omitempty"`
+	Sourcetype string                 `json:"sourcetype,omitempty"`
+	Index      string                 `json:"index,omitempty"`
+	Fields     map[string]interface{} `json:"fields,omitempty"`
+	Event      json.RawMessage        `json:"event"`
+}
+
+type hecResponse struct {
+	Text string `json:"text"`
+	Code int    `json:"code"`
+}
+
+// SplunkHECReceiver is an HTTP Event Collector compatible receiver, as described by client.SplunkHttpConfig.
+type SplunkHECReceiver struct {
+	recorder
+	token  string
+	server *httptest.Server
+
+	mu     sync.Mutex
+	events []HECEvent
+}
+
+// NewSplunkHECReceiver starts an HTTP Event Collector accepting the given token.
+// When useTLS is set the receiver serves HTTPS with a certificate returned by Certificate.
+func NewSplunkHECReceiver(token string, useTLS bool) *SplunkHECReceiver {
+	r := &SplunkHECReceiver{token: token}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/services/collector", r.handleEvents)
+	mux.HandleFunc("/services/collector/event", r.handleEvents)
+	mux.HandleFunc("/services/collector/event/1.0", r.handleEvents)
+	mux.HandleFunc("/services/collector/health", r.handleHealth)
+	mux.HandleFunc("/services/collector/health/1.0", r.handleHealth)
+	if useTLS {
+		r.server = httptest.NewTLSServer(mux)
+	} else {
+		r.server = httptest.NewServer(mux)
+	}
+	return r
+}
+
+// URL returns the base URL of the receiver.
+func (r *SplunkHECReceiver) URL() string {
+	return r.server.URL
+}
+
+// Certificate returns the PEM encoded certificate of an HTTPS receiver.
+func (r *SplunkHECReceiver) Certificate() string {
+	if r.server.TLS == nil {
+		return ""
+	}
+	return certificatePEM(r.server.Certificate().Raw)
+}
+
+// Client returns an HTTP client trusting the receiver certificate.
+func (r *SplunkHECReceiver) Client() *http.Client {
+	return r.server.Client()
+}
+
+// Config returns the Splunk configuration pointing at the receiver.
+func (r *SplunkHECReceiver) Config() *client.SplunkHttpConfig {
+	return &client.SplunkHttpConfig{
+		Certificate:        r.Certificate(),
+		PubliclyAccessible: false,
+		Token:              r.token,
+		Url:                r.server.URL,
+	}
+}
+
+// Events returns the valid events received so far.
+func (r *SplunkHECReceiver) Events() []HECEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]HECEvent(nil), r.events...)
+}
+
+// WaitForEvents waits until at least n valid events were received.
+func (r *SplunkHECReceiver) WaitForEvents(n int, timeout time.Duration) ([]HECEvent, error) {
+	err := waitFor(n, timeout, func() int { return len(r.Events()) })
+	return r.Events(), err
+}
+
+// Close shuts the receiver down.
+func (r *SplunkHECReceiver) Close() {
+	r.server.Close()
+}
+
+func (r *SplunkHECReceiver) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeHECResponse(w, http.StatusOK, hecHealthy, "HEC is healthy")
+}
+
+func (r *SplunkHECReceiver) handleEvents(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeHECResponse(w, http.StatusMethodNotAllowed, hecInvalidDataFormat, "Method not allowed")
+		return
+	}
+	if status, code, text := r.authorize(req.Header.Get("Authorization")); code != hecSuccess {
+		r.fail("splunk: %s", text)
+		writeHECResponse(w, status, code, text)
+		return
+	}
+	body, err := readBody(req)
+	if err != nil {
+		r.fail("splunk: %v", err)
+		writeHECResponse(w, http.StatusBadRequest, hecInvalidDataFormat, "Invalid data format")
+		return
+	}
+	events, code, err := parseHECEvents(body)
+	if err != nil {
+		r.fail("splunk: %v", err)
+		writeHECResponse(w, http.StatusBadRequest, code, err.Error())
+		return
+	}
+	r.mu.Lock()
+	r.events = append(r.events, events...)
+	r.mu.Unlock()
+	writeHECResponse(w, http.StatusOK, hecSuccess, "Success")
+}
+
+// authorize checks the "Authorization: Splunk <token>" header and returns the HEC status of the check.
+func (r *SplunkHECReceiver) authorize(header string) (int, int, string) {
+	if header == "" {
+		return http.StatusUnauthorized, hecTokenRequired, "Token is required"
+	}
+	token := strings.TrimPrefix(header, "Splunk ")
+	if token == header {
+		return http.StatusUnauthorized, hecInvalidAuthorization, "Invalid authorization"
+	}
+	if token != r.token {
+		return http.StatusForbidden, hecInvalidToken, "Invalid token"
+	}
+	return http.StatusOK, hecSuccess, ""
+}
+
+// parseHECEvents parses a batch of concatenated JSON event objects.
+func parseHECEvents(body []byte) ([]HECEvent, int, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, hecNoData, errors.New("No data")
+	}
+	var events []HECEvent
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	for i := 0; decoder.More(); i++ {
+		var event HECEvent
+		if err := decoder.Decode(&event); err != nil {
+			return nil, hecInvalidDataFormat, fmt.Errorf("Invalid data format: event %d: %v", i, err)
+		}
+		if event.Event == nil || string(event.Event) == "null" {
+			return nil, hecEventFieldRequired, fmt.Errorf("Event field is required: event %d", i)
+		}
+		if string(event.Event) == `""` {
+			return nil, hecEventFieldBlank, fmt.Errorf("Event field cannot be blank: event %d", i)
+		}
+		if event.Time != "" {
+			if _, err := event.Time.Float64(); err != nil {
+				return nil, hecInvalidDataFormat, fmt.Errorf("Invalid data format: event %d has a non numeric time", i)
+			}
+		}
+		events = append(events, event)
+	}
+	return events, hecSuccess, nil
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	var reader io.Reader = req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip body: %v", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	return io.ReadAll(reader)
+}
+
+func writeHECResponse(w http.ResponseWriter, status, code int, text string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(hecResponse{Text: text, Code: code})
+}
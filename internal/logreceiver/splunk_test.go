@@ -0,0 +1,132 @@
+package logreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestSplunkHECReceiver(t *testing.T) {
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	gz.Write([]byte(`{"event": {"type": "traffic"}}`))
+	gz.Close()
+
+	cases := map[string]struct {
+		Path          string
+		Authorization string
+		Gzip          bool
+		Body          []byte
+		Status        int
+		Code          int
+		Events        int
+	}{
+		"single-event": {
+			Path:          "/services/collector/event",
+			Authorization: "Splunk token",
+			Body:          []byte(`{"time": 1666173600.123, "sourcetype": "proofpoint:meta", "event": {"type": "traffic"}}`),
+			Status:        http.StatusOK,
+			Code:          hecSuccess,
+			Events:        1,
+		},
+		"batched-events": {
+			Path:          "/services/collector",
+			Authorization: "Splunk token",
+			Body:          []byte(`{"event": "first"}{"event": "second"}` + "\n" + `{"event": {"type": "api"}}`),
+			Status:        http.StatusOK,
+			Code:          hecSuccess,
+			Events:        3,
+		},
+		"gzip-event": {
+			Path:          "/services/collector/event",
+			Authorization: "Splunk token",
+			Gzip:          true,
+			Body:          gzipped.Bytes(),
+			Status:        http.StatusOK,
+			Code:          hecSuccess,
+			Events:        1,
+		},
+		"missing-token": {
+			Path:   "/services/collector/event",
+			Body:   []byte(`{"event": "first"}`),
+			Status: http.StatusUnauthorized,
+			Code:   hecTokenRequired,
+		},
+		"invalid-authorization": {
+			Path:          "/services/collector/event",
+			Authorization: "Bearer token",
+			Body:          []byte(`{"event": "first"}`),
+			Status:        http.StatusUnauthorized,
+			Code:          hecInvalidAuthorization,
+		},
+		"invalid-token": {
+			Path:          "/services/collector/event",
+			Authorization: "Splunk other",
+			Body:          []byte(`{"event": "first"}`),
+			Status:        http.StatusForbidden,
+			Code:          hecInvalidToken,
+		},
+		"no-data": {
+			Path:          "/services/collector/event",
+			Authorization: "Splunk token",
+			Status:        http.StatusBadRequest,
+			Code:          hecNoData,
+		},
+		"missing-event-field": {
+			Path:          "/services/collector/event",
+			Authorization: "Splunk token",
+			Body:          []byte(`{"event": "first"}{"source": "meta"}`),
+			Status:        http.StatusBadRequest,
+			Code:          hecEventFieldRequired,
+		},
+		"invalid-json": {
+			Path:          "/services/collector/event",
+			Authorization: "Splunk token",
+			Body:          []byte(`{"event": "first"`),
+			Status:        http.StatusBadRequest,
+			Code:          hecInvalidDataFormat,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewSplunkHECReceiver("token", true)
+			defer r.Close()
+
+			req, err := http.NewRequest(http.MethodPost, r.URL()+tc.Path, bytes.NewReader(tc.Body))
+			require.NoError(t, err)
+			if tc.Authorization != "" {
+				req.Header.Set("Authorization", tc.Authorization)
+			}
+			if tc.Gzip {
+				req.Header.Set("Content-Encoding", "gzip")
+			}
+			resp, err := r.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			var body hecResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tc.Status, resp.StatusCode)
+			assert.Equal(t, tc.Code, body.Code)
+			assert.Len(t, r.Events(), tc.Events)
+			assert.Equal(t, tc.Code != hecSuccess, len(r.Errors()) > 0)
+		})
+	}
+}
+
+func TestSplunkHECReceiverConfig(t *testing.T) {
+	r := NewSplunkHECReceiver("token", true)
+	defer r.Close()
+	conf := r.Config()
+	assert.Equal(t, "token", conf.Token)
+	assert.Equal(t, r.URL(), conf.Url)
+	assert.Contains(t, conf.Certificate, "-----BEGIN CERTIFICATE-----")
+
+	plain := NewSplunkHECReceiver("token", false)
+	defer plain.Close()
+	assert.Empty(t, plain.Config().Certificate)
+}
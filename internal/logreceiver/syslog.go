@@ -0,0 +1,310 @@
+package logreceiver
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const maxSyslogMessageSize = 64 * 1024
+
+var (
+	syslogPriorityPattern = regexp.MustCompile("^<([0-9]{1,3})>")
+	cefSeverities         = []string{"Unknown", "Low", "Medium", "High", "Very-High"}
+	cefExtensionKey       = regexp.MustCompile("^[A-Za-z0-9_.\\[\\]]+=")
+)
+
+// SyslogMessage is a syslog message carrying a CEF event.
+type SyslogMessage struct {
+	Facility int
+	Severity int
+	// Header is the syslog header between the priority and the CEF event.
+	Header string
+	CEF    string
+}
+
+// SyslogReceiver listens for syslog CEF messages over UDP, TCP or TLS, as described by client.SyslogConfig.
+type SyslogReceiver struct {
+	recorder
+	proto       string
+	certificate string
+	listener    net.Listener
+	conn        net.PacketConn
+	wg          sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []SyslogMessage
+	conns    map[net.Conn]struct{}
+}
+
+// NewSyslogReceiver starts a syslog listener on localhost. proto is one of udp, tcp or tls.
+// TLS listeners use a self-signed certificate returned by Certificate.
+func NewSyslogReceiver(proto string) (*SyslogReceiver, error) {
+	r := &SyslogReceiver{proto: proto, conns: map[net.Conn]struct{}{}}
+	var err error
+	switch proto {
+	case "udp":
+		r.conn, err = net.ListenPacket("udp", "127.0.0.1:0")
+	case "tcp":
+		r.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	case "tls":
+		var cert tls.Certificate
+		cert, r.certificate, err = selfSignedCertificate()
+		if err != nil {
+			return nil, err
+		}
+		r.listener, err = tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	default:
+		return nil, fmt.Errorf("unsupported syslog proto %q, expected udp, tcp or tls", proto)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.wg.Add(1)
+	if r.conn != nil {
+		go r.servePackets()
+	} else {
+		go r.serveStreams()
+	}
+	return r, nil
+}
+
+// Addr returns the address the receiver listens on.
+func (r *SyslogReceiver) Addr() net.Addr {
+	if r.conn != nil {
+		return r.conn.LocalAddr()
+	}
+	return r.listener.Addr()
+}
+
+// Certificate returns the PEM encoded certificate of a TLS receiver.
+func (r *SyslogReceiver) Certificate() string {
+	return r.certificate
+}
+
+// Config returns the syslog configuration pointing at the receiver. TLS receivers use the tcp proto.
+func (r *SyslogReceiver) Config() *client.SyslogConfig {
+	proto := r.proto
+	if proto == "tls" {
+		proto = "tcp"
+	}
+	var port int
+	switch addr := r.Addr().(type) {
+	case *net.UDPAddr:
+		port = addr.Port
+	case *net.TCPAddr:
+		port = addr.Port
+	}
+	return &client.SyslogConfig{Host: "localhost", Port: port, Proto: proto}
+}
+
+// Messages returns the valid messages received so far.
+func (r *SyslogReceiver) Messages() []SyslogMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]SyslogMessage(nil), r.messages...)
+}
+
+// WaitForMessages waits until at least n valid messages were received.
+func (r *SyslogReceiver) WaitForMessages(n int, timeout time.Duration) ([]SyslogMessage, error) {
+	err := waitFor(n, timeout, func() int { return len(r.Messages()) })
+	return r.Messages(), err
+}
+
+// Close stops the listener and waits for open connections to finish.
+func (r *SyslogReceiver) Close() error {
+	var err error
+	if r.conn != nil {
+		err = r.conn.Close()
+	} else {
+		err = r.listener.Close()
+		r.mu.Lock()
+		for c := range r.conns {
+			c.Close()
+		}
+		r.mu.Unlock()
+	}
+	r.wg.Wait()
+	return err
+}
+
+func (r *SyslogReceiver) servePackets() {
+	defer r.wg.Done()
+	buf := make([]byte, maxSyslogMessageSize)
+	for {
+		n, _, err := r.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		r.receive(string(buf[:n]))
+	}
+}
+
+func (r *SyslogReceiver) serveStreams() {
+	defer r.wg.Done()
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			return
+		}
+		r.mu.Lock()
+		r.conns[conn] = struct{}{}
+		r.mu.Unlock()
+		r.wg.Add(1)
+		go r.serveStream(conn)
+	}
+}
+
+func (r *SyslogReceiver) serveStream(conn net.Conn) {
+	defer r.wg.Done()
+	defer func() {
+		r.mu.Lock()
+		delete(r.conns, conn)
+		r.mu.Unlock()
+		conn.Close()
+	}()
+	reader := bufio.NewReader(conn)
+	for {
+		msg, err := readFrame(reader)
+		if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			r.fail("%v", err)
+			return
+		}
+		r.receive(msg)
+	}
+}
+
+// readFrame reads a single message using octet counting or non-transparent framing (RFC 6587).
+func readFrame(reader *bufio.Reader) (string, error) {
+	first, err := reader.Peek(1)
+	if err != nil {
+		return "", err
+	}
+	if first[0] >= '1' && first[0] <= '9' {
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			return "", fmt.Errorf("invalid octet counting frame: %v", err)
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+		if err != nil || n > maxSyslogMessageSize {
+			return "", fmt.Errorf("invalid octet counting frame length %q", length)
+		}
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return "", fmt.Errorf("truncated octet counting frame: %v", err)
+		}
+		return string(buf), nil
+	}
+	msg, err := reader.ReadString('\n')
+	if err != nil {
+		if errors.Is(err, io.EOF) && msg != "" {
+			return "", fmt.Errorf("message %q is not terminated by a newline", msg)
+		}
+		return "", err
+	}
+	return strings.TrimRight(msg, "\r\n"), nil
+}
+
+func (r *SyslogReceiver) receive(raw string) {
+	msg, err := ParseSyslogMessage(raw)
+	if err != nil {
+		r.fail("%v", err)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, *msg)
+}
+
+// ParseSyslogMessage parses an RFC 5424 or RFC 3164 syslog message whose content is a CEF event.
+func ParseSyslogMessage(raw string) (*SyslogMessage, error) {
+	match := syslogPriorityPattern.FindStringSubmatch(raw)
+	if match == nil {
+		return nil, fmt.Errorf("syslog message %q does not start with a priority", raw)
+	}
+	priority, _ := strconv.Atoi(match[1])
+	if priority > 191 {
+		return nil, fmt.Errorf("syslog message %q has an invalid priority %d", raw, priority)
+	}
+	rest := raw[len(match[0]):]
+	i := strings.Index(rest, "CEF:")
+	if i < 0 {
+		return nil, fmt.Errorf("syslog message %q does not contain a CEF event", raw)
+	}
+	cef := rest[i:]
+	if err := ValidateCEF(cef); err != nil {
+		return nil, err
+	}
+	return &SyslogMessage{
+		Facility: priority / 8,
+		Severity: priority % 8,
+		Header:   strings.TrimSpace(rest[:i]),
+		CEF:      cef,
+	}, nil
+}
+
+// ValidateCEF validates the header of a Common Event Format event:
+// CEF:Version|Device Vendor|Device Product|Device Version|Signature ID|Name|Severity|Extension
+func ValidateCEF(event string) error {
+	fields := splitCEFHeader(strings.TrimPrefix(event, "CEF:"))
+	if !strings.HasPrefix(event, "CEF:") || len(fields) != 8 {
+		return fmt.Errorf("CEF event %q must have 7 header fields followed by an extension", event)
+	}
+	if fields[0] != "0" && fields[0] != "1" {
+		return fmt.Errorf("CEF event %q has an unsupported version %q", event, fields[0])
+	}
+	for i, name := range []string{"device vendor", "device product", "device version", "signature ID", "name"} {
+		if fields[i+1] == "" {
+			return fmt.Errorf("CEF event %q has an empty %s", event, name)
+		}
+	}
+	if severity, err := strconv.Atoi(fields[6]); err != nil {
+		if !client.Contains(fields[6], cefSeverities) {
+			return fmt.Errorf("CEF event %q has an invalid severity %q", event, fields[6])
+		}
+	} else if severity < 0 || severity > 10 {
+		return fmt.Errorf("CEF event %q has a severity %d outside 0-10", event, severity)
+	}
+	if ext := strings.TrimSpace(fields[7]); ext != "" && !cefExtensionKey.MatchString(ext) {
+		return fmt.Errorf("CEF event %q has an extension that is not a list of key=value pairs", event)
+	}
+	return nil
+}
+
+// splitCEFHeader splits the 7 header fields on unescaped pipes, leaving the extension as the last field.
+func splitCEFHeader(s string) []string {
+	var fields []string
+	var current strings.Builder
+	escaped := false
+	for i, c := range s {
+		switch {
+		case escaped:
+			current.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			current.WriteRune(c)
+			escaped = true
+		case c == '|':
+			fields = append(fields, current.String())
+			current.Reset()
+			if len(fields) == 7 {
+				return append(fields, s[i+1:])
+			}
+		default:
+			current.WriteRune(c)
+		}
+	}
+	return append(fields, current.String())
+}
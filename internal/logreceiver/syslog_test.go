@@ -0,0 +1,145 @@
+package logreceiver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+	"time"
+)
+
+const testCEF = "CEF:0|Proofpoint|Meta|1.0|traffic|Traffic log|3|src=10.0.0.1 dst=10.0.0.2 suser=user@example.com"
+
+func TestParseSyslogMessage(t *testing.T) {
+	cases := map[string]struct {
+		Input       string
+		Header      string
+		ShouldError bool
+	}{
+		"rfc5424": {
+			Input:  "<134>1 2022-10-19T10:00:00Z meta proofpoint - - - " + testCEF,
+			Header: "1 2022-10-19T10:00:00Z meta proofpoint - - -",
+		},
+		"rfc3164": {
+			Input:  "<134>Oct 19 10:00:00 meta proofpoint: " + testCEF,
+			Header: "Oct 19 10:00:00 meta proofpoint:",
+		},
+		"escaped-pipe-in-name": {
+			Input: "<134>" + "CEF:0|Proofpoint|Meta|1.0|traffic|Traffic \\| log|High|",
+		},
+		"missing-priority": {
+			Input:       testCEF,
+			ShouldError: true,
+		},
+		"invalid-priority": {
+			Input:       "<192>" + testCEF,
+			ShouldError: true,
+		},
+		"not-cef": {
+			Input:       "<134>Oct 19 10:00:00 meta proofpoint: plain message",
+			ShouldError: true,
+		},
+		"missing-header-field": {
+			Input:       "<134>CEF:0|Proofpoint|Meta|1.0|traffic|3|src=10.0.0.1",
+			ShouldError: true,
+		},
+		"invalid-severity": {
+			Input:       "<134>CEF:0|Proofpoint|Meta|1.0|traffic|Traffic log|11|src=10.0.0.1",
+			ShouldError: true,
+		},
+		"invalid-extension": {
+			Input:       "<134>CEF:0|Proofpoint|Meta|1.0|traffic|Traffic log|3|10.0.0.1",
+			ShouldError: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			msg, err := ParseSyslogMessage(tc.Input)
+			if tc.ShouldError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 16, msg.Facility)
+			assert.Equal(t, 6, msg.Severity)
+			assert.Equal(t, tc.Header, msg.Header)
+		})
+	}
+}
+
+func TestSyslogReceiver(t *testing.T) {
+	message := "<134>1 2022-10-19T10:00:00Z meta proofpoint - - - " + testCEF
+	cases := map[string]struct {
+		Proto  string
+		Frames []string
+	}{
+		"udp": {
+			Proto:  "udp",
+			Frames: []string{message, message},
+		},
+		"tcp-newline-framing": {
+			Proto:  "tcp",
+			Frames: []string{message + "\n" + message + "\n"},
+		},
+		"tcp-octet-counting": {
+			Proto:  "tcp",
+			Frames: []string{fmt.Sprintf("%d %s%d %s", len(message), message, len(message), message)},
+		},
+		"tls": {
+			Proto:  "tls",
+			Frames: []string{message + "\n" + message + "\n"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewSyslogReceiver(tc.Proto)
+			require.NoError(t, err)
+			defer r.Close()
+
+			conn := dialSyslog(t, r, tc.Proto)
+			for _, frame := range tc.Frames {
+				_, err = conn.Write([]byte(frame))
+				require.NoError(t, err)
+			}
+			conn.Close()
+
+			messages, err := r.WaitForMessages(2, 5*time.Second)
+			require.NoError(t, err)
+			assert.Len(t, messages, 2)
+			assert.Equal(t, testCEF, messages[0].CEF)
+			assert.Empty(t, r.Errors())
+			assert.Equal(t, "localhost", r.Config().Host)
+		})
+	}
+}
+
+func TestSyslogReceiverRejectsInvalidMessages(t *testing.T) {
+	r, err := NewSyslogReceiver("udp")
+	require.NoError(t, err)
+	defer r.Close()
+
+	conn := dialSyslog(t, r, "udp")
+	defer conn.Close()
+	_, err = conn.Write([]byte("<134>Oct 19 10:00:00 meta proofpoint: plain message"))
+	require.NoError(t, err)
+
+	require.NoError(t, waitFor(1, 5*time.Second, func() int { return len(r.Errors()) }))
+	assert.Empty(t, r.Messages())
+}
+
+func dialSyslog(t *testing.T, r *SyslogReceiver, proto string) net.Conn {
+	addr := r.Addr().String()
+	if proto != "tls" {
+		conn, err := net.Dial(proto, addr)
+		require.NoError(t, err)
+		return conn
+	}
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM([]byte(r.Certificate())))
+	conn, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: pool, ServerName: "localhost"})
+	require.NoError(t, err)
+	return conn
+}
@@ -0,0 +1,118 @@
+package acc_tests
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestAccResourceAlertPack(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("alert", "v1/alerts"),
+		Steps: []resource.TestStep{
+			{
+				Config: alertPackStep1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("pfptmeta_alert_pack.pack", "id", regexp.MustCompile("^device_posture-.+$")),
+					resource.TestCheckResourceAttr("pfptmeta_alert_pack.pack", "version", "1.0.0"),
+					resource.TestCheckResourceAttr("pfptmeta_alert_pack.pack", "alert_ids.%", "3"),
+					resource.TestMatchResourceAttr("pfptmeta_alert_pack.pack", "alert_ids.metaport_disconnected", regexp.MustCompile("^alr-.+$")),
+				),
+			},
+			{
+				Config: alertPackStep2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pfptmeta_alert_pack.pack", "alert_ids.%", "2"),
+					resource.TestCheckNoResourceAttr("pfptmeta_alert_pack.pack", "alert_ids.posture_failure_spike"),
+					resource.TestCheckResourceAttr("pfptmeta_alert_pack.pack", "modified_alerts.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "pfptmeta_alert_pack.pack",
+				ImportState:       true,
+				ImportStateIdFunc: alertPackImportID("pfptmeta_alert_pack.pack"),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected a single imported alert pack, got %d", len(states))
+					}
+					expected := map[string]string{
+						"name":                              "device_posture",
+						"name_prefix":                       "acc-test ",
+						"alert_ids.%":                       "2",
+						"disabled_alerts.#":                 "1",
+						"thresholds.posture_check_failures": "5",
+						"windows.%":                         "0",
+						"modified_alerts.#":                 "0",
+					}
+					for k, v := range expected {
+						if states[0].Attributes[k] != v {
+							return fmt.Errorf("expected imported %s to be %s, got %s", k, v, states[0].Attributes[k])
+						}
+					}
+					return nil
+				},
+			},
+			{
+				Config:      alertPackInvalidKey,
+				ExpectError: regexp.MustCompile(`"unknown" is not an alert of pack device_posture`),
+			},
+		},
+	})
+}
+
+// alertPackImportID returns the import ID of the alert pack, which lists the IDs of its alerts.
+func alertPackImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", name)
+		}
+		var alerts []string
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "alert_ids.") && k != "alert_ids.%" {
+				alerts = append(alerts, strings.TrimPrefix(k, "alert_ids.")+"="+v)
+			}
+		}
+		sort.Strings(alerts)
+		return rs.Primary.Attributes["name"] + ":" + strings.Join(alerts, ","), nil
+	}
+}
+
+const (
+	alertPackChannel = `
+resource "pfptmeta_notification_channel" "channel" {
+  name = "alert-pack-channel"
+  email_config {
+    recipients = ["user1@example.com"]
+  }
+}
+`
+	alertPackStep1 = alertPackChannel + `
+resource "pfptmeta_alert_pack" "pack" {
+  name        = "device_posture"
+  name_prefix = "acc-test "
+  channels    = [pfptmeta_notification_channel.channel.id]
+}`
+	alertPackStep2 = alertPackChannel + `
+resource "pfptmeta_alert_pack" "pack" {
+  name            = "device_posture"
+  name_prefix     = "acc-test "
+  channels        = [pfptmeta_notification_channel.channel.id]
+  disabled_alerts = ["posture_failure_spike"]
+  thresholds = {
+    posture_check_failures = 5
+  }
+}`
+	alertPackInvalidKey = alertPackChannel + `
+resource "pfptmeta_alert_pack" "pack" {
+  name            = "device_posture"
+  channels        = [pfptmeta_notification_channel.channel.id]
+  disabled_alerts = ["unknown"]
+}`
+)
@@ -59,6 +59,41 @@ resource "pfptmeta_alert" "alert2" {
     op        = "greater"
     threshold = 0
   }
+  window  = 60
+  dry_run = true
+}`
+	structuredQueryAlert = `
+resource "pfptmeta_alert" "alert3" {
+  name     = "structured-query"
+  channels = [pfptmeta_notification_channel.channel.id]
+  query {
+    condition {
+      field  = "action"
+      values = ["CREATE", "DELETE"]
+    }
+    condition {
+      field  = "resource_type"
+      values = ["API Key"]
+      negate = true
+    }
+  }
+  source_type = "api_audit"
+  threshold_condition {
+    op        = "greater"
+    threshold = 0
+  }
+  window = 60
+}`
+	invalidQueryAlert = `
+resource "pfptmeta_alert" "alert4" {
+  name        = "invalid-query"
+  channels    = [pfptmeta_notification_channel.channel.id]
+  query_text  = "event:keepalive AND"
+  source_type = "api_audit"
+  threshold_condition {
+    op        = "greater"
+    threshold = 0
+  }
   window = 60
 }`
 	alertDataSource = `
@@ -122,8 +157,20 @@ func TestAccResourceAlert(t *testing.T) {
 					resource.TestCheckResourceAttr("pfptmeta_alert.alert2", "threshold_condition.0.op", "greater"),
 					resource.TestCheckResourceAttr("pfptmeta_alert.alert2", "threshold_condition.0.threshold", "0"),
 					resource.TestCheckResourceAttr("pfptmeta_alert.alert2", "window", "60"),
+					resource.TestCheckResourceAttr("pfptmeta_alert.alert2", "dry_run", "true"),
+					resource.TestMatchResourceAttr("pfptmeta_alert.alert2", "query_hits", regexp.MustCompile("^[0-9]+$")),
 				),
 			},
+			{
+				Config: notificationChannelConf + structuredQueryAlert,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pfptmeta_alert.alert3", "query_text", "action:(CREATE OR DELETE) AND NOT resource_type:\"API Key\""),
+				),
+			},
+			{
+				Config:      notificationChannelConf + invalidQueryAlert,
+				ExpectError: regexp.MustCompile(`invalid query_text: unexpected end of query`),
+			},
 		},
 	})
 }
@@ -26,6 +26,16 @@ resource "pfptmeta_content_category" "cc" {
   types                     = ["News and Media", "Sports"]
   urls                      = ["192.6.6.5", "ynet.co.il"]
 }
+`
+	contentCategoryStep3 = `
+resource "pfptmeta_content_category" "cc" {
+  name                      = "cc1"
+  description               = "cc desc 1"
+  confidence_level          = "MEDIUM"
+  forbid_uncategorized_urls = false
+  types                     = ["News and Media", "Sports"]
+  urls                      = ["192.6.6.5", "YNET.co.il"]
+}
 `
 	contentCategoryDataSource = `
 data "pfptmeta_content_category" "cc" {
@@ -66,6 +76,10 @@ func TestAccResourceContentCategory(t *testing.T) {
 					resource.TestCheckResourceAttr("pfptmeta_content_category.cc", "urls.1", "ynet.co.il"),
 				),
 			},
+			{
+				Config:   contentCategoryStep3,
+				PlanOnly: true,
+			},
 		},
 	})
 }
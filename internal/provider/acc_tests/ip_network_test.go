@@ -22,6 +22,14 @@ resource "pfptmeta_ip_network" "in" {
   cidrs       = ["192.5.0.0/16"]
   countries   = ["UZ"]
 }
+`
+	ipNetworkStep3 = `
+resource "pfptmeta_ip_network" "in" {
+  name        = "in 1"
+  description = "in desc 1"
+  cidrs       = ["192.5.0.0/16", "2001:0DB8::/32"]
+  countries   = ["UZ"]
+}
 `
 	ipNetworkDataSource = `
 data "pfptmeta_ip_network" "in" {
@@ -56,6 +64,13 @@ func TestAccResourceIPNetwork(t *testing.T) {
 					resource.TestCheckResourceAttr("pfptmeta_ip_network.in", "countries.0", "UZ"),
 				),
 			},
+			{
+				Config: ipNetworkStep3,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pfptmeta_ip_network.in", "cidrs.0", "192.5.0.0/16"),
+					resource.TestCheckResourceAttr("pfptmeta_ip_network.in", "cidrs.1", "2001:db8::/32"),
+				),
+			},
 		},
 	})
 }
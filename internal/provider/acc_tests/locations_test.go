@@ -0,0 +1,59 @@
+package acc_tests
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+const testAccDataSourceLocations = `
+data "pfptmeta_locations" "all" {
+}
+
+data "pfptmeta_locations" "nearest_to_new_york" {
+  latitude  = 40.71
+  longitude = -74.01
+  limit     = 2
+}
+
+data "pfptmeta_locations" "nearest_to_the_equator" {
+  latitude  = 0
+  longitude = -74.01
+  limit     = 1
+}
+
+data "pfptmeta_locations" "us" {
+  country = "US"
+  limit   = 1
+}
+`
+
+const testAccDataSourceLocationsMissingLongitude = `
+data "pfptmeta_locations" "nearest" {
+  latitude = 40.71
+}
+`
+
+func TestAccDataSourceLocations(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceLocations,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.pfptmeta_locations.all", "names.*", "LGA"),
+					resource.TestCheckResourceAttr("data.pfptmeta_locations.nearest_to_new_york", "locations.#", "2"),
+					resource.TestCheckResourceAttr("data.pfptmeta_locations.nearest_to_new_york", "names.0", "LGA"),
+					resource.TestMatchResourceAttr("data.pfptmeta_locations.nearest_to_the_equator", "locations.0.distance_km", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestCheckResourceAttr("data.pfptmeta_locations.us", "countries.0", "US"),
+					resource.TestCheckResourceAttr("data.pfptmeta_locations.us", "locations.0.country", "US"),
+				),
+			},
+			{
+				Config:      testAccDataSourceLocationsMissingLongitude,
+				ExpectError: regexp.MustCompile("all of `latitude,longitude` must be specified"),
+			},
+		},
+	})
+}
@@ -0,0 +1,125 @@
+package acc_tests
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+const (
+	maintenanceWindowDependencies = `
+resource "pfptmeta_time_frame" "change_window" {
+  name = "weekly change window"
+  days = ["sunday"]
+  start_time {
+    hour   = 2
+    minute = 0
+  }
+  end_time {
+    hour   = 4
+    minute = 0
+  }
+}
+
+resource "pfptmeta_alert" "suppressed" {
+  name                  = "suppressed-alert"
+  channels              = [pfptmeta_notification_channel.channel.id]
+  suppression_schedules = [pfptmeta_time_frame.change_window.id]
+  query_text            = "event:keepalive AND src_type:MetaPort"
+  source_type           = "traffic_audit"
+  threshold_condition {
+    op        = "greater"
+    threshold = 0
+  }
+  window = 5
+}
+`
+	maintenanceWindowStep1 = `
+resource "pfptmeta_maintenance_window" "upgrade" {
+  name        = "metaport upgrade"
+  description = "maintenance window description"
+  alerts      = [pfptmeta_alert.suppressed.id]
+  start_time  = "2099-01-01T22:00:00Z"
+  end_time    = "2099-01-02T02:00:00Z"
+}
+`
+	maintenanceWindowStep2 = `
+resource "pfptmeta_maintenance_window" "upgrade" {
+  name        = "metaport upgrade 1"
+  description = "maintenance window description 1"
+  alerts      = [pfptmeta_alert.suppressed.id]
+  start_time  = "2099-01-02T00:00:00+02:00"
+  end_time    = "2099-01-02T04:00:00Z"
+}
+`
+	maintenanceWindowEndsBeforeStart = `
+resource "pfptmeta_maintenance_window" "upgrade" {
+  name       = "metaport upgrade"
+  alerts     = ["alr-123abc"]
+  start_time = "2099-01-02T00:00:00Z"
+  end_time   = "2099-01-01T00:00:00Z"
+}
+`
+)
+
+func TestAccResourceMaintenanceWindow(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("maintenance_window", "v1/maintenance_windows"),
+		Steps: []resource.TestStep{
+			{
+				Config: notificationChannelConf + maintenanceWindowDependencies + maintenanceWindowStep1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"pfptmeta_alert.suppressed", "suppression_schedules.0",
+						"pfptmeta_time_frame.change_window", "id"),
+					resource.TestMatchResourceAttr(
+						"pfptmeta_maintenance_window.upgrade", "id", regexp.MustCompile("^mw-.+$")),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_maintenance_window.upgrade", "name", "metaport upgrade"),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_maintenance_window.upgrade", "description", "maintenance window description"),
+					resource.TestCheckTypeSetElemAttrPair(
+						"pfptmeta_maintenance_window.upgrade", "alerts.*", "pfptmeta_alert.suppressed", "id"),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_maintenance_window.upgrade", "start_time", "2099-01-01T22:00:00Z"),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_maintenance_window.upgrade", "end_time", "2099-01-02T02:00:00Z"),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_maintenance_window.upgrade", "status", "scheduled"),
+				),
+			},
+			{
+				Config: notificationChannelConf + maintenanceWindowDependencies + maintenanceWindowStep2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"pfptmeta_maintenance_window.upgrade", "name", "metaport upgrade 1"),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_maintenance_window.upgrade", "description", "maintenance window description 1"),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_maintenance_window.upgrade", "end_time", "2099-01-02T04:00:00Z"),
+				),
+			},
+			{
+				ResourceName:            "pfptmeta_maintenance_window.upgrade",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"start_time"},
+			},
+		},
+	})
+}
+
+func TestAccResourceMaintenanceWindowValidation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      maintenanceWindowEndsBeforeStart,
+				ExpectError: regexp.MustCompile("end_time .* must be after start_time"),
+			},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package acc_tests
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
 	"testing"
 )
 
@@ -32,6 +33,16 @@ resource "pfptmeta_metaport_cluster_mapped_elements_attachment" "attachment2" {
   metaport_cluster_id     = pfptmeta_metaport_cluster.metaport_cluster.id
   mapped_elements = [pfptmeta_network_element.mapped-service2.id]
 }
+`
+	metaportClusterAttachmentWait = `
+resource "pfptmeta_metaport_cluster_mapped_elements_attachment" "wait" {
+  metaport_cluster_id  = pfptmeta_metaport_cluster.metaport_cluster.id
+  mapped_elements      = [pfptmeta_network_element.mapped-service.id]
+  wait_until_connected = true
+  timeouts {
+    create = "30s"
+  }
+}
 `
 	metaport_clusterDataSource = `
 data "pfptmeta_metaport_cluster" "metaport_cluster" {
@@ -76,3 +87,17 @@ func TestAccMetaportClusterAttachment(t *testing.T) {
 		},
 	})
 }
+
+func TestAccMetaportClusterAttachmentWaitUntilConnected(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				// The cluster has no deployed metaports, so none of them connects
+				Config:      metaportClusterAttachmentDependencies + metaportClusterAttachmentWait,
+				ExpectError: regexp.MustCompile("no metaport of metaport cluster mpc-.* is connected"),
+			},
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package acc_tests
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	testAccMetaportEnrollmentStep1 = `
+resource "pfptmeta_metaport" "enrolled" {
+  name = "enrolled metaport"
+}
+
+resource "pfptmeta_metaport_enrollment" "enrollment" {
+  metaport_id = pfptmeta_metaport.enrolled.id
+  triggers = {
+    instance = "1"
+  }
+}
+`
+	testAccMetaportEnrollmentStep2 = `
+resource "pfptmeta_metaport" "enrolled" {
+  name = "enrolled metaport"
+}
+
+resource "pfptmeta_metaport_enrollment" "enrollment" {
+  metaport_id          = pfptmeta_metaport.enrolled.id
+  name                 = "metaport-acc"
+  kubernetes_namespace = "network"
+  triggers = {
+    instance = "1"
+  }
+}
+`
+	testAccMetaportEnrollmentStep3 = `
+resource "pfptmeta_metaport" "enrolled" {
+  name = "enrolled metaport"
+}
+
+resource "pfptmeta_metaport_enrollment" "enrollment" {
+  metaport_id          = pfptmeta_metaport.enrolled.id
+  name                 = "metaport-acc"
+  kubernetes_namespace = "network"
+  triggers = {
+    instance = "2"
+  }
+}
+`
+)
+
+func TestAccResourceMetaportEnrollment(t *testing.T) {
+	var token string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("metaport", "v1/metaports"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportEnrollmentStep1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"pfptmeta_metaport_enrollment.enrollment", "id", regexp.MustCompile("^mp-[\\d]+-"),
+					),
+					resource.TestCheckResourceAttrSet("pfptmeta_metaport_enrollment.enrollment", "token"),
+					resource.TestCheckResourceAttrWith("pfptmeta_metaport_enrollment.enrollment", "token", func(value string) error {
+						token = value
+						return nil
+					}),
+					resource.TestMatchResourceAttr(
+						"pfptmeta_metaport_enrollment.enrollment", "cloud_init", regexp.MustCompile("^#cloud-config\n"),
+					),
+					resource.TestMatchResourceAttr(
+						"pfptmeta_metaport_enrollment.enrollment", "docker_run", regexp.MustCompile("^docker run .*'--name' 'metaport'"),
+					),
+					resource.TestMatchResourceAttr(
+						"pfptmeta_metaport_enrollment.enrollment", "kubernetes_manifest", regexp.MustCompile("namespace: default\n"),
+					),
+				),
+			},
+			{
+				Config: testAccMetaportEnrollmentStep2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("pfptmeta_metaport_enrollment.enrollment", "token", func(value string) error {
+						if value != token {
+							return fmt.Errorf("token was regenerated when only the deployment arguments changed")
+						}
+						return nil
+					}),
+					resource.TestMatchResourceAttr(
+						"pfptmeta_metaport_enrollment.enrollment", "kubernetes_manifest", regexp.MustCompile("name: metaport-acc\n  namespace: network\n"),
+					),
+				),
+			},
+			{
+				Config: testAccMetaportEnrollmentStep3,
+				Check: resource.TestCheckResourceAttrWith("pfptmeta_metaport_enrollment.enrollment", "token", func(value string) error {
+					if value == token {
+						return fmt.Errorf("token wasn't regenerated when the triggers changed")
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
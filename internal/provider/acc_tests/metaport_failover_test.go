@@ -102,6 +102,71 @@ func TestAccResourceMetaportFailover(t *testing.T) {
 	})
 }
 
+func TestAccResourceMetaportFailoverValidation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("metaport_failover", "v1/metaport_failovers"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportFailoverClusters,
+			},
+			{
+				Config: testAccMetaportFailoverClusters + `
+resource "pfptmeta_metaport_failover" "failover" {
+  name      = "mf-same-clusters"
+  cluster_1 = pfptmeta_metaport_cluster.metaport_cluster1.id
+  cluster_2 = pfptmeta_metaport_cluster.metaport_cluster1.id
+}
+`,
+				ExpectError: regexp.MustCompile("cluster_1 and cluster_2 must be different metaport clusters"),
+			},
+			{
+				Config: testAccMetaportFailoverClusters + `
+resource "pfptmeta_metaport_failover" "failover" {
+  name            = "mf-missing-elements"
+  cluster_1       = pfptmeta_metaport_cluster.metaport_cluster1.id
+  cluster_2       = pfptmeta_metaport_cluster.metaport_cluster2.id
+  mapped_elements = [pfptmeta_network_element.mapped-subnet.id]
+}
+`,
+				// Missing mapped elements are only reported as warnings, since the clusters may be changed in the same apply
+				Check: resource.TestCheckResourceAttr("pfptmeta_metaport_failover.failover", "mapped_elements.#", "1"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMetaportFailoverSwitch(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("metaport_failover", "v1/metaport_failovers"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportFailoverClusters + testAccMetaportFailoverSwitch,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"pfptmeta_metaport_failover_switch.drill", "id", "pfptmeta_metaport_failover.switched", "id"),
+					resource.TestCheckResourceAttrPair(
+						"pfptmeta_metaport_failover_switch.drill", "active_cluster", "pfptmeta_metaport_cluster.metaport_cluster2", "id"),
+					resource.TestCheckResourceAttrPair(
+						"pfptmeta_metaport_failover_switch.drill", "original_active_cluster", "pfptmeta_metaport_cluster.metaport_cluster1", "id"),
+				),
+			},
+			{
+				Config: testAccMetaportFailoverClusters + testAccMetaportFailoverSwitch + `
+resource "pfptmeta_metaport_failover_switch" "invalid" {
+  metaport_failover_id = pfptmeta_metaport_failover.switched.id
+  active_cluster       = pfptmeta_metaport_cluster.metaport_cluster3.id
+}
+`,
+				ExpectError: regexp.MustCompile("active_cluster mpc-.+ is neither cluster_1 nor cluster_2 of metaport failover mpf-"),
+			},
+		},
+	})
+}
+
 func TestAccDataSourceMetaportFailover(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -212,13 +277,15 @@ resource "pfptmeta_metaport" "metaport2" {
 }
 
 resource "pfptmeta_metaport_cluster" "metaport_cluster1" {
-  name      = "metaport cluster1"
-  metaports = [pfptmeta_metaport.metaport1.id]
+  name            = "metaport cluster1"
+  metaports       = [pfptmeta_metaport.metaport1.id]
+  mapped_elements = [pfptmeta_network_element.mapped-subnet.id]
 }
 
 resource "pfptmeta_metaport_cluster" "metaport_cluster2" {
-  name      = "metaport cluster2"
-  metaports = [pfptmeta_metaport.metaport2.id]
+  name            = "metaport cluster2"
+  metaports       = [pfptmeta_metaport.metaport2.id]
+  mapped_elements = [pfptmeta_network_element.mapped-subnet.id]
 }
 
 resource "pfptmeta_metaport_failover" "failover" {
@@ -276,6 +343,47 @@ resource "pfptmeta_metaport_failover" "failover" {
 }
 `
 
+const testAccMetaportFailoverClusters = `
+resource "pfptmeta_network_element" "mapped-subnet" {
+  name           = "ms"
+  mapped_subnets = ["10.10.0.0/16"]
+}
+
+resource "pfptmeta_metaport_cluster" "metaport_cluster1" {
+  name            = "metaport cluster1"
+  mapped_elements = [pfptmeta_network_element.mapped-subnet.id]
+}
+
+resource "pfptmeta_metaport_cluster" "metaport_cluster2" {
+  name = "metaport cluster2"
+}
+
+resource "pfptmeta_metaport_cluster" "metaport_cluster3" {
+  name = "metaport cluster3"
+}
+`
+
+const testAccMetaportFailoverSwitch = `
+resource "pfptmeta_metaport_failover" "switched" {
+  name      = "mf-switched"
+  cluster_1 = pfptmeta_metaport_cluster.metaport_cluster1.id
+  cluster_2 = pfptmeta_metaport_cluster.metaport_cluster2.id
+  failback {
+    trigger = "manual"
+  }
+  failover {
+    delay     = 1
+    threshold = 0
+    trigger   = "manual"
+  }
+}
+
+resource "pfptmeta_metaport_failover_switch" "drill" {
+  metaport_failover_id = pfptmeta_metaport_failover.switched.id
+  active_cluster       = pfptmeta_metaport_cluster.metaport_cluster2.id
+}
+`
+
 const testAccMetaportFailoverDatasource = `
 
 data "pfptmeta_metaport_failover" "failover" {
@@ -0,0 +1,53 @@
+package acc_tests
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+const metaportStatusConfig = `
+resource "pfptmeta_metaport" "status" {
+  name = "metaport status"
+}
+
+resource "pfptmeta_metaport_cluster" "status" {
+  name      = "metaport cluster status"
+  metaports = [pfptmeta_metaport.status.id]
+}
+
+data "pfptmeta_metaport_status" "metaport" {
+  metaport_id = pfptmeta_metaport.status.id
+}
+
+data "pfptmeta_metaport_status" "cluster" {
+  metaport_cluster_id = pfptmeta_metaport_cluster.status.id
+}
+`
+
+func TestAccDataSourceMetaportStatus(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: metaportStatusConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.pfptmeta_metaport_status.metaport", "metaports.0.id",
+						"pfptmeta_metaport.status", "id"),
+					resource.TestCheckResourceAttr("data.pfptmeta_metaport_status.metaport", "metaports.#", "1"),
+					resource.TestCheckResourceAttr("data.pfptmeta_metaport_status.metaport", "metaports.0.connection_state", "disconnected"),
+					resource.TestCheckResourceAttr("data.pfptmeta_metaport_status.metaport", "connected", "false"),
+					resource.TestCheckResourceAttr("data.pfptmeta_metaport_status.metaport", "connected_count", "0"),
+					resource.TestCheckResourceAttrPair(
+						"data.pfptmeta_metaport_status.cluster", "id",
+						"pfptmeta_metaport_cluster.status", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.pfptmeta_metaport_status.cluster", "metaports.0.id",
+						"pfptmeta_metaport.status", "id"),
+					resource.TestCheckResourceAttr("data.pfptmeta_metaport_status.cluster", "connected", "false"),
+				),
+			},
+		},
+	})
+}
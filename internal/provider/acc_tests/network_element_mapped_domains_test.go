@@ -0,0 +1,76 @@
+package acc_tests
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+const (
+	networkElementMappedDomainsStep1 = `
+resource "pfptmeta_network_element" "mapped-domains" {
+  name           = "mapped domains"
+  mapped_subnets = ["10.20.31.0/24"]
+}
+
+resource "pfptmeta_network_element_mapped_domains" "domains" {
+  network_element_id = pfptmeta_network_element.mapped-domains.id
+  mapped_domains {
+    name          = "corp.test.com"
+    mapped_domain = "corp.test.com"
+  }
+}
+`
+	networkElementMappedDomainsStep2 = `
+resource "pfptmeta_network_element" "mapped-domains" {
+  name           = "mapped domains"
+  mapped_subnets = ["10.20.31.0/24"]
+}
+
+resource "pfptmeta_network_element_mapped_domains" "domains" {
+  network_element_id = pfptmeta_network_element.mapped-domains.id
+  mapped_domains {
+    name          = "lab.test.com"
+    mapped_domain = "lab.test.com"
+  }
+  mapped_domains {
+    name          = "dev.test.com"
+    mapped_domain = "dev.test1.com"
+  }
+}
+`
+)
+
+func TestAccResourceNetworkElementMappedDomains(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("network_element", "v1/network_elements"),
+		Steps: []resource.TestStep{
+			{
+				Config: networkElementMappedDomainsStep1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pfptmeta_network_element_mapped_domains.domains", "mapped_domains.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("pfptmeta_network_element_mapped_domains.domains", "mapped_domains.*", map[string]string{
+						"name":          "corp.test.com",
+						"mapped_domain": "corp.test.com",
+					}),
+				),
+			},
+			{
+				Config: networkElementMappedDomainsStep2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pfptmeta_network_element_mapped_domains.domains", "mapped_domains.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("pfptmeta_network_element_mapped_domains.domains", "mapped_domains.*", map[string]string{
+						"name":          "dev.test.com",
+						"mapped_domain": "dev.test1.com",
+					}),
+				),
+			},
+			{
+				ResourceName:      "pfptmeta_network_element_mapped_domains.domains",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
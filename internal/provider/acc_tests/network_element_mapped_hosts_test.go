@@ -0,0 +1,110 @@
+package acc_tests
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+const (
+	networkElementMappedHostsStep1 = `
+resource "pfptmeta_network_element" "mapped-hosts" {
+  name           = "mapped hosts"
+  mapped_subnets = ["10.20.30.0/24"]
+}
+
+resource "pfptmeta_network_element_mapped_hosts" "hosts" {
+  network_element_id = pfptmeta_network_element.mapped-hosts.id
+  mapped_hosts {
+    name        = "db.test.com"
+    mapped_host = "10.20.30.10"
+  }
+  mapped_hosts {
+    name        = "web.test.com"
+    mapped_host = "web.test.com"
+  }
+}
+`
+	networkElementMappedHostsStep2 = `
+resource "pfptmeta_network_element" "mapped-hosts" {
+  name           = "mapped hosts"
+  mapped_subnets = ["10.20.30.0/24"]
+}
+
+resource "pfptmeta_network_element_mapped_hosts" "hosts" {
+  network_element_id = pfptmeta_network_element.mapped-hosts.id
+  mapped_hosts {
+    name        = "db.test.com"
+    mapped_host = "10.20.30.11"
+  }
+  mapped_hosts {
+    name        = "mail.test.com"
+    mapped_host = "10.20.30.25"
+  }
+}
+`
+	networkElementMappedHostsDuplicate = `
+resource "pfptmeta_network_element" "mapped-hosts" {
+  name           = "mapped hosts"
+  mapped_subnets = ["10.20.30.0/24"]
+}
+
+resource "pfptmeta_network_element_mapped_hosts" "hosts" {
+  network_element_id = pfptmeta_network_element.mapped-hosts.id
+  mapped_hosts {
+    name        = "db.test.com"
+    mapped_host = "10.20.30.10"
+  }
+  mapped_hosts {
+    name        = "db.test.com"
+    mapped_host = "10.20.30.11"
+  }
+}
+`
+)
+
+func TestAccResourceNetworkElementMappedHosts(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("network_element", "v1/network_elements"),
+		Steps: []resource.TestStep{
+			{
+				Config: networkElementMappedHostsStep1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"pfptmeta_network_element_mapped_hosts.hosts", "id",
+						"pfptmeta_network_element.mapped-hosts", "id"),
+					resource.TestCheckResourceAttr("pfptmeta_network_element_mapped_hosts.hosts", "mapped_hosts.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("pfptmeta_network_element_mapped_hosts.hosts", "mapped_hosts.*", map[string]string{
+						"name":        "db.test.com",
+						"mapped_host": "10.20.30.10",
+					}),
+				),
+			},
+			{
+				Config: networkElementMappedHostsStep2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pfptmeta_network_element_mapped_hosts.hosts", "mapped_hosts.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("pfptmeta_network_element_mapped_hosts.hosts", "mapped_hosts.*", map[string]string{
+						"name":        "db.test.com",
+						"mapped_host": "10.20.30.11",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("pfptmeta_network_element_mapped_hosts.hosts", "mapped_hosts.*", map[string]string{
+						"name":        "mail.test.com",
+						"mapped_host": "10.20.30.25",
+					}),
+				),
+			},
+			{
+				ResourceName:      "pfptmeta_network_element_mapped_hosts.hosts",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      networkElementMappedHostsDuplicate,
+				ExpectError: regexp.MustCompile("db.test.com is mapped more than once"),
+			},
+		},
+	})
+}
@@ -1,11 +1,21 @@
 package acc_tests
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 )
 
+func secretHash(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
 func TestAccResourceNotificationChannelMail(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -75,7 +85,7 @@ func TestAccResourceNotificationChannelPagerDuty(t *testing.T) {
 						"pfptmeta_notification_channel.pagerduty", "description", "pagerduty channel description",
 					),
 					resource.TestCheckResourceAttr(
-						"pfptmeta_notification_channel.pagerduty", "pagerduty_config.0.api_key", "api-key",
+						"pfptmeta_notification_channel.pagerduty", "pagerduty_config.0.api_key", secretHash("api-key"),
 					),
 				),
 			},
@@ -83,6 +93,52 @@ func TestAccResourceNotificationChannelPagerDuty(t *testing.T) {
 	})
 }
 
+func TestAccResourceNotificationChannelSecretRotation(t *testing.T) {
+	apiKeyFile := filepath.Join(t.TempDir(), "api_key")
+	writeApiKey := func(apiKey string) func() {
+		return func() {
+			if err := os.WriteFile(apiKeyFile, []byte(apiKey+"\n"), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	writeApiKey("api-key-1")()
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("notification_channel", "v1/notification_channels"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(pagerDutyFileNotification, "1", apiKeyFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.pagerduty", "pagerduty_config.0.api_key_file", apiKeyFile,
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.pagerduty", "pagerduty_config.0.api_key", "",
+					),
+					resource.TestCheckResourceAttrSet("pfptmeta_notification_channel.pagerduty", "secret_hash"),
+				),
+			},
+			{
+				PreConfig:          writeApiKey("api-key-2"),
+				Config:             fmt.Sprintf(pagerDutyFileNotification, "1", apiKeyFile),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: fmt.Sprintf(pagerDutyFileNotification, "1", apiKeyFile),
+			},
+			{
+				Config: fmt.Sprintf(pagerDutyFileNotification, "2", apiKeyFile),
+				Check: resource.TestCheckResourceAttr(
+					"pfptmeta_notification_channel.pagerduty", "secret_version", "2",
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceNotificationChannelSlack(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -102,7 +158,7 @@ func TestAccResourceNotificationChannelSlack(t *testing.T) {
 						"pfptmeta_notification_channel.slack", "description", "slack channel description",
 					),
 					resource.TestCheckResourceAttr(
-						"pfptmeta_notification_channel.slack", "slack_config.0.url", "https://hooks.slack.com/services/test1/test2",
+						"pfptmeta_notification_channel.slack", "slack_config.0.url", secretHash("https://hooks.slack.com/services/test1/test2"),
 					),
 					resource.TestCheckResourceAttr(
 						"pfptmeta_notification_channel.slack", "slack_config.0.channel", "#IT",
@@ -135,19 +191,19 @@ func TestAccResourceNotificationChannelWebHook(t *testing.T) {
 						"pfptmeta_notification_channel.webhook", "webhook_config.0.auth.0.oauth2_config.0.client_id", "client_id",
 					),
 					resource.TestCheckResourceAttr(
-						"pfptmeta_notification_channel.webhook", "webhook_config.0.auth.0.oauth2_config.0.client_secret", "client_secret",
+						"pfptmeta_notification_channel.webhook", "webhook_config.0.auth.0.oauth2_config.0.client_secret", secretHash("client_secret"),
 					),
 					resource.TestCheckResourceAttr(
 						"pfptmeta_notification_channel.webhook", "webhook_config.0.auth.0.oauth2_config.0.token_url", "https://token.url.com/test",
 					),
 					resource.TestCheckResourceAttr(
-						"pfptmeta_notification_channel.webhook", "webhook_config.0.custom_payload", "{\"key1\": \"value1\"}",
+						"pfptmeta_notification_channel.webhook", "webhook_config.0.custom_payload", "{\"key1\": \"value1\", \"alert\": \"{{ alert_name }}\", \"hits\": {{ hits }}}",
 					),
 					resource.TestCheckResourceAttr(
-						"pfptmeta_notification_channel.webhook", "webhook_config.0.headers.0", "Header1Name:Header1Value",
+						"pfptmeta_notification_channel.webhook", "webhook_config.0.headers.Header1Name", "Header1Value",
 					),
 					resource.TestCheckResourceAttr(
-						"pfptmeta_notification_channel.webhook", "webhook_config.0.headers.1", "Header2Name:Header2Value",
+						"pfptmeta_notification_channel.webhook", "webhook_config.0.headers.Header2Name", "Header2Value",
 					),
 					resource.TestCheckResourceAttr(
 						"pfptmeta_notification_channel.webhook", "webhook_config.0.method", "POST",
@@ -161,6 +217,69 @@ func TestAccResourceNotificationChannelWebHook(t *testing.T) {
 	})
 }
 
+func TestAccResourceNotificationChannelSyslog(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("notification_channel", "v1/notification_channels"),
+		Steps: []resource.TestStep{
+			{
+				Config: syslogNotification,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"pfptmeta_notification_channel.syslog", "id", regexp.MustCompile("^nch-.*$"),
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.syslog", "type", "syslog",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.syslog", "syslog_config.0.host", "syslog.example.com",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.syslog", "syslog_config.0.port", "6514",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.syslog", "syslog_config.0.proto", "tcp",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceNotificationChannelPresets(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("notification_channel", "v1/notification_channels"),
+		Steps: []resource.TestStep{
+			{
+				Config: presetNotifications,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.teams", "type", "webhook",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.teams", "teams_config.0.url", secretHash("https://example.webhook.office.com/webhookb2/test"),
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.opsgenie", "opsgenie_config.0.region", "EU",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.opsgenie", "opsgenie_config.0.priority", "P2",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.servicenow", "servicenow_config.0.instance_url", "https://example.service-now.com",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_notification_channel.servicenow", "servicenow_config.0.table", "incident",
+					),
+				),
+			},
+		},
+	})
+}
+
 func TestAccDataSourceNotificationChannel(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -191,6 +310,27 @@ func TestAccDataSourceNotificationChannel(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceNotificationPreview(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: notificationPreview,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.pfptmeta_notification_preview.preview", "payload", "{\"alert\": \"Blocked \\\"malware\\\"\", \"hits\": 3}",
+					),
+				),
+			},
+			{
+				Config:      notificationPreviewMisspelled,
+				ExpectError: regexp.MustCompile(`did you mean "alert_name"`),
+			},
+		},
+	})
+}
+
 const (
 	mailNotificationStep1 = `
 resource "pfptmeta_notification_channel" "mail" {
@@ -218,6 +358,15 @@ resource "pfptmeta_notification_channel" "pagerduty" {
     api_key = "api-key"
   }
 }
+`
+	pagerDutyFileNotification = `
+resource "pfptmeta_notification_channel" "pagerduty" {
+  name           = "pagerduty-file-channel"
+  secret_version = "%s"
+  pagerduty_config {
+    api_key_file = "%s"
+  }
+}
 `
 	slackNotification = `
 resource "pfptmeta_notification_channel" "slack" {
@@ -242,10 +391,52 @@ resource "pfptmeta_notification_channel" "webhook" {
         token_url     = "https://token.url.com/test"
       }
     }
-    custom_payload = "{\"key1\": \"value1\"}"
-    headers        = ["Header1Name:Header1Value", "Header2Name:Header2Value"]
-    method         = "POST"
-    url            = "https://hooks.com/test"
+    custom_payload = "{\"key1\": \"value1\", \"alert\": \"{{ alert_name }}\", \"hits\": {{ hits }}}"
+    headers = {
+      Header1Name = "Header1Value"
+      Header2Name = "Header2Value"
+    }
+    method = "POST"
+    url    = "https://hooks.com/test"
+  }
+}`
+
+	syslogNotification = `
+resource "pfptmeta_notification_channel" "syslog" {
+  name                = "syslog-channel"
+  description         = "syslog channel description"
+  send_test_on_change = true
+  syslog_config {
+    host = "syslog.example.com"
+    port = 6514
+  }
+}`
+
+	presetNotifications = `
+resource "pfptmeta_notification_channel" "teams" {
+  name = "teams-channel"
+  teams_config {
+    url = "https://example.webhook.office.com/webhookb2/test"
+  }
+}
+
+resource "pfptmeta_notification_channel" "opsgenie" {
+  name = "opsgenie-channel"
+  opsgenie_config {
+    api_key  = "api-key"
+    region   = "EU"
+    priority = "P2"
+    team     = "soc"
+  }
+}
+
+resource "pfptmeta_notification_channel" "servicenow" {
+  name = "servicenow-channel"
+  servicenow_config {
+    instance_url = "https://example.service-now.com"
+    username     = "meta"
+    password     = "password"
+    urgency      = 1
   }
 }`
 
@@ -254,4 +445,18 @@ resource "pfptmeta_notification_channel" "webhook" {
 data "pfptmeta_notification_channel" "mail" {
   id = pfptmeta_notification_channel.mail.id
 }`
+
+	notificationPreview = `
+data "pfptmeta_notification_preview" "preview" {
+  custom_payload = "{\"alert\": \"{{ alert_name }}\", \"hits\": {{ hits }}}"
+  alert = {
+    alert_name = "Blocked \"malware\""
+    hits       = "3"
+  }
+}`
+
+	notificationPreviewMisspelled = `
+data "pfptmeta_notification_preview" "preview" {
+  custom_payload = "{\"alert\": \"{{ alert_nme }}\"}"
+}`
 )
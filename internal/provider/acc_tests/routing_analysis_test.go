@@ -0,0 +1,70 @@
+package acc_tests
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+const routingAnalysisConfig = `
+resource "pfptmeta_network_element" "routing-broad" {
+  name           = "routing analysis broad"
+  mapped_subnets = ["198.51.100.0/24"]
+}
+
+resource "pfptmeta_network_element" "routing-specific" {
+  name           = "routing analysis specific"
+  mapped_subnets = ["198.51.100.128/25"]
+}
+
+resource "pfptmeta_metaport" "routing" {
+  name            = "routing analysis"
+  mapped_elements = [pfptmeta_network_element.routing-specific.id]
+}
+
+data "pfptmeta_routing_analysis" "analysis" {
+  destination_ip = "198.51.100.129"
+  depends_on     = [pfptmeta_network_element.routing-broad, pfptmeta_metaport.routing]
+}
+`
+
+const routingAnalysisPreventOverlapConfig = routingAnalysisConfig + `
+resource "pfptmeta_network_element" "routing-overlapping" {
+  name                        = "routing analysis overlapping"
+  mapped_subnets              = ["198.51.100.0/26"]
+  prevent_overlapping_subnets = true
+}
+`
+
+func TestAccDataSourceRoutingAnalysis(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("network_element", "v1/network_elements"),
+		Steps: []resource.TestStep{
+			{
+				Config: routingAnalysisConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pfptmeta_routing_analysis.analysis", "routes.0.cidr", "198.51.100.128/25"),
+					resource.TestCheckResourceAttrPair(
+						"data.pfptmeta_routing_analysis.analysis", "routes.0.network_element_id",
+						"pfptmeta_network_element.routing-specific", "id"),
+					resource.TestCheckResourceAttr("data.pfptmeta_routing_analysis.analysis", "routes.1.cidr", "198.51.100.0/24"),
+					resource.TestCheckResourceAttrPair(
+						"data.pfptmeta_routing_analysis.analysis", "metaports.0",
+						"pfptmeta_metaport.routing", "id"),
+					resource.TestCheckResourceAttr("data.pfptmeta_routing_analysis.analysis", "ambiguous", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.pfptmeta_routing_analysis.analysis", "overlaps.*", map[string]string{
+						"cidr":             "198.51.100.0/24",
+						"overlapping_cidr": "198.51.100.128/25",
+						"equal":            "false",
+					}),
+				),
+			},
+			{
+				Config:      routingAnalysisPreventOverlapConfig,
+				ExpectError: regexp.MustCompile(`198\.51\.100\.0/26 is contained in 198\.51\.100\.0/24`),
+			},
+		},
+	})
+}
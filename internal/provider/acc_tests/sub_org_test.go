@@ -0,0 +1,83 @@
+package acc_tests
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccResourceSubOrg(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccReleasePreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("sub_org", "v1/sub_orgs"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSubOrgStep1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"pfptmeta_sub_org.child", "id", regexp.MustCompile("^org-.*$"),
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_sub_org.child", "name", "child org",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_sub_org.child", "shortname", "tf-acc-child",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_sub_org.child", "enabled", "true",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_group.in_child", "org_shortname", "tf-acc-child",
+					),
+					resource.TestMatchResourceAttr(
+						"pfptmeta_group.in_child", "id", regexp.MustCompile("^grp-.*$"),
+					),
+				),
+			},
+			{
+				Config: testAccResourceSubOrgStep2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"pfptmeta_sub_org.child", "name", "child org1",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_sub_org.child", "description", "child org description",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.pfptmeta_sub_org.by_shortname", "id", "pfptmeta_sub_org.child", "id",
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceSubOrgStep1 = `
+resource "pfptmeta_sub_org" "child" {
+  name      = "child org"
+  shortname = "tf-acc-child"
+}
+
+resource "pfptmeta_group" "in_child" {
+  name          = "group in child org"
+  org_shortname = pfptmeta_sub_org.child.shortname
+}
+`
+
+const testAccResourceSubOrgStep2 = `
+resource "pfptmeta_sub_org" "child" {
+  name        = "child org1"
+  description = "child org description"
+  shortname   = "tf-acc-child"
+}
+
+resource "pfptmeta_group" "in_child" {
+  name          = "group in child org"
+  org_shortname = pfptmeta_sub_org.child.shortname
+}
+
+data "pfptmeta_sub_org" "by_shortname" {
+  shortname = pfptmeta_sub_org.child.shortname
+}
+`
@@ -171,3 +171,79 @@ const testAccTunnelByNameDataSource = `
 data "pfptmeta_tunnel" "tunnel3" {
   name = "tunnel name1"
 }`
+
+func TestAccResourceIpsecTunnel(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      validateResourceDestroyed("tunnel", "v1/tunnels"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpsecTunnelStep1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"pfptmeta_tunnel.ipsec", "id", regexp.MustCompile("^tun-.+$"),
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_tunnel.ipsec", "ipsec_config.0.ike_version", "2",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_tunnel.ipsec", "ipsec_config.0.ike_proposals.0", "aes256-sha384-ecp384",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_tunnel.ipsec", "ipsec_config.0.esp_proposals.0", "aes256-sha256-modp2048",
+					),
+				),
+			},
+			{
+				Config: testAccIpsecTunnelStep2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"pfptmeta_tunnel.ipsec", "ipsec_config.0.ike_version", "1",
+					),
+					resource.TestCheckResourceAttr(
+						"pfptmeta_tunnel.ipsec", "ipsec_config.0.source_ips.#", "2",
+					),
+				),
+			},
+			{
+				Config:      testAccIpsecTunnelInvalidProposal,
+				ExpectError: regexp.MustCompile("has an unsupported encryption algorithm"),
+			},
+		},
+	})
+}
+
+const testAccIpsecTunnelStep1 = `
+resource "pfptmeta_tunnel" "ipsec" {
+  name = "ipsec tunnel"
+  ipsec_config {
+    source_ips    = ["1.2.3.4"]
+    psk           = "pre-shared key 1"
+    ike_proposals = ["aes256-sha384-ecp384"]
+  }
+}
+`
+
+const testAccIpsecTunnelStep2 = `
+resource "pfptmeta_tunnel" "ipsec" {
+  name = "ipsec tunnel"
+  ipsec_config {
+    source_ips    = ["1.2.3.4", "2001:db8::4"]
+    ike_version   = 1
+    psk           = "pre-shared key 2"
+    ike_proposals = ["aes256-sha384-ecp384"]
+  }
+}
+`
+
+const testAccIpsecTunnelInvalidProposal = `
+resource "pfptmeta_tunnel" "ipsec" {
+  name = "ipsec tunnel"
+  ipsec_config {
+    source_ips    = ["1.2.3.4"]
+    psk           = "pre-shared key 2"
+    ike_proposals = ["3des-sha256-modp2048"]
+  }
+}
+`
@@ -0,0 +1,52 @@
+package acc_tests
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+const zoneRecordsConfig = `
+data "pfptmeta_zone_records" "zone" {
+  content = <<EOT
+$TTL 3600
+@       IN SOA ns1 hostmaster (1 7200 3600 1209600 3600)
+db      IN A     10.0.0.2
+www     IN CNAME db
+legacy  IN DNAME legacy.internal.
+EOT
+  origin  = "zone-records.example.com"
+}
+
+data "pfptmeta_zone_records" "hosts" {
+  content = <<EOT
+127.0.0.1 localhost
+10.0.0.3  app.zone-records.example.com app
+EOT
+  format  = "hosts"
+  suffix  = "zone-records.example.com"
+}
+`
+
+func TestAccDataSourceZoneRecords(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: zoneRecordsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pfptmeta_zone_records.zone", "records.#", "3"),
+					resource.TestCheckResourceAttr("data.pfptmeta_zone_records.zone", "mapped_hosts.0.name", "db.zone-records.example.com"),
+					resource.TestCheckResourceAttr("data.pfptmeta_zone_records.zone", "mapped_hosts.0.mapped_host", "10.0.0.2"),
+					resource.TestCheckResourceAttr("data.pfptmeta_zone_records.zone", "mapped_hosts.1.name", "www.zone-records.example.com"),
+					resource.TestCheckResourceAttr("data.pfptmeta_zone_records.zone", "mapped_hosts.1.mapped_host", "db.zone-records.example.com"),
+					resource.TestCheckResourceAttr("data.pfptmeta_zone_records.zone", "mapped_domains.0.name", "legacy.zone-records.example.com"),
+					resource.TestCheckResourceAttr("data.pfptmeta_zone_records.zone", "mapped_domains.0.mapped_domain", "legacy.internal"),
+					resource.TestCheckResourceAttr("data.pfptmeta_zone_records.hosts", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.pfptmeta_zone_records.hosts", "mapped_hosts.0.name", "app.zone-records.example.com"),
+					resource.TestCheckResourceAttr("data.pfptmeta_zone_records.hosts", "mapped_hosts.0.mapped_host", "10.0.0.3"),
+				),
+			},
+		},
+	})
+}
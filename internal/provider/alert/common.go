@@ -2,6 +2,7 @@ package alert
 
 import (
 	"context"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
@@ -31,10 +32,108 @@ const (
 	opDesc         = "Operator used to compare to the threshold, ENUM: `greater`, `greaterequals`, `less`, `lessequals`, `equals`."
 	thresholdDesc  = "The threshold to compare result of the formula."
 	windowDesc     = "The time window of the check (in mins), ENUM: `1`, `3`, `5`, `10`, `30`, `60`, `360`, `1440`, `2880`, `10080`."
+	queryHitsDesc  = "The number of logs in the last `window` minutes matching `query_text` when it was last planned, when `dry_run` is enabled."
+	dryRunDesc     = "Run `query_text` against the logs of the last `window` minutes during plan, whenever the alert is created " +
+		"or its query, `group_by`, `source_type` or `window` change, and store the number of matching logs in `query_hits`. " +
+		"Failing queries are logged as warnings."
 )
 
+const (
+	queryTextDesc = "The query of the logs to alert on, in the Lucene query syntax, e.g. `event:keepalive AND src_type:MetaPort`.\n" +
+		"	The syntax is validated during plan, and fields which aren't known fields of the `source_type` logs are reported as warnings. Conflicts with `query`."
+	queryDesc           = "A structured query of the logs to alert on, rendered to `query_text`. Conflicts with `query_text`."
+	queryOperatorDesc   = "The operator combining the conditions, ENUM: `AND`, `OR`."
+	conditionDesc       = "A condition matching logs whose field has one of the values."
+	conditionFieldDesc  = "The name of the field, which should be a field of the `source_type` logs."
+	conditionValuesDesc = "The values to match. Values may use wildcards, e.g. `Meta*`."
+	conditionNegateDesc = "Match logs whose field has none of the values instead."
+)
+
+const suppressionSchedulesDesc = "List of time frame IDs during which the alert is suppressed, e.g. recurring change windows. " +
+	"The alert is still evaluated but no notifications are sent while any of the time frames is in effect."
+
 var excludedKeys = []string{"id", "spike_condition", "threshold_condition"}
 
+func validateQuerySyntax() func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, _ cty.Path) diag.Diagnostics {
+		if _, err := parseQuery(input.(string)); err != nil {
+			return diag.Errorf("invalid query_text: %s", err)
+		}
+		return nil
+	}
+}
+
+// customizeQueryDiff renders the query block to query_text and validates its syntax.
+// Unknown fields are only reported as warnings when the alert is applied, as CustomizeDiff can't return warnings.
+func customizeQueryDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if query := d.Get("query").([]interface{}); len(query) > 0 && query[0] != nil {
+		if !d.NewValueKnown("query") {
+			return d.SetNewComputed("query_text")
+		}
+		rendered := renderQuery(query[0].(map[string]interface{}))
+		if rendered != d.Get("query_text").(string) {
+			if err := d.SetNew("query_text", rendered); err != nil {
+				return err
+			}
+		}
+	}
+	if !d.NewValueKnown("query_text") {
+		return nil
+	}
+	_, err := UnknownFields(d.Get("source_type").(string), d.Get("query_text").(string), "")
+	return err
+}
+
+// unknownFieldWarnings warns about the fields of query_text and group_by which aren't known fields of the source_type logs.
+func unknownFieldWarnings(d *schema.ResourceData) diag.Diagnostics {
+	unknown, err := UnknownFields(d.Get("source_type").(string), d.Get("query_text").(string), d.Get("group_by").(string))
+	if err != nil {
+		return nil
+	}
+	var diags diag.Diagnostics
+	for _, u := range unknown {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unknown alert query field",
+			Detail:   u,
+		})
+	}
+	return diags
+}
+
+// dryRunDiff runs the alert's query during plan, if dry_run is enabled, so query_hits shows the number of logs
+// a new or changed query matches before it's applied. The query isn't run again on refresh.
+// Failing queries are only logged, as CustomizeDiff can't return warnings.
+func dryRunDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	queryKeys := []string{"query_text", "group_by", "source_type", "window"}
+	if !d.Get("dry_run").(bool) || (d.Id() != "" && !d.HasChanges(append(queryKeys, "dry_run")...)) {
+		return nil
+	}
+	for _, key := range queryKeys {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("query_hits")
+		}
+	}
+	q := &client.AlertQuery{
+		QueryText:  d.Get("query_text").(string),
+		SourceType: d.Get("source_type").(string),
+		Window:     d.Get("window").(int),
+	}
+	if groupBy := d.Get("group_by").(string); groupBy != "" {
+		q.GroupBy = &groupBy
+	}
+	c, ok := meta.(*client.Client)
+	if !ok {
+		return d.SetNewComputed("query_hits")
+	}
+	res, err := client.DryRunAlertQuery(ctx, c, q)
+	if err != nil {
+		log.Printf("[WARN] Could not run the query of alert %s: %v", d.Get("name").(string), err)
+		return nil
+	}
+	return d.SetNew("query_hits", res.Hits)
+}
+
 func alertToResource(d *schema.ResourceData, a *client.Alert) diag.Diagnostics {
 	var diags diag.Diagnostics
 	d.SetId(a.ID)
@@ -96,7 +195,7 @@ func alertCreate(ctx context.Context, d *schema.ResourceData, meta interface{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	return alertToResource(d, a)
+	return append(unknownFieldWarnings(d), alertToResource(d, a)...)
 }
 
 func alertUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -108,7 +207,7 @@ func alertUpdate(ctx context.Context, d *schema.ResourceData, meta interface{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	return alertToResource(d, a)
+	return append(unknownFieldWarnings(d), alertToResource(d, a)...)
 }
 
 func alertDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
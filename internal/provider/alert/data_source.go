@@ -32,6 +32,12 @@ func DataSource() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Computed:    true,
 			},
+			"suppression_schedules": {
+				Description: suppressionSchedulesDesc,
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
 			"group_by": {
 				Description: groupByDesc,
 				Type:        schema.TypeString,
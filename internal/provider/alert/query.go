@@ -0,0 +1,349 @@
+package alert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// commonFields are the log fields shared by all source types.
+var commonFields = []string{"@timestamp", "org_id", "src_ip", "user_agent", "country"}
+
+// sourceTypeFields are the known log fields of each source type, which can be used in query_text and group_by.
+// The lists aren't exhaustive, so other fields are only reported as warnings.
+var sourceTypeFields = map[string][]string{
+	"security_audit": {
+		"event", "event_type", "actor", "actor_id", "actor_type", "user_id", "email", "src_id", "src_type",
+		"device_id", "platform", "status", "reason", "auth_method", "idp_id", "posture_check_id", "location", "city",
+	},
+	"api_audit": {
+		"action", "resource_type", "resource_id", "actor", "actor_id", "actor_type", "api_key_id", "status",
+		"status_code", "method", "path",
+	},
+	"traffic_audit": {
+		"event", "src_type", "src_id", "src_port", "dst_ip", "dst_port", "dst_id", "dst_type", "protocol",
+		"hostname", "domain", "bytes_sent", "bytes_received", "duration", "user_id", "device_id", "metaport_id",
+		"pop", "action", "reason",
+	},
+	"webfilter_audit": {
+		"action", "url", "hostname", "domain", "category", "threat_category", "rule_id", "rule_name", "user_id",
+		"email", "device_id", "dst_ip", "method", "status_code", "file_name", "file_type", "file_hash", "app",
+		"app_category",
+	},
+}
+
+func fieldsOf(sourceType string) []string {
+	fields, ok := sourceTypeFields[sourceType]
+	if !ok {
+		return nil
+	}
+	res := append(append([]string{}, commonFields...), fields...)
+	sort.Strings(res)
+	return res
+}
+
+func isField(sourceType, field string) bool {
+	for _, f := range fieldsOf(sourceType) {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// validateField returns an error if the field isn't a field of the source type's logs.
+func validateField(sourceType, field string) error {
+	if fieldsOf(sourceType) == nil || isField(sourceType, field) {
+		return nil
+	}
+	return fmt.Errorf("\"%s\" is not a field of %s logs, supported fields are: %s",
+		field, sourceType, strings.Join(fieldsOf(sourceType), ", "))
+}
+
+// UnknownFields validates the syntax of an alert's query_text, and returns the fields of query_text and group_by
+// which aren't known fields of its source_type logs. Empty values are not validated.
+func UnknownFields(sourceType, queryText, groupBy string) ([]string, error) {
+	var res []string
+	if queryText != "" {
+		fields, err := parseQuery(queryText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query_text: %s", err)
+		}
+		seen := make(map[string]bool)
+		for _, field := range fields {
+			if seen[field] {
+				continue
+			}
+			seen[field] = true
+			if err := validateField(sourceType, field); err != nil {
+				res = append(res, fmt.Sprintf("query_text: %s", err))
+			}
+		}
+	}
+	if groupBy != "" {
+		if err := validateField(sourceType, groupBy); err != nil {
+			res = append(res, fmt.Sprintf("group_by: %s", err))
+		}
+	}
+	return res, nil
+}
+
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	quotedToken
+	openParenToken
+	closeParenToken
+	openRangeToken
+	closeRangeToken
+	colonToken
+	notToken
+	endToken
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+const specialChars = "():[]{}\""
+
+func tokenize(query string) ([]token, error) {
+	var res []token
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			res = append(res, token{openParenToken, "(", i})
+			i++
+		case r == ')':
+			res = append(res, token{closeParenToken, ")", i})
+			i++
+		case r == '[' || r == '{':
+			res = append(res, token{openRangeToken, string(r), i})
+			i++
+		case r == ']' || r == '}':
+			res = append(res, token{closeRangeToken, string(r), i})
+			i++
+		case r == ':':
+			res = append(res, token{colonToken, ":", i})
+			i++
+		case (r == '-' || r == '!') && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			res = append(res, token{notToken, string(r), i})
+			i++
+		case r == '"':
+			start := i
+			var value strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated quote at position %d", start)
+			}
+			i++
+			res = append(res, token{quotedToken, value.String(), start})
+		default:
+			start := i
+			var value strings.Builder
+			for ; i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(specialChars, runes[i]); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+			}
+			if value.String() == "NOT" {
+				res = append(res, token{notToken, "NOT", start})
+			} else {
+				res = append(res, token{wordToken, value.String(), start})
+			}
+		}
+	}
+	return append(res, token{endToken, "", len(runes)}), nil
+}
+
+// queryParser parses the lucene query syntax of query_text, i.e. terms of free text or field:value,
+// combined with AND, OR, NOT and parentheses, where values may be quoted, wildcards, ranges or groups.
+type queryParser struct {
+	tokens []token
+	pos    int
+	fields []string
+}
+
+// parseQuery returns the fields used by the query, or an error if its syntax is invalid.
+func parseQuery(query string) ([]string, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{tokens: tokens}
+	if err = p.parseOr(); err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != endToken {
+		return nil, fmt.Errorf("unexpected \"%s\" at position %d", t.value, t.pos)
+	}
+	return p.fields, nil
+}
+
+func (p *queryParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != endToken {
+		p.pos++
+	}
+	return t
+}
+
+func isOperator(t token, op string) bool {
+	return t.kind == wordToken && (t.value == op || (op == "AND" && t.value == "&&") || (op == "OR" && t.value == "||"))
+}
+
+func (p *queryParser) parseOr() error {
+	if err := p.parseAnd(); err != nil {
+		return err
+	}
+	for isOperator(p.peek(), "OR") {
+		p.next()
+		if err := p.parseAnd(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *queryParser) parseAnd() error {
+	if err := p.parseNot(); err != nil {
+		return err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case isOperator(t, "AND"):
+			p.next()
+		case t.kind == endToken || t.kind == closeParenToken || isOperator(t, "OR"):
+			return nil
+		}
+		// Terms without an operator between them are implicitly combined
+		if err := p.parseNot(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *queryParser) parseNot() error {
+	if p.peek().kind == notToken {
+		p.next()
+	}
+	return p.parseTerm()
+}
+
+func (p *queryParser) parseTerm() error {
+	t := p.next()
+	switch t.kind {
+	case openParenToken:
+		if err := p.parseOr(); err != nil {
+			return err
+		}
+		if closing := p.next(); closing.kind != closeParenToken {
+			return fmt.Errorf("missing \")\" for \"(\" at position %d", t.pos)
+		}
+		return nil
+	case quotedToken:
+		return nil
+	case wordToken:
+		if isOperator(t, "AND") || isOperator(t, "OR") {
+			return fmt.Errorf("missing term before \"%s\" at position %d", t.value, t.pos)
+		}
+		if p.peek().kind != colonToken {
+			return nil
+		}
+		p.next()
+		p.fields = append(p.fields, t.value)
+		return p.parseValue(t)
+	case endToken:
+		return fmt.Errorf("unexpected end of query")
+	}
+	return fmt.Errorf("unexpected \"%s\" at position %d", t.value, t.pos)
+}
+
+func (p *queryParser) parseValue(field token) error {
+	t := p.next()
+	switch t.kind {
+	case wordToken:
+		if isOperator(t, "AND") || isOperator(t, "OR") {
+			break
+		}
+		return nil
+	case quotedToken:
+		return nil
+	case openParenToken:
+		// A group of values of the same field, e.g. field:(a OR b)
+		if err := p.parseOr(); err != nil {
+			return err
+		}
+		if closing := p.next(); closing.kind != closeParenToken {
+			return fmt.Errorf("missing \")\" for \"(\" at position %d", t.pos)
+		}
+		return nil
+	case openRangeToken:
+		from, to := p.next(), p.next()
+		if from.kind != wordToken && from.kind != quotedToken || !isOperator(to, "TO") {
+			return fmt.Errorf("invalid range at position %d, ranges should be in the form [from TO to]", t.pos)
+		}
+		if end := p.next(); end.kind != wordToken && end.kind != quotedToken {
+			return fmt.Errorf("invalid range at position %d, ranges should be in the form [from TO to]", t.pos)
+		}
+		if closing := p.next(); closing.kind != closeRangeToken {
+			return fmt.Errorf("missing end of range starting at position %d", t.pos)
+		}
+		return nil
+	}
+	return fmt.Errorf("missing value of field \"%s\" at position %d", field.value, field.pos)
+}
+
+// quoteValue quotes a value of a query if it contains whitespace, special characters or operators,
+// or starts with - or !, which would negate it.
+func quoteValue(value string) string {
+	if value == "" || strings.ContainsAny(value, specialChars+" \t\n\\") || strings.ContainsAny(value[:1], "-!") ||
+		value == "AND" || value == "OR" || value == "NOT" || value == "TO" || value == "&&" || value == "||" {
+		return fmt.Sprintf("\"%s\"", strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(value))
+	}
+	return value
+}
+
+// renderQuery renders the structured query block as query_text.
+func renderQuery(query map[string]interface{}) string {
+	var terms []string
+	for _, c := range query["condition"].([]interface{}) {
+		condition := c.(map[string]interface{})
+		var values []string
+		for _, v := range condition["values"].([]interface{}) {
+			values = append(values, quoteValue(v.(string)))
+		}
+		term := fmt.Sprintf("%s:%s", condition["field"].(string), values[0])
+		if len(values) > 1 {
+			term = fmt.Sprintf("%s:(%s)", condition["field"].(string), strings.Join(values, " OR "))
+		}
+		if condition["negate"].(bool) {
+			term = "NOT " + term
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(terms, fmt.Sprintf(" %s ", query["operator"].(string)))
+}
@@ -0,0 +1,109 @@
+package alert
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	cases := map[string]struct {
+		Query  string
+		Fields []string
+		Error  string
+	}{
+		"fields":          {Query: "event:keepalive AND src_type:MetaPort", Fields: []string{"event", "src_type"}},
+		"free-text":       {Query: "keepalive", Fields: nil},
+		"implicit-and":    {Query: "event:keepalive src_type:MetaPort", Fields: []string{"event", "src_type"}},
+		"not-and-groups":  {Query: "NOT (action:CREATE OR -action:DELETE) && !resource_type:Device", Fields: []string{"action", "action", "resource_type"}},
+		"quoted":          {Query: `reason:"invalid password" OR reason:"user \"locked\""`, Fields: []string{"reason", "reason"}},
+		"value-group":     {Query: "action:(CREATE OR UPDATE)", Fields: []string{"action"}},
+		"range":           {Query: "bytes_sent:[1000 TO *]", Fields: []string{"bytes_sent"}},
+		"wildcard":        {Query: "hostname:*.example.com", Fields: []string{"hostname"}},
+		"empty":           {Query: " ", Error: "query cannot be empty"},
+		"unclosed-paren":  {Query: "(event:keepalive", Error: "missing \")\""},
+		"dangling-and":    {Query: "event:keepalive AND", Error: "unexpected end of query"},
+		"missing-value":   {Query: "event: AND src_type:MetaPort", Error: "missing value of field \"event\""},
+		"unclosed-quote":  {Query: `reason:"invalid`, Error: "unterminated quote"},
+		"invalid-range":   {Query: "bytes_sent:[1000 *]", Error: "invalid range"},
+		"leading-or":      {Query: "OR event:keepalive", Error: "missing term before \"OR\""},
+		"unexpected-char": {Query: "event:keepalive)", Error: "unexpected \")\""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fields, err := parseQuery(tc.Query)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Fields, fields)
+		})
+	}
+}
+
+func TestValidateField(t *testing.T) {
+	assert.NoError(t, validateField("traffic_audit", "src_type"))
+	assert.NoError(t, validateField("api_audit", "src_ip"))
+	assert.ErrorContains(t, validateField("api_audit", "src_type"), "\"src_type\" is not a field of api_audit logs")
+}
+
+func TestUnknownFields(t *testing.T) {
+	unknown, err := UnknownFields("api_audit", "action:CREATE AND src_type:MetaPort AND src_type:Device", "device_id")
+	assert.NoError(t, err)
+	assert.Len(t, unknown, 2)
+	assert.Contains(t, unknown[0], "query_text: \"src_type\" is not a field of api_audit logs")
+	assert.Contains(t, unknown[1], "group_by: \"device_id\" is not a field of api_audit logs")
+
+	_, err = UnknownFields("api_audit", "action:CREATE AND", "")
+	assert.EqualError(t, err, "invalid query_text: unexpected end of query")
+}
+
+func TestRenderQuery(t *testing.T) {
+	cases := map[string]struct {
+		Query    map[string]interface{}
+		Rendered string
+		Fields   []string
+	}{
+		"negated-group": {
+			Query: map[string]interface{}{
+				"operator": "AND",
+				"condition": []interface{}{
+					map[string]interface{}{"field": "event", "values": []interface{}{"keepalive"}, "negate": false},
+					map[string]interface{}{"field": "src_type", "values": []interface{}{"MetaPort", "Meta Port"}, "negate": true},
+				},
+			},
+			Rendered: `event:keepalive AND NOT src_type:(MetaPort OR "Meta Port")`,
+			Fields:   []string{"event", "src_type"},
+		},
+		"negation-prefixes": {
+			Query: map[string]interface{}{
+				"operator": "OR",
+				"condition": []interface{}{
+					map[string]interface{}{"field": "offset", "values": []interface{}{"-1"}, "negate": false},
+					map[string]interface{}{"field": "tag", "values": []interface{}{"!important", "a-b"}, "negate": false},
+				},
+			},
+			Rendered: `offset:"-1" OR tag:("!important" OR a-b)`,
+			Fields:   []string{"offset", "tag"},
+		},
+		"operators": {
+			Query: map[string]interface{}{
+				"operator": "AND",
+				"condition": []interface{}{
+					map[string]interface{}{"field": "op", "values": []interface{}{"&&", "||", "NOT"}, "negate": false},
+				},
+			},
+			Rendered: `op:("&&" OR "||" OR "NOT")`,
+			Fields:   []string{"op"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rendered := renderQuery(tc.Query)
+			assert.Equal(t, tc.Rendered, rendered)
+			fields, err := parseQuery(rendered)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Fields, fields)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package alert
 
 import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
 	"regexp"
@@ -8,6 +9,8 @@ import (
 
 const maxInt = int(^uint(0) >> 1)
 
+var fieldPattern = regexp.MustCompile("^[\\w@.-]*$")
+
 func Resource() *schema.Resource {
 	return &schema.Resource{
 		// This description is used by the documentation generator and the language server.
@@ -19,6 +22,7 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.Sequence(customizeQueryDiff, dryRunDiff),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -46,11 +50,20 @@ func Resource() *schema.Resource {
 				},
 				Required: true,
 			},
+			"suppression_schedules": {
+				Description: suppressionSchedulesDesc,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: common.ValidateID(false, "tmf"),
+				},
+			},
 			"group_by": {
 				Description:      groupByDesc,
 				Type:             schema.TypeString,
 				Optional:         true,
-				ValidateDiagFunc: common.ValidatePattern(regexp.MustCompile("^[\\w-]*$")),
+				ValidateDiagFunc: common.ValidatePattern(fieldPattern),
 			},
 			"notify_message": {
 				Description: notifyMessageDesc,
@@ -58,8 +71,59 @@ func Resource() *schema.Resource {
 				Optional:    true,
 			},
 			"query_text": {
-				Type:     schema.TypeString,
-				Required: true,
+				Description:      queryTextDesc,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"query_text", "query"},
+				ValidateDiagFunc: validateQuerySyntax(),
+			},
+			"query": {
+				Description:  queryDesc,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"query_text", "query"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"operator": {
+							Description:      queryOperatorDesc,
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "AND",
+							ValidateDiagFunc: common.ValidateStringENUM("AND", "OR"),
+						},
+						"condition": {
+							Description: conditionDesc,
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"field": {
+										Description:      conditionFieldDesc,
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateDiagFunc: common.ValidatePattern(fieldPattern),
+									},
+									"values": {
+										Description: conditionValuesDesc,
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+									"negate": {
+										Description: conditionNegateDesc,
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     false,
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 			"source_type": {
 				Description:      sourceTypeDesc,
@@ -140,6 +204,17 @@ func Resource() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"dry_run": {
+				Description: dryRunDesc,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"query_hits": {
+				Description: queryHitsDesc,
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 		},
 	}
 }
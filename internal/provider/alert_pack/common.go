@@ -0,0 +1,399 @@
+package alert_pack
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+)
+
+const (
+	description = "Creates a curated, versioned pack of alerts embedded in the provider, so common alerts don't need to be " +
+		"written again for every organization.\n\n" +
+		"The alerts of a pack can be customized with the notification channels, time windows and thresholds to use, " +
+		"and specific alerts can be disabled. When the pack is improved in a new version of the provider, " +
+		"the alerts are updated on the next apply. Alerts of the pack which are modified outside of terraform " +
+		"are restored on the next apply as well.\n\n" +
+		"Existing alerts can be imported as a pack with an ID in the form `<pack name>:<alert key>=<alert ID>,...`, " +
+		"e.g. `device_posture:posture_check_failures=alr-123,metaport_disconnected=alr-456`. " +
+		"Alerts of the pack which aren't listed are imported as disabled alerts.\n\n" +
+		"The following packs are available:\n"
+	nameDesc           = "The name of the pack."
+	namePrefixDesc     = "A prefix added to the names of the pack's alerts, e.g. to tell apart the alerts of several instances of a pack."
+	channelsDesc       = "List of notification channel IDs the alerts are sent to."
+	suppressionDesc    = "List of time frame IDs during which the pack's alerts are suppressed."
+	enabledDesc        = "Whether the pack's alerts are enabled."
+	windowDesc         = "The time window (in mins) of all the pack's alerts, instead of each alert's default window."
+	windowsDesc        = "The time windows (in mins) of specific alerts, mapping alert keys to windows."
+	thresholdsDesc     = "The thresholds of specific alerts with a threshold condition, mapping alert keys to thresholds."
+	disabledAlertsDesc = "Keys of alerts of the pack which should not be created."
+	versionDesc        = "The version of the pack the alerts were created from."
+	alertIDsDesc       = "The IDs of the created alerts, mapped by their keys."
+	modifiedAlertsDesc = "Keys of alerts of the pack which were modified outside of terraform, they are restored on the next apply."
+)
+
+var windows = []int{1, 3, 5, 10, 30, 60, 360, 1440, 2880, 10080}
+
+// packsDescription lists the available packs and their alerts for the documentation.
+func packsDescription() string {
+	var res strings.Builder
+	for _, name := range packNames() {
+		p := packs[name]
+		res.WriteString(fmt.Sprintf("- **%s** (version %s) - %s Alerts:\n", p.Name, p.Version, p.Description))
+		for _, a := range p.Alerts {
+			res.WriteString(fmt.Sprintf("	- `%s` - %s: %s\n", a.Key, a.Name, a.Description))
+		}
+	}
+	return res.String()
+}
+
+type getter interface {
+	Get(string) interface{}
+}
+
+// desiredAlerts returns the alerts of the pack to create, mapped by their keys, customized with the resource's parameters.
+func desiredAlerts(d getter, p *pack) map[string]*client.Alert {
+	disabled := make(map[string]bool)
+	for _, key := range d.Get("disabled_alerts").(*schema.Set).List() {
+		disabled[key.(string)] = true
+	}
+	var channels []string
+	for _, c := range d.Get("channels").([]interface{}) {
+		channels = append(channels, c.(string))
+	}
+	var suppressionSchedules []string
+	for _, tf := range d.Get("suppression_schedules").([]interface{}) {
+		suppressionSchedules = append(suppressionSchedules, tf.(string))
+	}
+	windowOverrides := d.Get("windows").(map[string]interface{})
+	thresholds := d.Get("thresholds").(map[string]interface{})
+	res := make(map[string]*client.Alert)
+	for _, def := range p.Alerts {
+		if disabled[def.Key] {
+			continue
+		}
+		a := def.Alert
+		a.Name = d.Get("name_prefix").(string) + def.Name
+		a.Channels = channels
+		a.SuppressionSchedules = suppressionSchedules
+		a.Enabled = d.Get("enabled").(bool)
+		if window := d.Get("window").(int); window != 0 {
+			a.Window = window
+		}
+		if window, ok := windowOverrides[def.Key]; ok {
+			a.Window = window.(int)
+		}
+		if def.ThresholdCondition != nil {
+			condition := *def.ThresholdCondition
+			if threshold, ok := thresholds[def.Key]; ok {
+				condition.Threshold = threshold.(int)
+			}
+			a.ThresholdCondition = &condition
+		}
+		if def.SpikeCondition != nil {
+			condition := *def.SpikeCondition
+			a.SpikeCondition = &condition
+		}
+		res[def.Key] = &a
+	}
+	return res
+}
+
+// validateParameters validates that the alert keys of the parameters are alerts of the pack.
+func validateParameters(d getter, p *pack) error {
+	var errs []string
+	validKey := func(attr, key string) bool {
+		if p.alert(key) == nil {
+			errs = append(errs, fmt.Sprintf("%s: \"%s\" is not an alert of pack %s, its alerts are: %s",
+				attr, key, p.Name, strings.Join(p.keys(), ", ")))
+			return false
+		}
+		return true
+	}
+	for _, key := range d.Get("disabled_alerts").(*schema.Set).List() {
+		validKey("disabled_alerts", key.(string))
+	}
+	for key, window := range d.Get("windows").(map[string]interface{}) {
+		if validKey("windows", key) && !common.ContainsInt(window.(int), windows) {
+			errs = append(errs, fmt.Sprintf("windows: the window of \"%s\" should be one of %v", key, windows))
+		}
+	}
+	for key := range d.Get("thresholds").(map[string]interface{}) {
+		if validKey("thresholds", key) && p.alert(key).ThresholdCondition == nil {
+			errs = append(errs, fmt.Sprintf("thresholds: \"%s\" has a spike condition, not a threshold condition", key))
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func customizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	p, ok := packs[d.Get("name").(string)]
+	if !ok {
+		return nil
+	}
+	if d.NewValueKnown("disabled_alerts") && d.NewValueKnown("windows") && d.NewValueKnown("thresholds") {
+		if err := validateParameters(d, p); err != nil {
+			return err
+		}
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	if d.Get("version").(string) != p.Version {
+		if err := d.SetNew("version", p.Version); err != nil {
+			return err
+		}
+		return d.SetNewComputed("alert_ids")
+	}
+	// Alerts were disabled, enabled or deleted outside of terraform
+	alertIDs := d.Get("alert_ids").(map[string]interface{})
+	desired := desiredAlerts(d, p)
+	changed := len(alertIDs) != len(desired)
+	for key := range desired {
+		if _, ok := alertIDs[key]; !ok {
+			changed = true
+		}
+	}
+	if changed && d.NewValueKnown("disabled_alerts") {
+		return d.SetNewComputed("alert_ids")
+	}
+	if d.Get("modified_alerts").(*schema.Set).Len() > 0 {
+		return d.SetNew("modified_alerts", []string{})
+	}
+	return nil
+}
+
+// alertModified returns whether the alert was modified outside of terraform, so it differs from the desired alert.
+func alertModified(desired, actual *client.Alert) bool {
+	a := *actual
+	a.ID, a.Type = desired.ID, desired.Type
+	if len(a.Channels) == 0 && len(desired.Channels) == 0 {
+		a.Channels = desired.Channels
+	}
+	if len(a.SuppressionSchedules) == 0 && len(desired.SuppressionSchedules) == 0 {
+		a.SuppressionSchedules = desired.SuppressionSchedules
+	}
+	return !reflect.DeepEqual(desired, &a)
+}
+
+func isNotFound(err error) bool {
+	errResponse, ok := err.(*client.ErrorResponse)
+	return ok && errResponse.Status == http.StatusNotFound
+}
+
+// applyPack creates, updates and deletes the pack's alerts to match the resource's parameters.
+// The IDs of the alerts are saved even if one of the requests fails, so the created alerts are not lost.
+func applyPack(ctx context.Context, c *client.Client, d *schema.ResourceData, alertIDs map[string]interface{}) diag.Diagnostics {
+	p := packs[d.Get("name").(string)]
+	desired := desiredAlerts(d, p)
+	ids := make(map[string]interface{})
+	for key, id := range alertIDs {
+		ids[key] = id
+	}
+	err := func() error {
+		for _, key := range p.keys() {
+			a, isDesired := desired[key]
+			id, exists := ids[key]
+			switch {
+			case isDesired && exists:
+				if _, err := client.UpdateAlert(ctx, c, id.(string), a); err != nil {
+					return fmt.Errorf("could not update alert %s: %v", key, err)
+				}
+			case isDesired:
+				created, err := client.CreateAlert(ctx, c, a)
+				if err != nil {
+					return fmt.Errorf("could not create alert %s: %v", key, err)
+				}
+				ids[key] = created.ID
+			case exists:
+				if _, err := client.DeleteAlert(ctx, c, id.(string)); err != nil && !isNotFound(err) {
+					return fmt.Errorf("could not delete alert %s: %v", key, err)
+				}
+				delete(ids, key)
+			}
+		}
+		return nil
+	}()
+	if setErr := d.Set("alert_ids", ids); setErr != nil {
+		return diag.FromErr(setErr)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("version", p.Version)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("modified_alerts", []string{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func alertPackCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	d.SetId(resource.PrefixedUniqueId(d.Get("name").(string) + "-"))
+	return applyPack(ctx, c, d, nil)
+}
+
+func alertPackRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(*client.Client)
+
+	var desired map[string]*client.Alert
+	if p, ok := packs[d.Get("name").(string)]; ok {
+		desired = desiredAlerts(d, p)
+	}
+	ids := make(map[string]interface{})
+	var modified []string
+	for key, alertID := range d.Get("alert_ids").(map[string]interface{}) {
+		a, err := client.GetAlert(ctx, c, alertID.(string))
+		if isNotFound(err) {
+			log.Printf("[WARN] Alert %s of alert pack %s is gone, it will be created again", alertID, d.Id())
+			continue
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ids[key] = alertID
+		if want, ok := desired[key]; ok && alertModified(want, a) {
+			log.Printf("[WARN] Alert %s of alert pack %s was modified, it will be restored", alertID, d.Id())
+			modified = append(modified, key)
+		}
+	}
+	err := d.Set("alert_ids", ids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("modified_alerts", modified)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return
+}
+
+// parseImportID parses an import ID in the form <pack name>:<alert key>=<alert ID>,... to the pack and its alert IDs.
+func parseImportID(id string) (*pack, map[string]interface{}, error) {
+	parts := strings.SplitN(id, ":", 2)
+	p, ok := packs[parts[0]]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown alert pack \"%s\", the available packs are: %s", parts[0], strings.Join(packNames(), ", "))
+	}
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, nil, fmt.Errorf("import ID should be in the form <pack name>:<alert key>=<alert ID>,..., got \"%s\"", id)
+	}
+	alertIDs := make(map[string]interface{})
+	for _, entry := range strings.Split(parts[1], ",") {
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, nil, fmt.Errorf("alerts should be in the form <alert key>=<alert ID>, got \"%s\"", entry)
+		}
+		if p.alert(kv[0]) == nil {
+			return nil, nil, fmt.Errorf("\"%s\" is not an alert of pack %s, its alerts are: %s", kv[0], p.Name, strings.Join(p.keys(), ", "))
+		}
+		alertIDs[kv[0]] = kv[1]
+	}
+	return p, alertIDs, nil
+}
+
+// alertPackImport imports existing alerts as a pack, deriving the pack's parameters from the alerts.
+func alertPackImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*client.Client)
+
+	p, alertIDs, err := parseImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	var disabled []string
+	windowOverrides := make(map[string]interface{})
+	thresholds := make(map[string]interface{})
+	first := true
+	for _, def := range p.Alerts {
+		alertID, ok := alertIDs[def.Key]
+		if !ok {
+			disabled = append(disabled, def.Key)
+			continue
+		}
+		a, err := client.GetAlert(ctx, c, alertID.(string))
+		if err != nil {
+			return nil, fmt.Errorf("could not read alert %s: %v", def.Key, err)
+		}
+		if first {
+			first = false
+			if strings.HasSuffix(a.Name, def.Name) {
+				if err = d.Set("name_prefix", strings.TrimSuffix(a.Name, def.Name)); err != nil {
+					return nil, err
+				}
+			}
+			if err = d.Set("channels", a.Channels); err != nil {
+				return nil, err
+			}
+			if err = d.Set("suppression_schedules", a.SuppressionSchedules); err != nil {
+				return nil, err
+			}
+			if err = d.Set("enabled", a.Enabled); err != nil {
+				return nil, err
+			}
+		}
+		if a.Window != def.Window {
+			windowOverrides[def.Key] = a.Window
+		}
+		if def.ThresholdCondition != nil && a.ThresholdCondition != nil && a.ThresholdCondition.Threshold != def.ThresholdCondition.Threshold {
+			thresholds[def.Key] = a.ThresholdCondition.Threshold
+		}
+	}
+	if err = d.Set("name", p.Name); err != nil {
+		return nil, err
+	}
+	if err = d.Set("disabled_alerts", disabled); err != nil {
+		return nil, err
+	}
+	if err = d.Set("windows", windowOverrides); err != nil {
+		return nil, err
+	}
+	if err = d.Set("thresholds", thresholds); err != nil {
+		return nil, err
+	}
+	if err = d.Set("alert_ids", alertIDs); err != nil {
+		return nil, err
+	}
+	if err = d.Set("version", p.Version); err != nil {
+		return nil, err
+	}
+	d.SetId(resource.PrefixedUniqueId(p.Name + "-"))
+	return []*schema.ResourceData{d}, nil
+}
+
+func alertPackUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	alertIDs, _ := d.GetChange("alert_ids")
+	return applyPack(ctx, c, d, alertIDs.(map[string]interface{}))
+}
+
+func alertPackDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	for _, alertID := range d.Get("alert_ids").(map[string]interface{}) {
+		_, err := client.DeleteAlert(ctx, c, alertID.(string))
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
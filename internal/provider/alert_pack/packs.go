@@ -0,0 +1,77 @@
+package alert_pack
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+)
+
+//go:embed packs/*.json
+var packFiles embed.FS
+
+// alertDefinition is an alert of a pack, identified within the pack by its key.
+type alertDefinition struct {
+	Key string `json:"key"`
+	client.Alert
+}
+
+// pack is a curated bundle of alerts. Its version should be bumped whenever its alerts change,
+// so resources created from an older version show a diff after upgrading the provider.
+type pack struct {
+	Name        string            `json:"name"`
+	Version     string            `json:"version"`
+	Description string            `json:"description"`
+	Alerts      []alertDefinition `json:"alerts"`
+}
+
+var packs = loadPacks()
+
+func loadPacks() map[string]*pack {
+	res := make(map[string]*pack)
+	entries, err := packFiles.ReadDir("packs")
+	if err != nil {
+		panic(fmt.Sprintf("could not read alert packs: %v", err))
+	}
+	for _, entry := range entries {
+		body, err := packFiles.ReadFile(path.Join("packs", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("could not read alert pack %s: %v", entry.Name(), err))
+		}
+		p := &pack{}
+		if err = json.Unmarshal(body, p); err != nil {
+			panic(fmt.Sprintf("could not parse alert pack %s: %v", entry.Name(), err))
+		}
+		res[p.Name] = p
+	}
+	return res
+}
+
+func packNames() []string {
+	res := make([]string, 0, len(packs))
+	for name := range packs {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (p *pack) alert(key string) *alertDefinition {
+	for i := range p.Alerts {
+		if p.Alerts[i].Key == key {
+			return &p.Alerts[i]
+		}
+	}
+	return nil
+}
+
+func (p *pack) keys() []string {
+	res := make([]string, len(p.Alerts))
+	for i, a := range p.Alerts {
+		res[i] = a.Key
+	}
+	return res
+}
@@ -0,0 +1,146 @@
+package alert_pack
+
+import (
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/alert"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+var versionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
+var placeholderPattern = regexp.MustCompile(`{{\s*([^{}]*?)\s*}}`)
+
+func TestPacks(t *testing.T) {
+	assert.NotEmpty(t, packs)
+	for name, p := range packs {
+		t.Run(name, func(t *testing.T) {
+			assert.Regexp(t, versionPattern, p.Version)
+			assert.NotEmpty(t, p.Alerts)
+			keys := make(map[string]bool)
+			for _, a := range p.Alerts {
+				assert.False(t, keys[a.Key], "duplicate alert key %s", a.Key)
+				keys[a.Key] = true
+				groupBy := ""
+				if a.GroupBy != nil {
+					groupBy = *a.GroupBy
+				}
+				unknown, err := alert.UnknownFields(a.SourceType, a.QueryText, groupBy)
+				assert.NoError(t, err, a.Key)
+				assert.Empty(t, unknown, a.Key)
+				assert.Contains(t, windows, a.Window, a.Key)
+				assert.True(t, (a.ThresholdCondition == nil) != (a.SpikeCondition == nil),
+					"%s should have either a threshold or a spike condition", a.Key)
+				assert.NotContains(t, a.NotifyMessage, "${", a.Key)
+				for _, match := range placeholderPattern.FindAllStringSubmatch(a.NotifyMessage, -1) {
+					switch match[1] {
+					case "hits":
+					case "group_value":
+						assert.NotNil(t, a.GroupBy, "%s uses group_value without group_by", a.Key)
+					default:
+						assert.Fail(t, "unknown placeholder", "%s: %s", a.Key, match[0])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDesiredAlerts(t *testing.T) {
+	d := Resource().TestResourceData()
+	assert.NoError(t, d.Set("name", "identity_threats"))
+	assert.NoError(t, d.Set("name_prefix", "[SOC] "))
+	assert.NoError(t, d.Set("channels", []string{"nch-123"}))
+	assert.NoError(t, d.Set("suppression_schedules", []string{"tmf-123"}))
+	assert.NoError(t, d.Set("window", 30))
+	assert.NoError(t, d.Set("windows", map[string]interface{}{"api_key_changes": 5}))
+	assert.NoError(t, d.Set("thresholds", map[string]interface{}{"brute_force": 20}))
+	assert.NoError(t, d.Set("disabled_alerts", []string{"password_resets"}))
+	p := packs["identity_threats"]
+	assert.NoError(t, validateParameters(d, p))
+
+	alerts := desiredAlerts(d, p)
+	assert.Len(t, alerts, len(p.Alerts)-1)
+	assert.NotContains(t, alerts, "password_resets")
+	assert.Equal(t, "[SOC] Brute force login attempts", alerts["brute_force"].Name)
+	assert.Equal(t, []string{"nch-123"}, alerts["brute_force"].Channels)
+	assert.Equal(t, []string{"tmf-123"}, alerts["brute_force"].SuppressionSchedules)
+	assert.Equal(t, 20, alerts["brute_force"].ThresholdCondition.Threshold)
+	assert.Equal(t, 30, alerts["brute_force"].Window)
+	assert.Equal(t, 5, alerts["api_key_changes"].Window)
+	// The pack's definitions are not modified
+	assert.Equal(t, 10, p.alert("brute_force").ThresholdCondition.Threshold)
+}
+
+func TestValidateParameters(t *testing.T) {
+	d := Resource().TestResourceData()
+	assert.NoError(t, d.Set("windows", map[string]interface{}{"metaport_disconnected": 7}))
+	assert.NoError(t, d.Set("thresholds", map[string]interface{}{"posture_failure_spike": 1}))
+	assert.NoError(t, d.Set("disabled_alerts", []string{"unknown"}))
+	err := validateParameters(d, packs["device_posture"])
+	assert.ErrorContains(t, err, "disabled_alerts: \"unknown\" is not an alert of pack device_posture")
+	assert.ErrorContains(t, err, "windows: the window of \"metaport_disconnected\" should be one of")
+	assert.ErrorContains(t, err, "thresholds: \"posture_failure_spike\" has a spike condition")
+}
+
+func TestAlertModified(t *testing.T) {
+	groupBy := "actor"
+	desired := func() *client.Alert {
+		d := Resource().TestResourceData()
+		_ = d.Set("channels", []string{"nch-123"})
+		return desiredAlerts(d, packs["identity_threats"])["brute_force"]
+	}
+	cases := map[string]struct {
+		modify   func(a *client.Alert)
+		modified bool
+	}{
+		"unmodified": {
+			modify: func(a *client.Alert) {
+				a.ID = "alr-123"
+				a.Type = "threshold"
+				a.SuppressionSchedules = []string{}
+				a.GroupBy = &groupBy
+				condition := *a.ThresholdCondition
+				a.ThresholdCondition = &condition
+			},
+		},
+		"threshold": {
+			modify: func(a *client.Alert) {
+				a.ThresholdCondition = &client.ThresholdCondition{Formula: "count", Op: "greater", Threshold: 1}
+			},
+			modified: true,
+		},
+		"channels":       {modify: func(a *client.Alert) { a.Channels = nil }, modified: true},
+		"enabled":        {modify: func(a *client.Alert) { a.Enabled = !a.Enabled }, modified: true},
+		"query_text":     {modify: func(a *client.Alert) { a.QueryText = "action:login" }, modified: true},
+		"notify_message": {modify: func(a *client.Alert) { a.NotifyMessage = "Brute force" }, modified: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual := *desired()
+			tc.modify(&actual)
+			assert.Equal(t, tc.modified, alertModified(desired(), &actual))
+		})
+	}
+}
+
+func TestParseImportID(t *testing.T) {
+	p, alertIDs, err := parseImportID("device_posture:posture_check_failures=alr-123,metaport_disconnected=alr-456")
+	assert.NoError(t, err)
+	assert.Equal(t, "device_posture", p.Name)
+	assert.Equal(t, map[string]interface{}{"posture_check_failures": "alr-123", "metaport_disconnected": "alr-456"}, alertIDs)
+
+	cases := map[string]string{
+		"unknown pack":  "unknown:brute_force=alr-123",
+		"no alerts":     "device_posture",
+		"no alert ID":   "device_posture:posture_check_failures",
+		"unknown alert": "device_posture:brute_force=alr-123",
+	}
+	for name, id := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := parseImportID(id)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package alert_pack
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description:   description + packsDescription(),
+		CreateContext: alertPackCreate,
+		ReadContext:   alertPackRead,
+		UpdateContext: alertPackUpdate,
+		DeleteContext: alertPackDelete,
+		CustomizeDiff: customizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: alertPackImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Description:      nameDesc,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: common.ValidateStringENUM(packNames()...),
+			},
+			"name_prefix": {
+				Description: namePrefixDesc,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"channels": {
+				Description: channelsDesc,
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: common.ValidateID(false, "nch"),
+				},
+			},
+			"suppression_schedules": {
+				Description: suppressionDesc,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: common.ValidateID(false, "tmf"),
+				},
+			},
+			"enabled": {
+				Description: enabledDesc,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"window": {
+				Description:      windowDesc,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: common.ValidateIntENUM(windows...),
+			},
+			"windows": {
+				Description: windowsDesc,
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"thresholds": {
+				Description: thresholdsDesc,
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"disabled_alerts": {
+				Description: disabledAlertsDesc,
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"version": {
+				Description: versionDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"alert_ids": {
+				Description: alertIDsDesc,
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"modified_alerts": {
+				Description: modifiedAlertsDesc,
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net"
+	"strings"
+)
+
+// CanonicalCIDR returns the form the API returns the CIDR in, e.g. "2001:db8::/32" for "2001:0DB8:0::/32".
+// CIDRs with host bits set are rejected by ValidateCIDR, so only the notation of valid CIDRs differs in practice.
+// Values which aren't CIDRs are returned as is, so they're left for the validation to reject.
+func CanonicalCIDR(v string) string {
+	_, ipNet, err := net.ParseCIDR(v)
+	if err != nil {
+		return v
+	}
+	return ipNet.String()
+}
+
+// CanonicalIP returns the form the API returns the IP address in, e.g. "2001:db8::1" for "2001:0db8:0:0::1".
+func CanonicalIP(v string) string {
+	ip := net.ParseIP(v)
+	if ip == nil {
+		return v
+	}
+	return ip.String()
+}
+
+// CanonicalHostname returns the form the API returns the hostname in, which is lower case.
+func CanonicalHostname(v string) string {
+	return strings.ToLower(v)
+}
+
+// CanonicalDomain returns the form the API returns the domain in, e.g. ".example.com" for ".Example.COM".
+// Domains may also be IP addresses, which are returned in their canonical form.
+func CanonicalDomain(v string) string {
+	if net.ParseIP(v) != nil {
+		return CanonicalIP(v)
+	}
+	return CanonicalHostname(v)
+}
+
+// CanonicalURL returns the form the API returns the URL in, in which only the host is canonical,
+// e.g. "example.com/Path" for "Example.COM/Path", since the paths of URLs are case sensitive.
+func CanonicalURL(v string) string {
+	scheme, rest := "", v
+	if i := strings.Index(v, "://"); i != -1 {
+		scheme, rest = strings.ToLower(v[:i+3]), v[i+3:]
+	}
+	host, path := rest, ""
+	if i := strings.Index(rest, "/"); i != -1 {
+		host, path = rest[:i], rest[i:]
+	}
+	return scheme + CanonicalDomain(host) + path
+}
+
+// SuppressEquivalentCIDR suppresses the diff of CIDRs which only differ in their notation.
+func SuppressEquivalentCIDR(_, old, new string, _ *schema.ResourceData) bool {
+	return CanonicalCIDR(old) == CanonicalCIDR(new)
+}
+
+// SuppressEquivalentHostname suppresses the diff of hostnames which only differ in their case.
+func SuppressEquivalentHostname(_, old, new string, _ *schema.ResourceData) bool {
+	return CanonicalHostname(old) == CanonicalHostname(new)
+}
+
+// SuppressEquivalentDomain suppresses the diff of domains which only differ in their case,
+// or of IP addresses which only differ in their notation.
+func SuppressEquivalentDomain(_, old, new string, _ *schema.ResourceData) bool {
+	return CanonicalDomain(old) == CanonicalDomain(new)
+}
+
+// SuppressEquivalentURL suppresses the diff of URLs whose hosts only differ in their case or notation.
+func SuppressEquivalentURL(_, old, new string, _ *schema.ResourceData) bool {
+	return CanonicalURL(old) == CanonicalURL(new)
+}
+
+// HashCIDR is the hash function of sets of CIDRs, so that CIDRs which only differ in their notation are the same element.
+func HashCIDR(v interface{}) int {
+	return schema.HashString(CanonicalCIDR(v.(string)))
+}
+
+// HashIP is the hash function of sets of IP addresses, so that addresses which only differ in their notation are the same element.
+func HashIP(v interface{}) int {
+	return schema.HashString(CanonicalIP(v.(string)))
+}
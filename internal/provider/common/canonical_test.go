@@ -0,0 +1,79 @@
+package common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCanonicalCIDR(t *testing.T) {
+	cases := map[string]struct {
+		Input    string
+		Expected string
+	}{
+		"ipv4":               {Input: "10.20.30.0/24", Expected: "10.20.30.0/24"},
+		"ipv6":               {Input: "2001:db8::/32", Expected: "2001:db8::/32"},
+		"ipv6-leading-zeros": {Input: "2001:0db8::/32", Expected: "2001:db8::/32"},
+		"ipv6-uppercase":     {Input: "2001:DB8:0:0::/64", Expected: "2001:db8::/64"},
+		"ipv4-host-bits":     {Input: "10.0.0.5/24", Expected: "10.0.0.0/24"},
+		"not-a-cidr":         {Input: "test.com", Expected: "test.com"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, CanonicalCIDR(tc.Input))
+		})
+	}
+	assert.True(t, SuppressEquivalentCIDR("cidrs.0", "2001:db8::/32", "2001:0db8::/32", nil))
+	assert.False(t, SuppressEquivalentCIDR("cidrs.0", "2001:db8::/32", "2001:db8::/48", nil))
+	assert.Equal(t, HashCIDR("2001:db8::/32"), HashCIDR("2001:0DB8::/32"))
+}
+
+func TestCanonicalIP(t *testing.T) {
+	assert.Equal(t, "2001:db8::1", CanonicalIP("2001:0db8:0:0::1"))
+	assert.Equal(t, "192.0.2.1", CanonicalIP("192.0.2.1"))
+	assert.Equal(t, "not-an-ip", CanonicalIP("not-an-ip"))
+	assert.Equal(t, HashIP("2001:db8::1"), HashIP("2001:DB8::0001"))
+}
+
+func TestCanonicalDomain(t *testing.T) {
+	cases := map[string]struct {
+		Input    string
+		Expected string
+	}{
+		"hostname":  {Input: "Mail.Example.COM", Expected: "mail.example.com"},
+		"subdomain": {Input: ".Example.com", Expected: ".example.com"},
+		"ipv4":      {Input: "192.0.2.1", Expected: "192.0.2.1"},
+		"ipv6":      {Input: "2001:DB8::0001", Expected: "2001:db8::1"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, CanonicalDomain(tc.Input))
+		})
+	}
+	assert.True(t, SuppressEquivalentDomain("domains.0", ".example.com", ".EXAMPLE.com", nil))
+	assert.False(t, SuppressEquivalentDomain("domains.0", ".example.com", "example.com", nil))
+	assert.True(t, SuppressEquivalentHostname("mapped_service", "service.example.com", "Service.Example.com", nil))
+	assert.False(t, SuppressEquivalentHostname("mapped_service", "service.example.com", "service.example.org", nil))
+}
+
+func TestCanonicalURL(t *testing.T) {
+	cases := map[string]struct {
+		Input    string
+		Expected string
+	}{
+		"hostname":    {Input: "Example.COM", Expected: "example.com"},
+		"path":        {Input: "Example.COM/Path/File.html", Expected: "example.com/Path/File.html"},
+		"scheme":      {Input: "HTTPS://Example.com/Path", Expected: "https://example.com/Path"},
+		"subdomain":   {Input: ".Example.com/Path", Expected: ".example.com/Path"},
+		"ipv6":        {Input: "2001:DB8::0001", Expected: "2001:db8::1"},
+		"ipv4-path":   {Input: "192.0.2.1/Path", Expected: "192.0.2.1/Path"},
+		"empty-path":  {Input: "example.com/", Expected: "example.com/"},
+		"only-scheme": {Input: "https://", Expected: "https://"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, CanonicalURL(tc.Input))
+		})
+	}
+	assert.True(t, SuppressEquivalentURL("urls.0", "example.com/Path", "EXAMPLE.com/Path", nil))
+	assert.False(t, SuppressEquivalentURL("urls.0", "example.com/Path", "example.com/path", nil))
+}
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// AttributePath converts the path of a field in an API request, e.g. "webhook_config.auth.oauth2_config.client_id",
+// to the path of the matching attribute in s.
+// The API represents blocks as objects while the schema represents them as single item lists, so their index is added.
+// The path is truncated at the first field that is not in the schema or that cannot be addressed, e.g. set elements.
+func AttributePath(s map[string]*schema.Schema, field string) cty.Path {
+	var path cty.Path
+	current := s
+	// list or set attribute whose index is expected next
+	var collection *schema.Schema
+	var mapPending bool
+	for _, part := range strings.Split(field, ".") {
+		if mapPending {
+			return path.Index(cty.StringVal(part))
+		}
+		if collection != nil {
+			if collection.Type != schema.TypeList {
+				return path
+			}
+			index, err := strconv.Atoi(part)
+			if err == nil {
+				path = path.IndexInt(index)
+				collection = nil
+				continue
+			}
+			// A field of a block, which is sent to the API as an object rather than a list
+			path = path.IndexInt(0)
+			collection = nil
+		}
+		if current == nil {
+			return path
+		}
+		attr, ok := current[part]
+		if !ok {
+			return path
+		}
+		path = path.GetAttr(part)
+		current = nil
+		switch attr.Type {
+		case schema.TypeList, schema.TypeSet:
+			collection = attr
+			if r, ok := attr.Elem.(*schema.Resource); ok {
+				current = r.Schema
+			}
+		case schema.TypeMap:
+			mapPending = true
+		}
+	}
+	return path
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAttributePath(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"name": {Type: schema.TypeString},
+		"tags": {Type: schema.TypeMap, Elem: &schema.Schema{Type: schema.TypeString}},
+		"mapped_subnets": {
+			Type: schema.TypeList,
+			Elem: &schema.Schema{Type: schema.TypeString},
+		},
+		"aliases": {
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{Type: schema.TypeString},
+		},
+		"webhook_config": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"url": {Type: schema.TypeString},
+				},
+			},
+		},
+	}
+	cases := map[string]struct {
+		Field    string
+		Expected cty.Path
+	}{
+		"attribute":          {"name", cty.GetAttrPath("name")},
+		"map-key":            {"tags.env", cty.GetAttrPath("tags").Index(cty.StringVal("env"))},
+		"list-item":          {"mapped_subnets.1", cty.GetAttrPath("mapped_subnets").IndexInt(1)},
+		"set-item":           {"aliases.0", cty.GetAttrPath("aliases")},
+		"block-field":        {"webhook_config.url", cty.GetAttrPath("webhook_config").IndexInt(0).GetAttr("url")},
+		"indexed-block":      {"webhook_config.0.url", cty.GetAttrPath("webhook_config").IndexInt(0).GetAttr("url")},
+		"unknown-attribute":  {"unknown", nil},
+		"unknown-nested-key": {"webhook_config.unknown", cty.GetAttrPath("webhook_config").IndexInt(0)},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, AttributePath(s, tc.Field))
+		})
+	}
+}
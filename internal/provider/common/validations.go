@@ -22,8 +22,10 @@ var hostnameLabelPattern = regexp.MustCompile("^[A-Za-z\\d\\_][A-Za-z\\d\\-\\_]{
 var TagPattern = regexp.MustCompile("^[a-zA-Z0-9-_]+$")
 var PrivilegesPattern = regexp.MustCompile("^[a-z_]+:(read|write)$")
 var HttpHeaderPattern = regexp.MustCompile("^([\\w\\-]+):(.*)$")
+var HttpHeaderNamePattern = regexp.MustCompile("^[\\w\\-]+$")
 var DomainPattern = regexp.MustCompile("^(?:[a-z0-9](?:[a-z0-9-_]{0,61}[a-z0-9])?\\.)+[a-z0-9][a-z0-9-_]{0,61}[a-z]$")
 var AccessIdPattern = regexp.MustCompile("^([A-Za-z0-9_-]={0,2}){40,50}$")
+var OrgShortnamePattern = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_-]{0,63}$")
 
 func containsString(v string, a []string) bool {
 	for _, i := range a {
@@ -34,7 +36,8 @@ func containsString(v string, a []string) bool {
 	return false
 }
 
-func containsInt(v int, a []int) bool {
+// ContainsInt returns whether the slice contains the int.
+func ContainsInt(v int, a []int) bool {
 	for _, i := range a {
 		if i == v {
 			return true
@@ -65,7 +68,7 @@ func ValidateIntENUM(enum ...int) func(interface{}, cty.Path) diag.Diagnostics {
 	return func(input interface{}, _ cty.Path) diag.Diagnostics {
 		var diags diag.Diagnostics
 		switch {
-		case containsInt(input.(int), enum):
+		case ContainsInt(input.(int), enum):
 			return diags
 		default:
 			return diag.Errorf("%d is not one of %+v", input, enum)
@@ -155,6 +158,20 @@ func ValidateIntRange(min, max int) func(interface{}, cty.Path) diag.Diagnostics
 	}
 }
 
+// ValidateFloatRange that float value is between specified range
+func ValidateFloatRange(min, max float64) func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, _ cty.Path) diag.Diagnostics {
+		inputFloat := input.(float64)
+		if inputFloat < min {
+			return diag.Errorf("%g is lower than minimum value %g", inputFloat, min)
+		}
+		if inputFloat > max {
+			return diag.Errorf("%g is higher than maximum value %g", inputFloat, max)
+		}
+		return nil
+	}
+}
+
 // ValidateStringToIntRange that integer value given as string is between specified range
 func ValidateStringToIntRange(min, max int) func(interface{}, cty.Path) diag.Diagnostics {
 	return func(input interface{}, _ cty.Path) diag.Diagnostics {
@@ -189,6 +206,17 @@ func ValidateHostnameOrIPV4() func(interface{}, cty.Path) diag.Diagnostics {
 	}
 }
 
+// ValidateHostnameOrIP validates that the input is a hostname, an IPv4 or an IPv6 address.
+func ValidateHostnameOrIP() func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, _ cty.Path) (diags diag.Diagnostics) {
+		inputString := input.(string)
+		if net.ParseIP(inputString) == nil && ValidateHostName()(inputString, nil).HasError() {
+			return diag.Errorf("\"%s\" is not a valid hostname or IP address", inputString)
+		}
+		return
+	}
+}
+
 func ValidateCustomUrlOrIPV4() func(interface{}, cty.Path) diag.Diagnostics {
 	return func(input interface{}, _ cty.Path) (diags diag.Diagnostics) {
 		inputString := input.(string)
@@ -261,6 +289,29 @@ func ValidateHTTPNetLocation() func(interface{}, cty.Path) diag.Diagnostics {
 	}
 }
 
+// ValidateHttpHeaders validates a map of HTTP header names to values
+func ValidateHttpHeaders() func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, path cty.Path) (diags diag.Diagnostics) {
+		for name, value := range input.(map[string]interface{}) {
+			if !HttpHeaderNamePattern.MatchString(name) {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       fmt.Sprintf("\"%s\" is not a valid HTTP header name", name),
+					AttributePath: path.Index(cty.StringVal(name)),
+				})
+			}
+			if s, _ := value.(string); strings.ContainsAny(s, "\r\n") {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       fmt.Sprintf("the value of HTTP header \"%s\" cannot contain line breaks", name),
+					AttributePath: path.Index(cty.StringVal(name)),
+				})
+			}
+		}
+		return
+	}
+}
+
 func ValidateJson() func(interface{}, cty.Path) diag.Diagnostics {
 	return func(input interface{}, _ cty.Path) (diags diag.Diagnostics) {
 		inputString := input.(string)
@@ -328,6 +379,31 @@ func ValidateCIDR4() func(interface{}, cty.Path) diag.Diagnostics {
 	}
 }
 
+func ValidateCIDR() func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, path cty.Path) (diags diag.Diagnostics) {
+		inputString := input.(string)
+		addr, ipNet, err := net.ParseCIDR(inputString)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !addr.Equal(ipNet.IP) {
+			return diag.Errorf("\"%s\" is not a valid CIDR, the address has bits set to the right of the mask, did you mean \"%s\"?",
+				inputString, ipNet)
+		}
+		return
+	}
+}
+
+func ValidateIP() func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, _ cty.Path) (diags diag.Diagnostics) {
+		inputString := input.(string)
+		if net.ParseIP(inputString) == nil {
+			return diag.Errorf("\"%s\" is not a valid IP address", inputString)
+		}
+		return
+	}
+}
+
 func ValidatePEMCert() func(interface{}, cty.Path) diag.Diagnostics {
 	return func(input interface{}, path cty.Path) (diags diag.Diagnostics) {
 		inputString := input.(string)
@@ -353,3 +429,45 @@ func ValidateIsoTimeFormat() func(interface{}, cty.Path) diag.Diagnostics {
 		return
 	}
 }
+
+var uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+func ValidateUUID() func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, path cty.Path) (diags diag.Diagnostics) {
+		inputString := input.(string)
+		if !uuidPattern.MatchString(inputString) {
+			return diag.Errorf("\"%s\" is not a valid UUID", inputString)
+		}
+		return
+	}
+}
+
+var s3BucketPattern = regexp.MustCompile("^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$")
+
+// ValidateS3BucketName validates the AWS S3 general purpose bucket naming rules
+func ValidateS3BucketName() func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, path cty.Path) (diags diag.Diagnostics) {
+		inputString := input.(string)
+		if !s3BucketPattern.MatchString(inputString) {
+			return diag.Errorf("\"%s\" is not a valid S3 bucket name - bucket names must be 3-63 characters long, "+
+				"consist of lowercase letters, numbers, dots and hyphens and begin and end with a letter or number", inputString)
+		}
+		if strings.Contains(inputString, "..") || strings.Contains(inputString, ".-") || strings.Contains(inputString, "-.") {
+			return diag.Errorf("\"%s\" is not a valid S3 bucket name - dots must not be adjacent to other dots or hyphens", inputString)
+		}
+		if net.ParseIP(inputString) != nil {
+			return diag.Errorf("\"%s\" is not a valid S3 bucket name - bucket names must not be formatted as an IP address", inputString)
+		}
+		for _, prefix := range []string{"xn--", "sthree-", "amzn-s3-demo-"} {
+			if strings.HasPrefix(inputString, prefix) {
+				return diag.Errorf("\"%s\" is not a valid S3 bucket name - the prefix \"%s\" is reserved", inputString, prefix)
+			}
+		}
+		for _, suffix := range []string{"-s3alias", "--ol-s3", "--x-s3", ".mrap"} {
+			if strings.HasSuffix(inputString, suffix) {
+				return diag.Errorf("\"%s\" is not a valid S3 bucket name - the suffix \"%s\" is reserved", inputString, suffix)
+			}
+		}
+		return
+	}
+}
@@ -213,6 +213,26 @@ func TestValidateIntRange(t *testing.T) {
 	}
 }
 
+func TestValidateFloatRange(t *testing.T) {
+	cases := map[string]struct {
+		Input       float64
+		ShouldError bool
+	}{
+		"positive-test":           {Input: 40.7, ShouldError: false},
+		"positive-test-inclusive": {Input: -90, ShouldError: false},
+		"negative-test-min":       {Input: -90.5, ShouldError: true},
+		"negative-test-max":       {Input: 91, ShouldError: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := ValidateFloatRange(-90, 90)(tc.Input, nil)
+			if diags.HasError() != tc.ShouldError {
+				t.Errorf("%s failed: %+v", name, diags)
+			}
+		})
+	}
+}
+
 func TestValidateHostnameOrIPV4(t *testing.T) {
 	cases := map[string]struct {
 		Input       string
@@ -257,6 +277,27 @@ func TestValidateHostnameOrIPV4(t *testing.T) {
 	}
 }
 
+func TestValidateHostnameOrIP(t *testing.T) {
+	cases := map[string]struct {
+		Input       string
+		ShouldError bool
+	}{
+		"hostname":       {Input: "db.example.com"},
+		"ipv4":           {Input: "10.0.0.1"},
+		"ipv6":           {Input: "2001:db8::1"},
+		"ipv6-uppercase": {Input: "2001:DB8::0001"},
+		"invalid-ipv4":   {Input: "127.0.0.1.", ShouldError: true},
+		"invalid-ipv6":   {Input: "2001:db8:::1", ShouldError: true},
+		"dot-suffix":     {Input: "test.com.", ShouldError: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := ValidateHostnameOrIP()(tc.Input, nil)
+			assert.Equal(t, tc.ShouldError, diags.HasError(), "%+v", diags)
+		})
+	}
+}
+
 var validPrivs = []string{
 	"orgs:read",
 	"orgs:write",
@@ -677,6 +718,78 @@ func TestValidateCIDR4(t *testing.T) {
 	}
 }
 
+func TestValidateCIDR(t *testing.T) {
+	cases := map[string]struct {
+		Input       string
+		ShouldError bool
+	}{
+		"positive-ipv4": {
+			Input:       "192.0.2.0/24",
+			ShouldError: false,
+		},
+		"positive-ipv6": {
+			Input:       "2001:db8::/32",
+			ShouldError: false,
+		},
+		"positive-ipv6-expanded": {
+			Input:       "2001:0DB8:0000::/48",
+			ShouldError: false,
+		},
+		"negative-ipv4-host-bits-set": {
+			Input:       "192.0.2.1/24",
+			ShouldError: true,
+		},
+		"negative-ipv6-host-bits-set": {
+			Input:       "2001:db8:a0b:12f0::1/32",
+			ShouldError: true,
+		},
+		"negative-no-prefix": {
+			Input:       "2001:db8::1",
+			ShouldError: true,
+		},
+		"negative-hostname": {
+			Input:       "test.com",
+			ShouldError: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := ValidateCIDR()(tc.Input, nil)
+			assert.Equal(t, tc.ShouldError, diags.HasError())
+		})
+	}
+}
+
+func TestValidateIP(t *testing.T) {
+	cases := map[string]struct {
+		Input       string
+		ShouldError bool
+	}{
+		"positive-ipv4": {
+			Input:       "192.0.2.1",
+			ShouldError: false,
+		},
+		"positive-ipv6": {
+			Input:       "2001:db8::1",
+			ShouldError: false,
+		},
+		"negative-cidr": {
+			Input:       "192.0.2.0/24",
+			ShouldError: true,
+		},
+		"negative-hostname": {
+			Input:       "test.com",
+			ShouldError: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := ValidateIP()(tc.Input, nil)
+			assert.Equal(t, tc.ShouldError, diags.HasError())
+		})
+	}
+}
+
 func TestValidatePEMCert(t *testing.T) {
 	cases := map[string]struct {
 		Input       string
@@ -728,3 +841,75 @@ AIU+2GKjyT3iMuzZxxFxPFMCAwEAAQ==
 		})
 	}
 }
+
+func TestValidateUUID(t *testing.T) {
+	cases := map[string]struct {
+		Input       string
+		ShouldError bool
+	}{
+		"positive-test": {
+			Input:       "0b7c3f6e-6d1a-4a7e-9f51-2d5c8e4b1a90",
+			ShouldError: false,
+		},
+		"negative-test-missing-group": {
+			Input:       "0b7c3f6e-6d1a-4a7e-2d5c8e4b1a90",
+			ShouldError: true,
+		},
+		"negative-test-not-hex": {
+			Input:       "0b7c3f6e-6d1a-4a7e-9f51-2d5c8e4b1z90",
+			ShouldError: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := ValidateUUID()(tc.Input, nil)
+			assert.Equal(t, tc.ShouldError, diags.HasError())
+		})
+	}
+}
+
+func TestValidateS3BucketName(t *testing.T) {
+	cases := map[string]struct {
+		Input       string
+		ShouldError bool
+	}{
+		"positive-test": {
+			Input:       "my-log-bucket",
+			ShouldError: false,
+		},
+		"positive-test-with-dots": {
+			Input:       "logs.example.com",
+			ShouldError: false,
+		},
+		"negative-test-too-short": {
+			Input:       "ab",
+			ShouldError: true,
+		},
+		"negative-test-uppercase": {
+			Input:       "My-Bucket",
+			ShouldError: true,
+		},
+		"negative-test-adjacent-dots": {
+			Input:       "my..bucket",
+			ShouldError: true,
+		},
+		"negative-test-ip-address": {
+			Input:       "192.168.5.4",
+			ShouldError: true,
+		},
+		"negative-test-reserved-prefix": {
+			Input:       "xn--bucket",
+			ShouldError: true,
+		},
+		"negative-test-reserved-suffix": {
+			Input:       "my-bucket-s3alias",
+			ShouldError: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := ValidateS3BucketName()(tc.Input, nil)
+			assert.Equal(t, tc.ShouldError, diags.HasError())
+		})
+	}
+}
@@ -58,6 +58,7 @@ func Resource() *schema.Resource {
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: common.ValidateCustomUrlOrIPV4(),
+					DiffSuppressFunc: common.SuppressEquivalentURL,
 				},
 				Optional: true,
 			},
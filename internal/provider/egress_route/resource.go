@@ -44,6 +44,7 @@ func Resource() *schema.Resource {
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: common.ComposeOrValidations(common.ValidateHostName(), common.ValidatePattern(regexp.MustCompile("^\\.$"))),
+					DiffSuppressFunc: common.SuppressEquivalentHostname,
 				},
 				Optional: true,
 			},
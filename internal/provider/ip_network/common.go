@@ -14,7 +14,7 @@ var excludedKeys = []string{"id", "org_id"}
 const (
 	description = "You can define ranges of IP addresses to be used as indicators of user location. " +
 		"These ranges are intended for use as determining conditions in other resources."
-	cirdsDesc     = "list of cidrs included in the network"
+	cirdsDesc     = "list of IPv4 or IPv6 cidrs included in the network"
 	countriesDesc = "list of countries included in the network"
 )
 
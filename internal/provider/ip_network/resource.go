@@ -34,7 +34,8 @@ func Resource() *schema.Resource {
 				Type:        schema.TypeList,
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
-					ValidateDiagFunc: common.ValidateCIDR4(),
+					ValidateDiagFunc: common.ValidateCIDR(),
+					DiffSuppressFunc: common.SuppressEquivalentCIDR,
 				},
 				MaxItems: 50,
 				Optional: true,
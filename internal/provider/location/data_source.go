@@ -42,6 +42,11 @@ func DataSource() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"dedicated_ips": {
+				Description: "Whether the POP was upgraded with dedicated IP ranges, which `POPS_WITH_DEDICATED_IPS` proxy_pops of the user settings use.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -0,0 +1,188 @@
+package locations
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+	"math"
+	"sort"
+)
+
+const (
+	description = "Lists the locations, which are the Points-of-Presence (POPs), optionally the nearest ones to coordinates or to a country. " +
+		"The names of the locations can be used as the POPs of tunnels or as the egress locations of egress routes, " +
+		"their countries as the locations of AAC rules and their proxy_pops as the proxy_pops of the user settings."
+	latitudeDesc  = "Latitude of the point to find the nearest locations to, in degrees."
+	longitudeDesc = "Longitude of the point to find the nearest locations to, in degrees."
+	countryDesc   = "Alpha-2 code (ISO-3166) of the country to find the nearest locations to. " +
+		"The locations of the country are the nearest, followed by the locations nearest to their center. " +
+		"The country must have at least one location. Enum: " + common.CountriesDoc
+	limitDesc            = "Maximum number of locations to return, e.g. 3 for the 3 nearest locations."
+	statusDesc           = "Only return locations with this status."
+	dedicatedIpsOnlyDesc = "Only return locations with dedicated IP ranges."
+	locationsDesc        = "The locations, the nearest first when querying by coordinates or by country, otherwise ordered by name."
+	distanceDesc         = "Great-circle distance from the queried coordinates or country, in kilometers. 0 when not querying by either."
+	dedicatedIpsDesc     = "Whether the location was upgraded with dedicated IP ranges."
+	namesDesc            = "Names of the locations, in the same order."
+	countriesDesc        = "Distinct countries of the locations, in the same order."
+	proxyPopsDesc        = "`POPS_WITH_DEDICATED_IPS` when all the locations have dedicated IP ranges, otherwise `ALL_POPS`."
+)
+
+const earthRadiusKm float64 = 6371
+
+// distanceKm returns the great-circle distance between two points, by the haversine formula.
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat, dLon := toRad(lat2-lat1), toRad(lon2-lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// countryCenter returns the mean coordinates of the locations of the country.
+func countryCenter(locations []client.Location, country string) (float64, float64, error) {
+	var lat, lon float64
+	var n int
+	for _, l := range locations {
+		if l.Country == country {
+			lat += float64(l.Latitude)
+			lon += float64(l.Longitude)
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, 0, fmt.Errorf("there are no locations in %s, query by coordinates instead", country)
+	}
+	return lat / float64(n), lon / float64(n), nil
+}
+
+type query struct {
+	// near is set when querying by coordinates or by country.
+	near             bool
+	latitude         float64
+	longitude        float64
+	country          string
+	limit            int
+	status           string
+	dedicatedIpsOnly bool
+}
+
+type locationDistance struct {
+	client.Location
+	distance float64
+}
+
+// selectLocations filters the locations and orders them by their distance from the queried point, or by name.
+func selectLocations(locations []client.Location, q query) ([]locationDistance, error) {
+	if q.country != "" {
+		var err error
+		q.latitude, q.longitude, err = countryCenter(locations, q.country)
+		if err != nil {
+			return nil, err
+		}
+		q.near = true
+	}
+	res := make([]locationDistance, 0, len(locations))
+	for _, l := range locations {
+		if q.status != "" && l.Status != q.status {
+			continue
+		}
+		if q.dedicatedIpsOnly && !l.DedicatedIps {
+			continue
+		}
+		ld := locationDistance{Location: l}
+		if q.near {
+			ld.distance = distanceKm(q.latitude, q.longitude, float64(l.Latitude), float64(l.Longitude))
+		}
+		res = append(res, ld)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if q.country != "" && (res[i].Country == q.country) != (res[j].Country == q.country) {
+			return res[i].Country == q.country
+		}
+		if res[i].distance != res[j].distance {
+			return res[i].distance < res[j].distance
+		}
+		return res[i].Name < res[j].Name
+	})
+	if q.limit > 0 && len(res) > q.limit {
+		res = res[:q.limit]
+	}
+	return res, nil
+}
+
+func locationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+	all, err := client.ListLocations(ctx, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	q := query{
+		country:          d.Get("country").(string),
+		limit:            d.Get("limit").(int),
+		status:           d.Get("status").(string),
+		dedicatedIpsOnly: d.Get("dedicated_ips_only").(bool),
+	}
+	// GetOk would treat the equator as unset, so the raw config tells whether coordinates were queried
+	if raw := d.GetRawConfig(); !raw.IsNull() && !raw.GetAttr("latitude").IsNull() {
+		q.near = true
+		q.latitude = d.Get("latitude").(float64)
+		q.longitude = d.Get("longitude").(float64)
+	}
+	selected, err := selectLocations(all, q)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("locations")
+	return locationsToResource(d, selected)
+}
+
+func locationsToResource(d *schema.ResourceData, selected []locationDistance) diag.Diagnostics {
+	locations := make([]map[string]interface{}, len(selected))
+	names := make([]string, len(selected))
+	var countries []string
+	proxyPops := "POPS_WITH_DEDICATED_IPS"
+	for i, l := range selected {
+		locations[i] = map[string]interface{}{
+			"name":          l.Name,
+			"city":          l.City,
+			"country":       l.Country,
+			"state":         l.State,
+			"latitude":      l.Latitude,
+			"longitude":     l.Longitude,
+			"status":        l.Status,
+			"dedicated_ips": l.DedicatedIps,
+			"distance_km":   math.Round(l.distance),
+		}
+		names[i] = l.Name
+		if !client.Contains(l.Country, countries) {
+			countries = append(countries, l.Country)
+		}
+		if !l.DedicatedIps {
+			proxyPops = "ALL_POPS"
+		}
+	}
+	if len(selected) == 0 {
+		proxyPops = "ALL_POPS"
+	}
+	err := d.Set("locations", locations)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("names", names)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("countries", countries)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("proxy_pops", proxyPops)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
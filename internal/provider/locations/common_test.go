@@ -0,0 +1,56 @@
+package locations
+
+import (
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testLocations = []client.Location{
+	{Name: "LGA", Country: "US", Latitude: 40.77, Longitude: -73.87, Status: "active", DedicatedIps: true},
+	{Name: "SFO", Country: "US", Latitude: 37.62, Longitude: -122.38, Status: "active", DedicatedIps: true},
+	{Name: "YYZ", Country: "CA", Latitude: 43.68, Longitude: -79.63, Status: "active"},
+	{Name: "LHR", Country: "GB", Latitude: 51.47, Longitude: -0.45, Status: "active", DedicatedIps: true},
+	{Name: "FRA", Country: "DE", Latitude: 50.04, Longitude: 8.56, Status: "maintenance", DedicatedIps: true},
+}
+
+func names(locations []locationDistance) []string {
+	res := make([]string, len(locations))
+	for i, l := range locations {
+		res[i] = l.Name
+	}
+	return res
+}
+
+func TestDistanceKm(t *testing.T) {
+	assert.InDelta(t, 5540, distanceKm(40.77, -73.87, 51.47, -0.45), 10)
+	assert.Equal(t, 0.0, distanceKm(51.47, -0.45, 51.47, -0.45))
+}
+
+func TestSelectLocations(t *testing.T) {
+	cases := map[string]struct {
+		query    query
+		expected []string
+		err      string
+	}{
+		"all by name":          {query: query{}, expected: []string{"FRA", "LGA", "LHR", "SFO", "YYZ"}},
+		"nearest to boston":    {query: query{near: true, latitude: 42.36, longitude: -71.06, limit: 2}, expected: []string{"LGA", "YYZ"}},
+		"nearest to paris":     {query: query{near: true, latitude: 48.86, longitude: 2.35}, expected: []string{"LHR", "FRA", "LGA", "YYZ", "SFO"}},
+		"country first":        {query: query{country: "US"}, expected: []string{"LGA", "SFO", "YYZ", "LHR", "FRA"}},
+		"country with limit":   {query: query{country: "CA", limit: 2}, expected: []string{"YYZ", "LGA"}},
+		"status":               {query: query{status: "active", near: true, latitude: 48.86, longitude: 2.35, limit: 2}, expected: []string{"LHR", "LGA"}},
+		"dedicated ips only":   {query: query{country: "CA", dedicatedIpsOnly: true}, expected: []string{"LGA", "SFO", "LHR", "FRA"}},
+		"country without pops": {query: query{country: "FR"}, err: "there are no locations in FR, query by coordinates instead"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			res, err := selectLocations(testLocations, tc.query)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, names(res))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package locations
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+)
+
+const maxInt = int(^uint(0) >> 1)
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: description,
+
+		ReadContext: locationsRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latitude": {
+				Description:      latitudeDesc,
+				Type:             schema.TypeFloat,
+				Optional:         true,
+				RequiredWith:     []string{"longitude"},
+				ConflictsWith:    []string{"country"},
+				ValidateDiagFunc: common.ValidateFloatRange(-90, 90),
+			},
+			"longitude": {
+				Description:      longitudeDesc,
+				Type:             schema.TypeFloat,
+				Optional:         true,
+				RequiredWith:     []string{"latitude"},
+				ConflictsWith:    []string{"country"},
+				ValidateDiagFunc: common.ValidateFloatRange(-180, 180),
+			},
+			"country": {
+				Description:      countryDesc,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: common.ValidateStringENUM(common.Countries...),
+			},
+			"limit": {
+				Description:      limitDesc,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: common.ValidateIntRange(1, maxInt),
+			},
+			"status": {
+				Description: statusDesc,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"dedicated_ips_only": {
+				Description: dedicatedIpsOnlyDesc,
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"locations": {
+				Description: locationsDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"city": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"country": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"latitude": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"longitude": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dedicated_ips": {
+							Description: dedicatedIpsDesc,
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"distance_km": {
+							Description: distanceDesc,
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"names": {
+				Description: namesDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"countries": {
+				Description: countriesDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"proxy_pops": {
+				Description: proxyPopsDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -2,11 +2,13 @@ package log_streaming_access_bridge
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
 	"log"
 	"net/http"
+	"regexp"
 )
 
 const (
@@ -20,11 +22,18 @@ Proofpoint supports the following log streaming standards:
 - Syslog Common Event Format (CEF), an open-source log management standard. CEF allows third parties to create their own device schemas that are compatible with industry-standard methods for normalizing security events.
 - Proofpoint CASB - Proofpoint CASB service that accepts traffic or web security logs for subsequent shadow IT processing.
 This integration enables organizations to govern user access to both IT-authorized and unauthorized apps (also known as shadow IT)
+- Elasticsearch / OpenSearch bulk API indexing.
+- Azure Sentinel through the Log Analytics HTTP Data Collector API.
+- Google Chronicle ingestion API.
+- Sumo Logic hosted HTTP collector sources.
+- Datadog log intake API.
+
+A warning is reported when an enabled log streamer is in one of the non-running states, together with the reported status description.
 
 You can use [**pfptmeta_notification_channel**](https://registry.terraform.io/providers/nsofnetworks/pfptmeta/latest/docs/resources/notification_channel) for receiving alerts on events from log streaming service.
 When the log streamer status changes to one of the non-running states (error, suspended, stopped) a notification event is triggered.`
 	notificationChannelDesc = "Notification channel IDs to which an alert will be sent if the log streaming service becomes unavailable or the endpoint is unreachable."
-	exportLogsDesc          = "Enum: `api` `traffic` `security` `metaproxy` `webfilter`."
+	exportLogsDesc          = "Enum: `api` `traffic` `security` `metaproxy` `webfilter`. Proofpoint CASB accepts `traffic` and `webfilter` only."
 
 	proofpointCASBConfig = "Configuration for log streaming to Proofpoint CASB for shadow IT processing."
 	casbRegionDesc       = "Tenant region in Proofpoint CASB system. ENUM: `EU`, `US`."
@@ -37,7 +46,15 @@ When the log streamer status changes to one of the non-running states (error, su
 	s3ConfDesc     = "Configuration for log streaming to an Amazon S3 bucket."
 	s3BucketDesc   = "Name of your AWS S3 bucket. The bucket must have proper writing permissions and access rights for the Proofpoint logging."
 	s3CompressDesc = "Defines whether to compress log objects using gzip or not."
-	s3Prefix       = "Shared name prefix for destination object in your AWS S3 bucket."
+	s3Prefix       = "Shared name prefix for destination object in your AWS S3 bucket. " +
+		"The prefix may contain `{{ variable }}` placeholders which are replaced when the objects are written: " +
+		"`log_type`, `date` (`YYYY-MM-DD`), `year`, `month`, `day` and `hour`, e.g. `meta/{{ log_type }}/{{ year }}/{{ month }}/{{ day }}/`."
+	s3PrincipalArnDesc = "ARN of the AWS principal which writes the log objects into the S3 bucket. " +
+		"The principal is assigned when the log streamer is created, so it is only known after the log streamer is created."
+	s3BucketPolicyDesc = "JSON bucket policy document which allows `s3_principal_arn` to write objects under the prefix of the S3 bucket. " +
+		"Can be passed to the `policy` argument of the AWS provider `aws_s3_bucket_policy` resource."
+	s3TwoStepApplyDesc = " Since the policy is only known after the log streamer is created, create the log streamer with `enabled = false`, " +
+		"apply the bucket policy, and then enable the log streamer in a second apply."
 
 	splunkDesc = "Configuration for log streaming to Self-Hosted / cloud Splunk." +
 		" see [here](https://help.metanetworks.com/knowledgebase/log_streaming_for_splunk_self_hosted/#configuring-splunk-http-event-collector) for instructions on how to enable HTTP Event Collector on Self-Hosted Instance," +
@@ -49,8 +66,37 @@ When the log streamer status changes to one of the non-running states (error, su
 
 	syslogDesc      = "Configuration for log streaming in Syslog Common Event Format (CEF)."
 	syslogHostDesc  = "SIEM destination FQDN."
-	syslogPortDesc  = "TCP port for log data input."
+	syslogPortDesc  = "TCP or UDP port for log data input. Ports `601` and `6514` are reserved for syslog over TCP."
 	syslogProtoDesc = "ENUM: `tcp`, `udp`."
+
+	elasticDesc             = "Configuration for log streaming to an Elasticsearch or OpenSearch cluster using the bulk API."
+	elasticApiKeyDesc       = "Base64 encoded API key used for authentication. Conflicts with `username` and `password`."
+	elasticCertDesc         = "Base64 root CA certificate of the cluster."
+	elasticDistributionDesc = "ENUM: `elasticsearch`, `opensearch`. Defaults to `elasticsearch`."
+	elasticIndexDesc        = "Name of the index or data stream the logs are written to."
+	elasticPasswordDesc     = "Password used for basic authentication."
+	elasticURLDesc          = "Cluster URL, e.g. `https://logs.example.com:9200`."
+	elasticUsernameDesc     = "Username used for basic authentication."
+
+	sentinelDesc            = "Configuration for log streaming to Azure Sentinel using the Log Analytics HTTP Data Collector API."
+	sentinelLogTypeDesc     = "Custom log table name. Azure appends the `_CL` suffix. Defaults to `ProofpointMeta`."
+	sentinelSharedKeyDesc   = "Primary or secondary key of the Log Analytics workspace."
+	sentinelWorkspaceIDDesc = "Log Analytics workspace ID."
+
+	chronicleDesc            = "Configuration for log streaming to Google Chronicle using the ingestion API."
+	chronicleCredentialsDesc = "Google Cloud service account credentials JSON with access to the Chronicle ingestion API."
+	chronicleCustomerIDDesc  = "Chronicle customer ID."
+	chronicleLogTypeDesc     = "Chronicle log type the logs are ingested as. Defaults to `PROOFPOINT_META`."
+	chronicleRegionDesc      = "Chronicle instance region. ENUM: `us`, `europe`, `europe-west2`, `asia-southeast1`, `australia-southeast1`, `me-central2`. Defaults to `us`."
+
+	sumoLogicDesc    = "Configuration for log streaming to a Sumo Logic hosted HTTP collector source."
+	sumoLogicURLDesc = "Unique URL of the HTTP source. The URL contains the source token, so it is treated as sensitive and is not returned by the API."
+
+	datadogDesc        = "Configuration for log streaming to Datadog."
+	datadogApiKeyDesc  = "Datadog API key."
+	datadogServiceDesc = "Value of the `service` attribute set on the ingested logs."
+	datadogSiteDesc    = "Datadog site. ENUM: `datadoghq.com`, `us3.datadoghq.com`, `us5.datadoghq.com`, `datadoghq.eu`, `ap1.datadoghq.com`, `ddog-gov.com`. Defaults to `datadoghq.com`."
+	datadogTagsDesc    = "Tags added to the ingested logs in the `key:value` format."
 )
 
 const (
@@ -59,10 +105,173 @@ const (
 	s3             = "s3"
 	splunkHTTP     = "splunk_http"
 	sysLog         = "syslog"
+
+	elastic         = "elastic"
+	azureSentinel   = "azure_sentinel"
+	googleChronicle = "google_chronicle"
+	sumoLogic       = "sumo_logic"
+	datadog         = "datadog"
 )
 
+var (
+	exportLogTypes   = []string{"api", "traffic", "security", "metaproxy", "webfilter"}
+	chronicleRegions = []string{"us", "europe", "europe-west2", "asia-southeast1", "australia-southeast1", "me-central2"}
+	datadogSites     = []string{"datadoghq.com", "us3.datadoghq.com", "us5.datadoghq.com", "datadoghq.eu", "ap1.datadoghq.com", "ddog-gov.com"}
+
+	elasticIndexPattern    = regexp.MustCompile("^[a-z0-9][a-z0-9_.+-]{0,254}$")
+	sentinelLogTypePattern = regexp.MustCompile("^[A-Za-z0-9_]{1,100}$")
+	datadogTagPattern      = regexp.MustCompile("^[a-z][a-z0-9_./-]*:[^\\s,]+$")
+)
+
+// siemConfigs are the mutually exclusive configuration blocks of the SIEM destinations.
+var siemConfigs = []string{
+	"proofpoint_casb_config", "qradar_http_config", "s3_config", "splunk_http_config", "syslog_config",
+	"elastic_config", "azure_sentinel_config", "google_chronicle_config", "sumo_logic_config", "datadog_config",
+}
+
+func otherSiemConfigs(config string) []string {
+	var res []string
+	for _, c := range siemConfigs {
+		if c != config {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// supportedExportLogs lists the log types a destination accepts when it does not accept all of them.
+var supportedExportLogs = map[string][]string{
+	"proofpoint_casb_config": {"traffic", "webfilter"},
+}
+
+// tcpOnlySyslogPorts are the IANA registered syslog ports that are not defined for UDP.
+var tcpOnlySyslogPorts = map[int]string{
+	601:  "syslog over TCP (RFC 3195)",
+	6514: "syslog over TLS (RFC 5425)",
+}
+
+// unhealthyStatuses are the non-running log streamer states.
+var unhealthyStatuses = []string{"error", "suspended", "stopped"}
+
 var ExcludedKeys = []string{"id", "siem_config", "type"}
 
+func validateSyslogPort(proto string, port int) error {
+	if reserved, ok := tcpOnlySyslogPorts[port]; ok && proto == "udp" {
+		return fmt.Errorf("syslog_config: port %d is reserved for %s and cannot be used with proto \"udp\"", port, reserved)
+	}
+	return nil
+}
+
+func validateExportLogs(config string, exportLogs []string) error {
+	seen := make(map[string]bool, len(exportLogs))
+	for _, logType := range exportLogs {
+		if seen[logType] {
+			return fmt.Errorf("export_logs: log type \"%s\" is set more than once", logType)
+		}
+		seen[logType] = true
+		if supported, ok := supportedExportLogs[config]; ok && !client.Contains(logType, supported) {
+			return fmt.Errorf("export_logs: log type \"%s\" is not supported by %s, supported log types: %v", logType, config, supported)
+		}
+	}
+	return nil
+}
+
+func validateSiemConfig(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if conf := d.Get("syslog_config").([]interface{}); len(conf) > 0 && conf[0] != nil && d.NewValueKnown("syslog_config") {
+		confMap := conf[0].(map[string]interface{})
+		if err := validateSyslogPort(confMap["proto"].(string), confMap["port"].(int)); err != nil {
+			return err
+		}
+	}
+	if !d.NewValueKnown("export_logs") {
+		return nil
+	}
+	var exportLogs []string
+	for _, logType := range d.Get("export_logs").([]interface{}) {
+		if logType != nil {
+			exportLogs = append(exportLogs, logType.(string))
+		}
+	}
+	for _, config := range siemConfigs {
+		if conf := d.Get(config).([]interface{}); len(conf) > 0 {
+			return validateExportLogs(config, exportLogs)
+		}
+	}
+	return nil
+}
+
+func s3PolicyToResource(d *schema.ResourceData, conf *client.S3Config) diag.Diagnostics {
+	var policy string
+	if conf.PrincipalArn != "" {
+		var err error
+		policy, err = bucketPolicy(conf.PrincipalArn, conf.Bucket, conf.Prefix)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	err := d.Set("s3_principal_arn", conf.PrincipalArn)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("s3_bucket_policy", policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// s3BucketPolicyDiff updates the planned bucket policy when the S3 configuration changes.
+func s3BucketPolicyDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChange("s3_config") {
+		return nil
+	}
+	conf := d.Get("s3_config").([]interface{})
+	if len(conf) == 0 || conf[0] == nil {
+		if err := d.SetNew("s3_principal_arn", ""); err != nil {
+			return err
+		}
+		return d.SetNew("s3_bucket_policy", "")
+	}
+	principalArn := d.Get("s3_principal_arn").(string)
+	if principalArn == "" {
+		if err := d.SetNewComputed("s3_principal_arn"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("s3_bucket_policy")
+	}
+	if !d.NewValueKnown("s3_config") {
+		return d.SetNewComputed("s3_bucket_policy")
+	}
+	confMap := conf[0].(map[string]interface{})
+	policy, err := bucketPolicy(principalArn, confMap["bucket"].(string), confMap["prefix"].(string))
+	if err != nil {
+		return err
+	}
+	return d.SetNew("s3_bucket_policy", policy)
+}
+
+// statusDiagnostics warns when an enabled log streamer is not running.
+func statusDiagnostics(ab *client.AccessBridge) diag.Diagnostics {
+	if !ab.Enabled || !client.Contains(ab.Status, unhealthyStatuses) {
+		return nil
+	}
+	detail := ab.StatusDescription
+	if detail == "" {
+		detail = "No status description was reported."
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("log streaming access bridge %s (%s) is %s", ab.Name, ab.ID, ab.Status),
+		Detail:   detail,
+	}}
+}
+
+// secretValue returns a write-only value from the configuration, since the API does not return it.
+func secretValue(d *schema.ResourceData, key string) string {
+	v, _ := d.Get(key).(string)
+	return v
+}
+
 func accessBridgeToResource(d *schema.ResourceData, ab *client.AccessBridge) (diags diag.Diagnostics) {
 	d.SetId(ab.ID)
 	err := client.MapResponseToResource(ab, d, ExcludedKeys)
@@ -101,6 +310,7 @@ func accessBridgeToResource(d *schema.ResourceData, ab *client.AccessBridge) (di
 		}
 	case s3:
 		if ab.SiemConfig.S3Config != nil {
+			diags = append(diags, s3PolicyToResource(d, ab.SiemConfig.S3Config)...)
 			conf := []map[string]interface{}{
 				{
 					"bucket":   ab.SiemConfig.S3Config.Bucket,
@@ -142,6 +352,80 @@ func accessBridgeToResource(d *schema.ResourceData, ab *client.AccessBridge) (di
 				return diag.FromErr(err)
 			}
 		}
+	case elastic:
+		if ab.SiemConfig.ElasticConfig != nil {
+			conf := []map[string]interface{}{
+				{
+					"api_key":      secretValue(d, "elastic_config.0.api_key"),
+					"certificate":  secretValue(d, "elastic_config.0.certificate"),
+					"distribution": ab.SiemConfig.ElasticConfig.Distribution,
+					"index":        ab.SiemConfig.ElasticConfig.Index,
+					"password":     secretValue(d, "elastic_config.0.password"),
+					"url":          ab.SiemConfig.ElasticConfig.Url,
+					"username":     ab.SiemConfig.ElasticConfig.Username,
+				},
+			}
+			err = d.Set("elastic_config", conf)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	case azureSentinel:
+		if ab.SiemConfig.AzureSentinelConfig != nil {
+			conf := []map[string]interface{}{
+				{
+					"log_type":     ab.SiemConfig.AzureSentinelConfig.LogType,
+					"shared_key":   secretValue(d, "azure_sentinel_config.0.shared_key"),
+					"workspace_id": ab.SiemConfig.AzureSentinelConfig.WorkspaceId,
+				},
+			}
+			err = d.Set("azure_sentinel_config", conf)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	case googleChronicle:
+		if ab.SiemConfig.GoogleChronicleConfig != nil {
+			conf := []map[string]interface{}{
+				{
+					"credentials": secretValue(d, "google_chronicle_config.0.credentials"),
+					"customer_id": ab.SiemConfig.GoogleChronicleConfig.CustomerId,
+					"log_type":    ab.SiemConfig.GoogleChronicleConfig.LogType,
+					"region":      ab.SiemConfig.GoogleChronicleConfig.Region,
+				},
+			}
+			err = d.Set("google_chronicle_config", conf)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	case sumoLogic:
+		if ab.SiemConfig.SumoLogicConfig != nil {
+			conf := []map[string]interface{}{
+				{
+					"url": secretValue(d, "sumo_logic_config.0.url"),
+				},
+			}
+			err = d.Set("sumo_logic_config", conf)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	case datadog:
+		if ab.SiemConfig.DatadogConfig != nil {
+			conf := []map[string]interface{}{
+				{
+					"api_key": secretValue(d, "datadog_config.0.api_key"),
+					"service": ab.SiemConfig.DatadogConfig.Service,
+					"site":    ab.SiemConfig.DatadogConfig.Site,
+					"tags":    ab.SiemConfig.DatadogConfig.Tags,
+				},
+			}
+			err = d.Set("datadog_config", conf)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
 	}
 	exportLogs := make([]string, len(ab.SiemConfig.ExportLogs))
 	for i, val := range ab.SiemConfig.ExportLogs {
@@ -151,7 +435,10 @@ func accessBridgeToResource(d *schema.ResourceData, ab *client.AccessBridge) (di
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	return
+	if ab.SiemConfig.Type != s3 {
+		diags = append(diags, s3PolicyToResource(d, &client.S3Config{})...)
+	}
+	return append(diags, statusDiagnostics(ab)...)
 }
 
 func abRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
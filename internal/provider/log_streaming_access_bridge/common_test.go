@@ -0,0 +1,92 @@
+package log_streaming_access_bridge
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateSyslogPort(t *testing.T) {
+	cases := map[string]struct {
+		Proto       string
+		Port        int
+		ShouldError bool
+	}{
+		"udp-default-port":  {Proto: "udp", Port: 514},
+		"tcp-default-port":  {Proto: "tcp", Port: 514},
+		"tcp-tls-port":      {Proto: "tcp", Port: 6514},
+		"udp-tls-port":      {Proto: "udp", Port: 6514, ShouldError: true},
+		"udp-reliable-port": {Proto: "udp", Port: 601, ShouldError: true},
+		"udp-custom-port":   {Proto: "udp", Port: 5514},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateSyslogPort(tc.Proto, tc.Port)
+			assert.Equal(t, tc.ShouldError, err != nil)
+		})
+	}
+}
+
+func TestValidateExportLogs(t *testing.T) {
+	cases := map[string]struct {
+		Config      string
+		ExportLogs  []string
+		ShouldError bool
+	}{
+		"all-log-types": {
+			Config:     "splunk_http_config",
+			ExportLogs: exportLogTypes,
+		},
+		"casb-supported-log-types": {
+			Config:     "proofpoint_casb_config",
+			ExportLogs: []string{"traffic", "webfilter"},
+		},
+		"casb-unsupported-log-type": {
+			Config:      "proofpoint_casb_config",
+			ExportLogs:  []string{"traffic", "api"},
+			ShouldError: true,
+		},
+		"duplicate-log-type": {
+			Config:      "syslog_config",
+			ExportLogs:  []string{"security", "security"},
+			ShouldError: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateExportLogs(tc.Config, tc.ExportLogs)
+			assert.Equal(t, tc.ShouldError, err != nil)
+		})
+	}
+}
+
+func TestStatusDiagnostics(t *testing.T) {
+	cases := map[string]struct {
+		AccessBridge *client.AccessBridge
+		Warning      bool
+	}{
+		"running": {
+			AccessBridge: &client.AccessBridge{ID: "ab-123", Enabled: true, Status: "running"},
+		},
+		"error": {
+			AccessBridge: &client.AccessBridge{ID: "ab-123", Enabled: true, Status: "error", StatusDescription: "connection refused"},
+			Warning:      true,
+		},
+		"stopped-while-disabled": {
+			AccessBridge: &client.AccessBridge{ID: "ab-123", Enabled: false, Status: "stopped"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := statusDiagnostics(tc.AccessBridge)
+			if !tc.Warning {
+				assert.Empty(t, diags)
+				return
+			}
+			assert.Len(t, diags, 1)
+			assert.Equal(t, diag.Warning, diags[0].Severity)
+			assert.Equal(t, tc.AccessBridge.StatusDescription, diags[0].Detail)
+		})
+	}
+}
@@ -160,6 +160,128 @@ func DataSource() *schema.Resource {
 					},
 				},
 			},
+			"elastic_config": {
+				Description: elasticDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"distribution": {
+							Description: elasticDistributionDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"index": {
+							Description: elasticIndexDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"url": {
+							Description: elasticURLDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"username": {
+							Description: elasticUsernameDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"azure_sentinel_config": {
+				Description: sentinelDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"log_type": {
+							Description: sentinelLogTypeDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"workspace_id": {
+							Description: sentinelWorkspaceIDDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"google_chronicle_config": {
+				Description: chronicleDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"customer_id": {
+							Description: chronicleCustomerIDDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"log_type": {
+							Description: chronicleLogTypeDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"region": {
+							Description: chronicleRegionDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"sumo_logic_config": {
+				Description: sumoLogicDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Description: sumoLogicURLDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"datadog_config": {
+				Description: datadogDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service": {
+							Description: datadogServiceDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"site": {
+							Description: datadogSiteDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tags": {
+							Description: datadogTagsDesc,
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"s3_principal_arn": {
+				Description: s3PrincipalArnDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"s3_bucket_policy": {
+				Description: s3BucketPolicyDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -1,6 +1,7 @@
 package log_streaming_access_bridge
 
 import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
 	"regexp"
@@ -15,6 +16,7 @@ func Resource() *schema.Resource {
 		ReadContext:   abRead,
 		UpdateContext: abUpdate,
 		DeleteContext: abDelete,
+		CustomizeDiff: customdiff.All(validateSiemConfig, s3BucketPolicyDiff),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -54,21 +56,16 @@ func Resource() *schema.Resource {
 				MinItems:    1,
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
-					ValidateDiagFunc: common.ValidateStringENUM("api", "traffic", "security", "metaproxy", "webfilter"),
+					ValidateDiagFunc: common.ValidateStringENUM(exportLogTypes...),
 				},
 			},
 			"proofpoint_casb_config": {
-				Description: proofpointCASBConfig,
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Optional:    true,
-				ConflictsWith: []string{
-					"qradar_http_config",
-					"s3_config",
-					"splunk_http_config",
-					"syslog_config",
-				},
+				Description:   proofpointCASBConfig,
+				Type:          schema.TypeList,
+				MaxItems:      1,
+				MinItems:      1,
+				Optional:      true,
+				ConflictsWith: otherSiemConfigs("proofpoint_casb_config"),
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"region": {
@@ -87,17 +84,12 @@ func Resource() *schema.Resource {
 				},
 			},
 			"qradar_http_config": {
-				Description: qRadarConfDesc,
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				MinItems:    1,
-				ExactlyOneOf: []string{
-					"proofpoint_casb_config",
-					"s3_config",
-					"splunk_http_config",
-					"syslog_config",
-				},
+				Description:  qRadarConfDesc,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				MinItems:     1,
+				ExactlyOneOf: otherSiemConfigs("qradar_http_config"),
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"certificate": {
@@ -117,23 +109,19 @@ func Resource() *schema.Resource {
 				},
 			},
 			"s3_config": {
-				Description: s3ConfDesc,
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				MinItems:    1,
-				ExactlyOneOf: []string{
-					"proofpoint_casb_config",
-					"qradar_http_config",
-					"splunk_http_config",
-					"syslog_config",
-				},
+				Description:  s3ConfDesc,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				MinItems:     1,
+				ExactlyOneOf: otherSiemConfigs("s3_config"),
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"bucket": {
-							Description: s3BucketDesc,
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:      s3BucketDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: common.ValidateS3BucketName(),
 						},
 						"compress": {
 							Description: s3CompressDesc,
@@ -141,25 +129,21 @@ func Resource() *schema.Resource {
 							Required:    true,
 						},
 						"prefix": {
-							Description: s3Prefix,
-							Type:        schema.TypeString,
-							Optional:    true,
+							Description:      s3Prefix,
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validatePrefix(),
 						},
 					},
 				},
 			},
 			"splunk_http_config": {
-				Description: splunkDesc,
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				MinItems:    1,
-				ExactlyOneOf: []string{
-					"proofpoint_casb_config",
-					"qradar_http_config",
-					"s3_config",
-					"syslog_config",
-				},
+				Description:  splunkDesc,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				MinItems:     1,
+				ExactlyOneOf: otherSiemConfigs("splunk_http_config"),
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"certificate": {
@@ -190,17 +174,12 @@ func Resource() *schema.Resource {
 				},
 			},
 			"syslog_config": {
-				Description: syslogDesc,
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				MinItems:    1,
-				ExactlyOneOf: []string{
-					"proofpoint_casb_config",
-					"qradar_http_config",
-					"s3_config",
-					"splunk_http_config",
-				},
+				Description:  syslogDesc,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				MinItems:     1,
+				ExactlyOneOf: otherSiemConfigs("syslog_config"),
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"host": {
@@ -224,6 +203,201 @@ func Resource() *schema.Resource {
 					},
 				},
 			},
+			"elastic_config": {
+				Description:  elasticDesc,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				MinItems:     1,
+				ExactlyOneOf: otherSiemConfigs("elastic_config"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_key": {
+							Description:   elasticApiKeyDesc,
+							Type:          schema.TypeString,
+							Optional:      true,
+							Sensitive:     true,
+							ConflictsWith: []string{"elastic_config.0.username", "elastic_config.0.password"},
+						},
+						"certificate": {
+							Description:      elasticCertDesc,
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							ValidateDiagFunc: common.ValidatePEMCert(),
+						},
+						"distribution": {
+							Description:      elasticDistributionDesc,
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "elasticsearch",
+							ValidateDiagFunc: common.ValidateStringENUM("elasticsearch", "opensearch"),
+						},
+						"index": {
+							Description:      elasticIndexDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: common.ValidatePattern(elasticIndexPattern),
+						},
+						"password": {
+							Description:  elasticPasswordDesc,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							RequiredWith: []string{"elastic_config.0.username"},
+						},
+						"url": {
+							Description:      elasticURLDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: common.ValidateURL(),
+						},
+						"username": {
+							Description:  elasticUsernameDesc,
+							Type:         schema.TypeString,
+							Optional:     true,
+							RequiredWith: []string{"elastic_config.0.password"},
+						},
+					},
+				},
+			},
+			"azure_sentinel_config": {
+				Description:  sentinelDesc,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				MinItems:     1,
+				ExactlyOneOf: otherSiemConfigs("azure_sentinel_config"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"log_type": {
+							Description:      sentinelLogTypeDesc,
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "ProofpointMeta",
+							ValidateDiagFunc: common.ValidatePattern(sentinelLogTypePattern),
+						},
+						"shared_key": {
+							Description: sentinelSharedKeyDesc,
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"workspace_id": {
+							Description:      sentinelWorkspaceIDDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: common.ValidateUUID(),
+						},
+					},
+				},
+			},
+			"google_chronicle_config": {
+				Description:  chronicleDesc,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				MinItems:     1,
+				ExactlyOneOf: otherSiemConfigs("google_chronicle_config"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"credentials": {
+							Description:      chronicleCredentialsDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							Sensitive:        true,
+							ValidateDiagFunc: common.ValidateJson(),
+						},
+						"customer_id": {
+							Description:      chronicleCustomerIDDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: common.ValidateUUID(),
+						},
+						"log_type": {
+							Description: chronicleLogTypeDesc,
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "PROOFPOINT_META",
+						},
+						"region": {
+							Description:      chronicleRegionDesc,
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "us",
+							ValidateDiagFunc: common.ValidateStringENUM(chronicleRegions...),
+						},
+					},
+				},
+			},
+			"sumo_logic_config": {
+				Description:  sumoLogicDesc,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				MinItems:     1,
+				ExactlyOneOf: otherSiemConfigs("sumo_logic_config"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Description:      sumoLogicURLDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							Sensitive:        true,
+							ValidateDiagFunc: common.ValidateURL(),
+						},
+					},
+				},
+			},
+			"datadog_config": {
+				Description:  datadogDesc,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				MinItems:     1,
+				ExactlyOneOf: otherSiemConfigs("datadog_config"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_key": {
+							Description: datadogApiKeyDesc,
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"service": {
+							Description: datadogServiceDesc,
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"site": {
+							Description:      datadogSiteDesc,
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "datadoghq.com",
+							ValidateDiagFunc: common.ValidateStringENUM(datadogSites...),
+						},
+						"tags": {
+							Description: datadogTagsDesc,
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: common.ValidatePattern(datadogTagPattern),
+							},
+						},
+					},
+				},
+			},
+			"s3_principal_arn": {
+				Description: s3PrincipalArnDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"s3_bucket_policy": {
+				Description: s3BucketPolicyDesc + s3TwoStepApplyDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -0,0 +1,143 @@
+package log_streaming_access_bridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// prefixVariables are the variables which can be used in the S3 prefix, mapped to how they are rendered.
+var prefixVariables = map[string]func(t time.Time, logType string) string{
+	"log_type": func(_ time.Time, logType string) string { return logType },
+	"date":     func(t time.Time, _ string) string { return t.Format("2006-01-02") },
+	"year":     func(t time.Time, _ string) string { return t.Format("2006") },
+	"month":    func(t time.Time, _ string) string { return t.Format("01") },
+	"day":      func(t time.Time, _ string) string { return t.Format("02") },
+	"hour":     func(t time.Time, _ string) string { return t.Format("15") },
+}
+
+var (
+	prefixPlaceholderPattern = regexp.MustCompile(`{{\s*([^{}]*?)\s*}}`)
+	// prefixCharsPattern allows the characters AWS documents as safe for object key names.
+	prefixCharsPattern  = regexp.MustCompile(`^[A-Za-z0-9!_.*'()/-]*$`)
+	arnPartitionPattern = regexp.MustCompile(`^arn:(aws[a-z-]*):`)
+)
+
+const maxPrefixLength = 512
+
+func prefixVariableNames() []string {
+	res := make([]string, 0, len(prefixVariables))
+	for name := range prefixVariables {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// renderPrefix replaces every {{ variable }} placeholder in the S3 prefix.
+func renderPrefix(prefix string, t time.Time, logType string) (string, error) {
+	var unknown []string
+	rendered := prefixPlaceholderPattern.ReplaceAllStringFunc(prefix, func(placeholder string) string {
+		name := prefixPlaceholderPattern.FindStringSubmatch(placeholder)[1]
+		render, ok := prefixVariables[name]
+		if !ok {
+			unknown = append(unknown, name)
+			return placeholder
+		}
+		return render(t, logType)
+	})
+	if len(unknown) > 0 {
+		return "", fmt.Errorf("unknown prefix variables \"%s\", supported variables are %s",
+			strings.Join(unknown, "\", \""), strings.Join(prefixVariableNames(), ", "))
+	}
+	if strings.Contains(rendered, "{{") || strings.Contains(rendered, "}}") {
+		return "", fmt.Errorf("prefix contains an unclosed placeholder, placeholders should be in the form {{ variable }}")
+	}
+	return rendered, nil
+}
+
+// validatePrefix renders the prefix with sample values and validates the result as an S3 object key prefix.
+func validatePrefix() func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, _ cty.Path) diag.Diagnostics {
+		prefix := input.(string)
+		rendered, err := renderPrefix(prefix, time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), "webfilter")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !prefixCharsPattern.MatchString(rendered) {
+			return diag.Errorf("prefix \"%s\" may only contain letters, digits and the characters !_.*'()/-", prefix)
+		}
+		if strings.HasPrefix(rendered, "/") || strings.Contains(rendered, "//") {
+			return diag.Errorf("prefix \"%s\" must not start with a slash or contain empty path segments", prefix)
+		}
+		for _, segment := range strings.Split(rendered, "/") {
+			if segment == "." || segment == ".." {
+				return diag.Errorf("prefix \"%s\" must not contain relative path segments", prefix)
+			}
+		}
+		if len(rendered) > maxPrefixLength {
+			return diag.Errorf("prefix \"%s\" is longer than %d characters", prefix, maxPrefixLength)
+		}
+		return nil
+	}
+}
+
+// staticPrefix returns the part of the prefix before the first placeholder.
+func staticPrefix(prefix string) string {
+	if loc := prefixPlaceholderPattern.FindStringIndex(prefix); loc != nil {
+		return prefix[:loc[0]]
+	}
+	return prefix
+}
+
+type policyStatement struct {
+	Sid       string            `json:"Sid"`
+	Effect    string            `json:"Effect"`
+	Principal map[string]string `json:"Principal"`
+	Action    []string          `json:"Action"`
+	Resource  string            `json:"Resource"`
+}
+
+type policyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []policyStatement `json:"Statement"`
+}
+
+// bucketPolicy returns the bucket policy which allows the log streaming principal to write objects under the prefix.
+func bucketPolicy(principalArn, bucket, prefix string) (string, error) {
+	partition := "aws"
+	if match := arnPartitionPattern.FindStringSubmatch(principalArn); match != nil {
+		partition = match[1]
+	}
+	bucketArn := fmt.Sprintf("arn:%s:s3:::%s", partition, bucket)
+	principal := map[string]string{"AWS": principalArn}
+	policy := policyDocument{
+		Version: "2012-10-17",
+		Statement: []policyStatement{
+			{
+				Sid:       "ProofpointLogStreamingPutObject",
+				Effect:    "Allow",
+				Principal: principal,
+				Action:    []string{"s3:PutObject"},
+				Resource:  bucketArn + "/" + staticPrefix(prefix) + "*",
+			},
+			{
+				Sid:       "ProofpointLogStreamingGetBucketLocation",
+				Effect:    "Allow",
+				Principal: principal,
+				Action:    []string{"s3:GetBucketLocation"},
+				Resource:  bucketArn,
+			},
+		},
+	}
+	res, err := json.Marshal(policy)
+	if err != nil {
+		return "", fmt.Errorf("could not convert bucket policy to json: %v", err)
+	}
+	return string(res), nil
+}
@@ -0,0 +1,104 @@
+package log_streaming_access_bridge
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRenderPrefix(t *testing.T) {
+	at := time.Date(2022, 10, 19, 7, 30, 0, 0, time.UTC)
+	cases := map[string]struct {
+		Prefix      string
+		Expected    string
+		ShouldError bool
+	}{
+		"static": {
+			Prefix:   "meta/logs/",
+			Expected: "meta/logs/",
+		},
+		"all-variables": {
+			Prefix:   "meta/{{ log_type }}/{{year}}/{{ month }}/{{ day }}/{{ hour }}/{{ date }}/",
+			Expected: "meta/traffic/2022/10/19/07/2022-10-19/",
+		},
+		"unknown-variable": {
+			Prefix:      "meta/{{ logtype }}/",
+			ShouldError: true,
+		},
+		"unclosed-placeholder": {
+			Prefix:      "meta/{{ log_type/",
+			ShouldError: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rendered, err := renderPrefix(tc.Prefix, at, "traffic")
+			assert.Equal(t, tc.ShouldError, err != nil)
+			assert.Equal(t, tc.Expected, rendered)
+		})
+	}
+}
+
+func TestValidatePrefix(t *testing.T) {
+	cases := map[string]struct {
+		Prefix      string
+		ShouldError bool
+	}{
+		"templated":            {Prefix: "meta/{{ log_type }}/{{ date }}/"},
+		"safe-characters":      {Prefix: "meta_logs-(prod)!.*'/"},
+		"leading-slash":        {Prefix: "/meta/", ShouldError: true},
+		"empty-segment":        {Prefix: "meta//{{ log_type }}/", ShouldError: true},
+		"relative-segment":     {Prefix: "meta/../logs/", ShouldError: true},
+		"unsafe-character":     {Prefix: "meta logs/", ShouldError: true},
+		"unknown-variable":     {Prefix: "meta/{{ region }}/", ShouldError: true},
+		"rendered-empty-value": {Prefix: "{{ log_type }}//", ShouldError: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := validatePrefix()(tc.Prefix, nil)
+			assert.Equal(t, tc.ShouldError, diags.HasError())
+		})
+	}
+}
+
+func TestBucketPolicy(t *testing.T) {
+	cases := map[string]struct {
+		PrincipalArn   string
+		Prefix         string
+		ObjectResource string
+		BucketResource string
+	}{
+		"static-prefix": {
+			PrincipalArn:   "arn:aws:iam::123456789012:role/proofpoint-log-streaming",
+			Prefix:         "meta/",
+			ObjectResource: "arn:aws:s3:::mybucket/meta/*",
+			BucketResource: "arn:aws:s3:::mybucket",
+		},
+		"templated-prefix": {
+			PrincipalArn:   "arn:aws:iam::123456789012:role/proofpoint-log-streaming",
+			Prefix:         "meta/{{ log_type }}/{{ date }}/",
+			ObjectResource: "arn:aws:s3:::mybucket/meta/*",
+			BucketResource: "arn:aws:s3:::mybucket",
+		},
+		"no-prefix-gov-cloud": {
+			PrincipalArn:   "arn:aws-us-gov:iam::123456789012:role/proofpoint-log-streaming",
+			ObjectResource: "arn:aws-us-gov:s3:::mybucket/*",
+			BucketResource: "arn:aws-us-gov:s3:::mybucket",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			policy, err := bucketPolicy(tc.PrincipalArn, "mybucket", tc.Prefix)
+			assert.NoError(t, err)
+			var doc policyDocument
+			assert.NoError(t, json.Unmarshal([]byte(policy), &doc))
+			assert.Equal(t, "2012-10-17", doc.Version)
+			assert.Len(t, doc.Statement, 2)
+			assert.Equal(t, tc.ObjectResource, doc.Statement[0].Resource)
+			assert.Equal(t, []string{"s3:PutObject"}, doc.Statement[0].Action)
+			assert.Equal(t, tc.BucketResource, doc.Statement[1].Resource)
+			assert.Equal(t, tc.PrincipalArn, doc.Statement[1].Principal["AWS"])
+		})
+	}
+}
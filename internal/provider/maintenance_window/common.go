@@ -0,0 +1,157 @@
+package maintenance_window
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"log"
+	"net/http"
+	"time"
+)
+
+var excludedKeys = []string{"id", "start_time", "end_time"}
+
+const (
+	description = "Maintenance windows temporarily disable a set of alerts, e.g. during a planned change. " +
+		"The alerts are disabled from `start_time` and are re-enabled by Proofpoint NaaS when the window expires at `end_time`, " +
+		"or when the maintenance window is deleted before it expires.\n\n" +
+		"For recurring change windows, set `suppression_schedules` of [**pfptmeta_alert**](https://registry.terraform.io/providers/nsofnetworks/pfptmeta/latest/docs/resources/alert) " +
+		"to [**pfptmeta_time_frame**](https://registry.terraform.io/providers/nsofnetworks/pfptmeta/latest/docs/resources/time_frame) IDs instead."
+	alertsDesc    = "IDs of the alerts to disable during the maintenance window."
+	startTimeDesc = "When the maintenance window starts, in RFC3339 format, e.g. `2022-10-19T22:00:00Z`. Defaults to the time the maintenance window is created."
+	endTimeDesc   = "When the maintenance window expires and the alerts are re-enabled, in RFC3339 format."
+	statusDesc    = "The status of the maintenance window, ENUM: `scheduled`, `active`, `expired`."
+)
+
+const expired = "expired"
+
+// suppressEquivalentTimes ignores differences in the representation of the same instant, e.g. time zones.
+func suppressEquivalentTimes(_, old, new string, _ *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+// validateTimes checks that the window ends after it starts, and that a new window does not end in the past.
+func validateTimes(startTime, endTime string, now time.Time, isNew bool) error {
+	end, err := time.Parse(time.RFC3339, endTime)
+	if err != nil {
+		return err
+	}
+	if startTime != "" {
+		start, err := time.Parse(time.RFC3339, startTime)
+		if err != nil {
+			return err
+		}
+		if !end.After(start) {
+			return fmt.Errorf("end_time %s must be after start_time %s", endTime, startTime)
+		}
+	}
+	if isNew && !end.After(now) {
+		return fmt.Errorf("end_time %s is in the past", endTime)
+	}
+	return nil
+}
+
+func customizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("start_time") || !d.NewValueKnown("end_time") {
+		return nil
+	}
+	if !d.HasChange("start_time") && !d.HasChange("end_time") {
+		return nil
+	}
+	return validateTimes(d.Get("start_time").(string), d.Get("end_time").(string), time.Now(), d.Id() == "")
+}
+
+func maintenanceWindowToResource(d *schema.ResourceData, mw *client.MaintenanceWindow) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId(mw.ID)
+	err := client.MapResponseToResource(mw, d, excludedKeys)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !suppressEquivalentTimes("", d.Get("start_time").(string), mw.StartTime, d) {
+		err = d.Set("start_time", mw.StartTime)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if !suppressEquivalentTimes("", d.Get("end_time").(string), mw.EndTime, d) {
+		err = d.Set("end_time", mw.EndTime)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if mw.Status == expired {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("maintenance window %s expired", mw.ID),
+			Detail: fmt.Sprintf("The maintenance window expired at %s and its alerts were re-enabled. "+
+				"It can be removed from the configuration.", mw.EndTime),
+		})
+	}
+	return diags
+}
+
+func maintenanceWindowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	id := d.Get("id").(string)
+	mw, err := client.GetMaintenanceWindow(ctx, c, id)
+	if err != nil {
+		errResponse, ok := err.(*client.ErrorResponse)
+		if ok && errResponse.Status == http.StatusNotFound {
+			log.Printf("[WARN] Removing maintenance window %s because it's gone", id)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	return maintenanceWindowToResource(d, mw)
+}
+
+func maintenanceWindowCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	body := client.NewMaintenanceWindow(d)
+	mw, err := client.CreateMaintenanceWindow(ctx, c, body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return maintenanceWindowToResource(d, mw)
+}
+
+func maintenanceWindowUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	id := d.Id()
+	body := client.NewMaintenanceWindow(d)
+	mw, err := client.UpdateMaintenanceWindow(ctx, c, id, body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return maintenanceWindowToResource(d, mw)
+}
+
+func maintenanceWindowDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	id := d.Id()
+	_, err := client.DeleteMaintenanceWindow(ctx, c, id)
+	if err != nil {
+		errResponse, ok := err.(*client.ErrorResponse)
+		if !ok || errResponse.Status != http.StatusNotFound {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,48 @@
+package maintenance_window
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSuppressEquivalentTimes(t *testing.T) {
+	cases := map[string]struct {
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		"same":           {Old: "2022-10-19T22:00:00Z", New: "2022-10-19T22:00:00Z", Suppress: true},
+		"other-timezone": {Old: "2022-10-19T22:00:00Z", New: "2022-10-20T00:00:00+02:00", Suppress: true},
+		"other-instant":  {Old: "2022-10-19T22:00:00Z", New: "2022-10-19T23:00:00Z"},
+		"unset":          {Old: "", New: "2022-10-19T22:00:00Z"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Suppress, suppressEquivalentTimes("end_time", tc.Old, tc.New, nil))
+		})
+	}
+}
+
+func TestValidateTimes(t *testing.T) {
+	now := time.Date(2022, 10, 19, 12, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		StartTime   string
+		EndTime     string
+		IsNew       bool
+		ShouldError bool
+	}{
+		"scheduled":                {StartTime: "2022-10-19T22:00:00Z", EndTime: "2022-10-20T02:00:00Z", IsNew: true},
+		"starts-on-creation":       {EndTime: "2022-10-19T14:00:00Z", IsNew: true},
+		"ends-before-start":        {StartTime: "2022-10-19T22:00:00Z", EndTime: "2022-10-19T21:00:00Z", ShouldError: true},
+		"new-window-ended":         {StartTime: "2022-10-18T22:00:00Z", EndTime: "2022-10-19T02:00:00Z", IsNew: true, ShouldError: true},
+		"existing-window-expired":  {StartTime: "2022-10-18T22:00:00Z", EndTime: "2022-10-19T02:00:00Z"},
+		"ends-when-start-in-zones": {StartTime: "2022-10-19T22:00:00Z", EndTime: "2022-10-20T00:00:00+02:00", ShouldError: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateTimes(tc.StartTime, tc.EndTime, now, tc.IsNew)
+			assert.Equal(t, tc.ShouldError, err != nil)
+		})
+	}
+}
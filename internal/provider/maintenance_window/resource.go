@@ -0,0 +1,66 @@
+package maintenance_window
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: description,
+
+		CreateContext: maintenanceWindowCreate,
+		ReadContext:   maintenanceWindowRead,
+		UpdateContext: maintenanceWindowUpdate,
+		DeleteContext: maintenanceWindowDelete,
+		CustomizeDiff: customizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"alerts": {
+				Description: alertsDesc,
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: common.ValidateID(false, "alr"),
+				},
+			},
+			"start_time": {
+				Description:      startTimeDesc,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: common.ValidateIsoTimeFormat(),
+				DiffSuppressFunc: suppressEquivalentTimes,
+			},
+			"end_time": {
+				Description:      endTimeDesc,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: common.ValidateIsoTimeFormat(),
+				DiffSuppressFunc: suppressEquivalentTimes,
+			},
+			"status": {
+				Description: statusDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
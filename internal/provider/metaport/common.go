@@ -16,8 +16,9 @@ const (
 	description = "MetaPort is a lightweight virtual appliance that enables the secure authenticated interface " +
 		"interact between existing servers and the Proofpoint NaaS cloud. " +
 		"Once configured, metaports enable users to access your applications via the Proofpoint cloud."
-	mappedElementsDesc       = "List of mapped element IDs"
-	notificationChannelsDesc = "List of notification channel IDs"
+	mappedElementsDesc                   = "List of mapped element IDs"
+	notificationChannelsDesc             = "List of notification channel IDs"
+	notificationSuppressionSchedulesDesc = "List of time frame IDs during which notifications about the metaport are not sent to `notification_channels`"
 )
 
 func metaportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -51,6 +51,12 @@ func DataSource() *schema.Resource {
 				Computed:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"notification_suppression_schedules": {
+				Description: notificationSuppressionSchedulesDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -57,6 +57,14 @@ func Resource() *schema.Resource {
 					Type:             schema.TypeString,
 					ValidateDiagFunc: common.ValidateID(false, "nch")},
 			},
+			"notification_suppression_schedules": {
+				Description: notificationSuppressionSchedulesDesc,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: common.ValidateID(false, "tmf")},
+			},
 		},
 	}
 }
@@ -4,12 +4,48 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
 	"log"
 	"net/http"
+	"time"
 )
 
+const waitUntilConnectedDesc = "Wait until at least one MetaPort of the cluster is connected before attaching the mapped elements, " +
+	"so traffic is only shifted onto a connected cluster. The wait is limited by the create timeout, " +
+	"or by the update timeout when enabled for an existing attachment."
+
+// pollInterval is the interval between checks of the statuses of the cluster's MetaPorts.
+const pollInterval = 10 * time.Second
+
+// waitUntilConnected waits until at least one MetaPort of the cluster is connected, checking their statuses every interval.
+func waitUntilConnected(ctx context.Context, c *client.Client, mcID string, timeout, interval time.Duration) error {
+	conf := &resource.StateChangeConf{
+		Pending: []string{"disconnected"},
+		Target:  []string{client.MetaportConnected},
+		Refresh: func() (interface{}, string, error) {
+			statuses, err := client.GetMetaportClusterStatus(ctx, c, mcID)
+			if err != nil {
+				return nil, "", err
+			}
+			for _, s := range statuses {
+				if s.ConnectionState == client.MetaportConnected {
+					return statuses, client.MetaportConnected, nil
+				}
+			}
+			return statuses, "disconnected", nil
+		},
+		Timeout:      timeout,
+		PollInterval: interval,
+	}
+	_, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("no metaport of metaport cluster %s is connected: %v", mcID, err)
+	}
+	return nil
+}
+
 func generateID(mID string, meIDs []string) string {
 	hash := 0
 	for _, meID := range meIDs {
@@ -60,6 +96,12 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	c := meta.(*client.Client)
 
 	mID := d.Get("metaport_cluster_id").(string)
+	if d.Get("wait_until_connected").(bool) {
+		err := waitUntilConnected(ctx, c, mID, d.Timeout(schema.TimeoutCreate), pollInterval)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	mes := client.ResourceTypeSetToStringSlice(d.Get("mapped_elements").(*schema.Set))
 	m, err := client.AddMappedElementsToMetaportCluster(ctx, c, mID, mes)
 	if err != nil {
@@ -68,6 +110,19 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	return attachmentToResource(d, m)
 }
 
+// updateResource only waits for the cluster to connect, since all the other arguments force a new attachment.
+func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	if d.HasChange("wait_until_connected") && d.Get("wait_until_connected").(bool) {
+		err := waitUntilConnected(ctx, c, d.Get("metaport_cluster_id").(string), d.Timeout(schema.TimeoutUpdate), pollInterval)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return readResource(ctx, d, meta)
+}
+
 func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	c := meta.(*client.Client)
 
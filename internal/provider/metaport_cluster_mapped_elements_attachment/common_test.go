@@ -0,0 +1,60 @@
+package metaport_cluster_mapped_elements_attachment
+
+import (
+	"context"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitUntilConnected(t *testing.T) {
+	cases := map[string]struct {
+		ConnectedAfter int32
+		Error          string
+	}{
+		"already-connected": {ConnectedAfter: 0},
+		"connects":          {ConnectedAfter: 3},
+		"never-connects":    {ConnectedAfter: 1000, Error: "no metaport of metaport cluster mpc-123 is connected"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var checks int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v1/metaport_clusters/mpc-123":
+					_, _ = w.Write([]byte(`{"id": "mpc-123", "metaports": ["mp-1", "mp-2"]}`))
+				case "/v1/metaports/mp-1/status":
+					_, _ = w.Write([]byte(`{"connection_state": "disconnected"}`))
+				case "/v1/metaports/mp-2/status":
+					state := "disconnected"
+					if atomic.AddInt32(&checks, 1) > tc.ConnectedAfter {
+						state = "connected"
+					}
+					_, _ = w.Write([]byte(`{"connection_state": "` + state + `"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+			c := &client.Client{
+				HTTP:        retryablehttp.NewClient(),
+				BaseURL:     server.URL,
+				Credentials: &client.Credentials{AccessToken: "token"},
+			}
+			err := waitUntilConnected(context.Background(), c, "mpc-123", 500*time.Millisecond, 10*time.Millisecond)
+			if tc.Error == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.ConnectedAfter+1, atomic.LoadInt32(&checks))
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.Error)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package metaport_cluster_mapped_elements_attachment
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+	"time"
 )
 
 func Resource() *schema.Resource {
@@ -10,7 +11,12 @@ func Resource() *schema.Resource {
 		Description:   "Attaches mapped elements to metaport cluster.",
 		ReadContext:   readResource,
 		CreateContext: createResource,
+		UpdateContext: updateResource,
 		DeleteContext: deleteResource,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -33,6 +39,12 @@ func Resource() *schema.Resource {
 				MinItems: 1,
 				ForceNew: true,
 			},
+			"wait_until_connected": {
+				Description: waitUntilConnectedDesc,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
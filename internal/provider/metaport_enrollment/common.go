@@ -0,0 +1,154 @@
+package metaport_enrollment
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+const (
+	description = "Generates a one-time activation code (OTAC) with which a MetaPort instance enrolls as the MetaPort, " +
+		"and renders the artifacts which deploy the instance with it: cloud-init user data, a docker run command and Kubernetes manifests.\n\n" +
+		"The code is generated once, when the resource is created, and again when it expires. Change `triggers` to generate a new code, " +
+		"e.g. to deploy a new instance. Changing the deployment arguments renders the artifacts again with the same code."
+	metaportIdDesc   = "ID of the MetaPort the instance enrolls as."
+	triggersDesc     = "Arbitrary map of values that, when changed, generate a new one-time activation code."
+	nameDesc         = "Name of the container and of the Kubernetes resources."
+	namespaceDesc    = "Kubernetes namespace of the rendered manifests."
+	imageDesc        = "MetaPort container image."
+	tokenDesc        = "The one-time activation code."
+	expiresAtDesc    = "Time the activation code expires at, if it has to be used before a certain time. Once it passes, the enrollment is replaced with a new code."
+	cloudInitDesc    = "cloud-init user data which installs docker and runs the MetaPort container, for Debian and Ubuntu based images, e.g. `user_data` of an `aws_instance`."
+	dockerRunDesc    = "docker run command which runs the MetaPort container on the host network."
+	kubernetesDesc   = "Kubernetes manifests of a secret holding the activation code and a single replica deployment of the MetaPort container, separated by `---`."
+	defaultImage     = "nsofnetworks/metaport:latest"
+	defaultName      = "metaport"
+	defaultNamespace = "default"
+)
+
+// dnsLabelPattern matches a name which is valid for both containers and Kubernetes resources (RFC 1123 label).
+var dnsLabelPattern = regexp.MustCompile("^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$")
+
+var artifactKeys = []string{"cloud_init", "docker_run", "kubernetes_manifest"}
+
+// renderArtifacts sets the deployment artifacts of the enrollment's token.
+func renderArtifacts(d *schema.ResourceData, c *client.Client) diag.Diagnostics {
+	dep := deployment{
+		Name:      d.Get("name").(string),
+		Namespace: d.Get("kubernetes_namespace").(string),
+		Image:     d.Get("image").(string),
+		ApiURL:    c.BaseURL,
+		Token:     d.Get("token").(string),
+	}
+	cloudInit, err := dep.cloudInit()
+	if err != nil {
+		return diag.Errorf("could not render cloud-init user data: %v", err)
+	}
+	manifest, err := dep.kubernetesManifest()
+	if err != nil {
+		return diag.Errorf("could not render kubernetes manifest: %v", err)
+	}
+	for key, value := range map[string]string{
+		"cloud_init":          cloudInit,
+		"docker_run":          dep.dockerRun(),
+		"kubernetes_manifest": manifest,
+	} {
+		err = d.Set(key, value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// expired returns whether the activation code of the enrollment has expired.
+func expired(expiresAt string, now time.Time) bool {
+	if expiresAt == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		log.Printf("[WARN] Could not parse expiration time %q of the activation code: %v", expiresAt, err)
+		return false
+	}
+	return !now.Before(t)
+}
+
+// customizeDiff replaces the enrollment when its activation code has expired
+// and plans new artifacts when the deployment arguments change.
+func customizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if expired(d.Get("expires_at").(string), time.Now()) {
+		err := d.SetNewComputed("token")
+		if err != nil {
+			return err
+		}
+		return d.ForceNew("token")
+	}
+	if !d.HasChanges("name", "kubernetes_namespace", "image") {
+		return nil
+	}
+	for _, key := range artifactKeys {
+		err := d.SetNewComputed(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	mID := d.Get("metaport_id").(string)
+	otac, err := client.GenerateMetaportOTAC(ctx, c, mID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(resource.PrefixedUniqueId(mID + "-"))
+	err = d.Set("token", otac.Secret)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("expires_at", otac.ExpiresAt)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return renderArtifacts(d, c)
+}
+
+func readResource(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(*client.Client)
+
+	mID := d.Get("metaport_id").(string)
+	_, err := client.GetMetaport(ctx, c, mID)
+	if err != nil {
+		errResponse, ok := err.(*client.ErrorResponse)
+		if ok && errResponse.Status == http.StatusNotFound {
+			log.Printf("[WARN] Removing enrollment of metaport %s because it's gone", mID)
+			d.SetId("")
+			return
+		} else {
+			return diag.FromErr(err)
+		}
+	}
+	return
+}
+
+func updateResource(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return renderArtifacts(d, meta.(*client.Client))
+}
+
+// deleteResource only removes the enrollment from the state, unused codes expire on their own.
+func deleteResource(_ context.Context, d *schema.ResourceData, _ interface{}) (diags diag.Diagnostics) {
+	d.SetId("")
+	return
+}
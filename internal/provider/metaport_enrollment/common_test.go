@@ -0,0 +1,68 @@
+package metaport_enrollment
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2022, 10, 19, 12, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		expiresAt string
+		expected  bool
+	}{
+		"no-expiration": {expiresAt: "", expected: false},
+		"future":        {expiresAt: "2022-10-19T13:00:00Z", expected: false},
+		"past":          {expiresAt: "2022-10-19T11:00:00Z", expected: true},
+		"now":           {expiresAt: "2022-10-19T12:00:00Z", expected: true},
+		"invalid":       {expiresAt: "tomorrow", expected: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, expired(tc.expiresAt, now))
+		})
+	}
+}
+
+func TestCustomizeDiffReplacesExpiredCode(t *testing.T) {
+	cases := map[string]struct {
+		expiresAt       string
+		expectedReplace bool
+	}{
+		"expired":     {expiresAt: time.Now().Add(-time.Hour).Format(time.RFC3339), expectedReplace: true},
+		"not-expired": {expiresAt: time.Now().Add(time.Hour).Format(time.RFC3339), expectedReplace: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "mp-123-1",
+				Attributes: map[string]string{
+					"id":                   "mp-123-1",
+					"metaport_id":          "mp-123",
+					"name":                 defaultName,
+					"kubernetes_namespace": defaultNamespace,
+					"image":                defaultImage,
+					"token":                "secret",
+					"expires_at":           tc.expiresAt,
+					"cloud_init":           "cloud-init",
+					"docker_run":           "docker run",
+					"kubernetes_manifest":  "manifest",
+				},
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{"metaport_id": "mp-123"})
+			diff, err := Resource().SimpleDiff(context.Background(), state, config, nil)
+			assert.NoError(t, err)
+			if !tc.expectedReplace {
+				assert.True(t, diff == nil || diff.Empty())
+				return
+			}
+			if !assert.NotNil(t, diff) {
+				return
+			}
+			assert.True(t, diff.RequiresNew())
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package metaport_enrollment
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+)
+
+// deployment holds the values the deployment artifacts are rendered with.
+type deployment struct {
+	Name      string
+	Namespace string
+	Image     string
+	ApiURL    string
+	Token     string
+}
+
+// env returns the environment variables the MetaPort container enrolls with.
+func (d deployment) env() [][2]string {
+	return [][2]string{
+		{"METAPORT_API_URL", d.ApiURL},
+		{"METAPORT_OTAC", d.Token},
+	}
+}
+
+// shellQuote quotes s as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// yamlQuote quotes s as a double-quoted YAML scalar, which is a superset of a JSON string.
+func yamlQuote(s string) string {
+	res, _ := json.Marshal(s)
+	return string(res)
+}
+
+var templateFuncs = template.FuncMap{"quote": yamlQuote}
+
+const envFilePath = "/etc/metaport/metaport.env"
+
+var cloudInitTemplate = template.Must(template.New("cloud_init").Funcs(templateFuncs).Parse(`#cloud-config
+package_update: true
+packages:
+  - docker.io
+write_files:
+  - path: ` + envFilePath + `
+    owner: root:root
+    permissions: "0600"
+    content: |
+{{- range .Env }}
+      {{ index . 0 }}={{ index . 1 }}
+{{- end }}
+runcmd:
+  - [systemctl, enable, --now, docker]
+  - [{{ range $i, $arg := .Run }}{{ if $i }}, {{ end }}{{ quote $arg }}{{ end }}]
+`))
+
+var kubernetesTemplate = template.Must(template.New("kubernetes").Funcs(templateFuncs).Parse(`apiVersion: v1
+kind: Secret
+metadata:
+  name: {{ .Name }}-enrollment
+  namespace: {{ .Namespace }}
+type: Opaque
+stringData:
+{{- range .Env }}
+  {{ index . 0 }}: {{ quote (index . 1) }}
+{{- end }}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}
+  namespace: {{ .Namespace }}
+  labels:
+    app.kubernetes.io/name: {{ .Name }}
+spec:
+  replicas: 1
+  strategy:
+    type: Recreate
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: {{ .Name }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: {{ .Name }}
+    spec:
+      hostNetwork: true
+      containers:
+        - name: metaport
+          image: {{ quote .Image }}
+          envFrom:
+            - secretRef:
+                name: {{ .Name }}-enrollment
+          securityContext:
+            capabilities:
+              add: ["NET_ADMIN"]
+`))
+
+// runArgs returns the docker run arguments, without the environment variables.
+func (d deployment) runArgs() []string {
+	return []string{
+		"docker", "run", "--detach", "--name", d.Name, "--restart", "unless-stopped",
+		"--network", "host", "--cap-add", "NET_ADMIN",
+	}
+}
+
+// dockerRun renders a docker run command which passes the enrollment environment variables on the command line.
+func (d deployment) dockerRun() string {
+	args := d.runArgs()
+	for _, env := range d.env() {
+		args = append(args, "--env", env[0]+"="+env[1])
+	}
+	args = append(args, d.Image)
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if i < 2 {
+			quoted[i] = arg
+		} else {
+			quoted[i] = shellQuote(arg)
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// cloudInit renders cloud-init user data which installs docker on Debian based images
+// and runs the MetaPort container with the enrollment environment variables read from a root only file.
+func (d deployment) cloudInit() (string, error) {
+	run := append(d.runArgs(), "--env-file", envFilePath, d.Image)
+	var b bytes.Buffer
+	err := cloudInitTemplate.Execute(&b, map[string]interface{}{"Env": d.env(), "Run": run})
+	return b.String(), err
+}
+
+// kubernetesManifest renders a secret holding the enrollment environment variables and a single replica deployment.
+func (d deployment) kubernetesManifest() (string, error) {
+	var b bytes.Buffer
+	err := kubernetesTemplate.Execute(&b, map[string]interface{}{
+		"Name":      d.Name,
+		"Namespace": d.Namespace,
+		"Image":     d.Image,
+		"Env":       d.env(),
+	})
+	return b.String(), err
+}
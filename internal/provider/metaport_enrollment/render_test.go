@@ -0,0 +1,50 @@
+package metaport_enrollment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+var testDeployment = deployment{
+	Name:      "metaport-1",
+	Namespace: "network",
+	Image:     "nsofnetworks/metaport:1.2.3",
+	ApiURL:    "https://api.access.proofpoint.com",
+	Token:     "it's-a-token",
+}
+
+func TestDockerRun(t *testing.T) {
+	assert.Equal(t,
+		`docker run '--detach' '--name' 'metaport-1' '--restart' 'unless-stopped' '--network' 'host' '--cap-add' 'NET_ADMIN' `+
+			`'--env' 'METAPORT_API_URL=https://api.access.proofpoint.com' '--env' 'METAPORT_OTAC=it'"'"'s-a-token' 'nsofnetworks/metaport:1.2.3'`,
+		testDeployment.dockerRun())
+}
+
+func TestCloudInit(t *testing.T) {
+	cloudInit, err := testDeployment.cloudInit()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(cloudInit, "#cloud-config\n"))
+	assert.Contains(t, cloudInit, `
+    content: |
+      METAPORT_API_URL=https://api.access.proofpoint.com
+      METAPORT_OTAC=it's-a-token
+runcmd:`)
+	assert.Contains(t, cloudInit, `  - ["docker", "run", "--detach", "--name", "metaport-1", "--restart", "unless-stopped", "--network", "host", `+
+		`"--cap-add", "NET_ADMIN", "--env-file", "/etc/metaport/metaport.env", "nsofnetworks/metaport:1.2.3"]`)
+	assert.NotContains(t, strings.SplitN(cloudInit, "runcmd:", 2)[1], "token")
+}
+
+func TestKubernetesManifest(t *testing.T) {
+	manifest, err := testDeployment.kubernetesManifest()
+	assert.NoError(t, err)
+	documents := strings.Split(manifest, "\n---\n")
+	if assert.Len(t, documents, 2) {
+		assert.Contains(t, documents[0], "kind: Secret")
+		assert.Contains(t, documents[0], "  name: metaport-1-enrollment\n  namespace: network\n")
+		assert.Contains(t, documents[0], "  METAPORT_OTAC: \"it's-a-token\"")
+		assert.Contains(t, documents[1], "kind: Deployment")
+		assert.Contains(t, documents[1], "          image: \"nsofnetworks/metaport:1.2.3\"\n")
+		assert.Contains(t, documents[1], "                name: metaport-1-enrollment\n")
+	}
+}
@@ -0,0 +1,86 @@
+package metaport_enrollment
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Description:   description,
+		CreateContext: createResource,
+		ReadContext:   readResource,
+		UpdateContext: updateResource,
+		DeleteContext: deleteResource,
+		CustomizeDiff: customizeDiff,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metaport_id": {
+				Description:      metaportIdDesc,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: common.ValidateID(false, "mp"),
+			},
+			"triggers": {
+				Description: triggersDesc,
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"name": {
+				Description:      nameDesc,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          defaultName,
+				ValidateDiagFunc: common.ValidatePattern(dnsLabelPattern),
+			},
+			"kubernetes_namespace": {
+				Description:      namespaceDesc,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          defaultNamespace,
+				ValidateDiagFunc: common.ValidatePattern(dnsLabelPattern),
+			},
+			"image": {
+				Description: imageDesc,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultImage,
+			},
+			"token": {
+				Description: tokenDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": {
+				Description: expiresAtDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"cloud_init": {
+				Description: cloudInitDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"docker_run": {
+				Description: dockerRunDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"kubernetes_manifest": {
+				Description: kubernetesDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
@@ -2,16 +2,19 @@ package metaport_failover
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
 	"log"
 	"net/http"
+	"strings"
 )
 
 const (
 	description        = "MetaPort failover defines a failover model between a primary and a secondary MetaPort clusters."
-	mappedElementsDesc = "List of mapped element IDs"
+	mappedElementsDesc = "List of mapped element IDs, which should be mapped to both clusters."
 	cluster1Desc       = "Priority #1 MetaPort cluster ID. This cluster is active by default. " +
 		"When failover condition is met for this cluster, the higher priority cluster becomes active."
 	cluster2Desc             = "Priority #2 MetaPort cluster ID. This cluster becomes active, when failover condition is met for a lower priority cluster."
@@ -25,6 +28,65 @@ const (
 
 var excludedKeys = []string{"id", "failback", "failover"}
 
+// missingMappedElements returns the mapped elements which aren't mapped to the cluster.
+func missingMappedElements(mes []string, mc *client.MetaportCluster) []string {
+	clusterMes := make(map[string]bool, len(mc.MappedElements))
+	for _, me := range mc.MappedElements {
+		clusterMes[me] = true
+	}
+	var res []string
+	for _, me := range mes {
+		if !clusterMes[me] {
+			res = append(res, me)
+		}
+	}
+	return res
+}
+
+// validateClusters validates that the failover is between two different clusters.
+func validateClusters(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("cluster_1") || !d.NewValueKnown("cluster_2") {
+		return nil
+	}
+	cluster1 := d.Get("cluster_1").(string)
+	if cluster1 != "" && cluster1 == d.Get("cluster_2").(string) {
+		return fmt.Errorf("cluster_1 and cluster_2 must be different metaport clusters, got %s for both", cluster1)
+	}
+	return nil
+}
+
+// mappedElementsWarnings warns about clusters which don't carry all the mapped elements of the failover.
+// The mapped elements of the clusters may be changed in the same apply, so they are only checked once the failover
+// is applied, and missing mapped elements are reported as warnings.
+func mappedElementsWarnings(ctx context.Context, c *client.Client, d *schema.ResourceData) diag.Diagnostics {
+	mes := client.ResourceTypeSetToStringSlice(d.Get("mapped_elements").(*schema.Set))
+	if len(mes) == 0 {
+		return nil
+	}
+	var diags diag.Diagnostics
+	for _, key := range []string{"cluster_1", "cluster_2"} {
+		mcID := d.Get(key).(string)
+		if mcID == "" {
+			continue
+		}
+		mc, err := client.GetMetaportCluster(ctx, c, mcID)
+		if err != nil {
+			log.Printf("[WARN] Could not validate the mapped elements of %s %s: %v", key, mcID, err)
+			continue
+		}
+		if missing := missingMappedElements(mes, mc); len(missing) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s %s doesn't carry all the mapped elements of the failover", key, mcID),
+				Detail: fmt.Sprintf("The mapped elements %s should be mapped to both clusters.",
+					strings.Join(missing, ", ")),
+				AttributePath: cty.GetAttrPath("mapped_elements"),
+			})
+		}
+	}
+	return diags
+}
+
 func metaportFailoverRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	id := d.Get("id").(string)
@@ -51,7 +113,11 @@ func metaportFailoverCreate(ctx context.Context, d *schema.ResourceData, meta in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	return metaportFailoverToResource(d, m)
+	diags := metaportFailoverToResource(d, m)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, mappedElementsWarnings(ctx, c, d)...)
 }
 
 func metaportFailoverUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -63,7 +129,11 @@ func metaportFailoverUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	return metaportFailoverToResource(d, m)
+	diags := metaportFailoverToResource(d, m)
+	if diags.HasError() || !d.HasChanges("cluster_1", "cluster_2", "mapped_elements") {
+		return diags
+	}
+	return append(diags, mappedElementsWarnings(ctx, c, d)...)
 }
 
 func metaportFailoverDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -0,0 +1,14 @@
+package metaport_failover
+
+import (
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMissingMappedElements(t *testing.T) {
+	mc := &client.MetaportCluster{ID: "mpc-1", MappedElements: []string{"ne-1", "ed-1"}}
+	assert.Empty(t, missingMappedElements([]string{"ne-1", "ed-1"}, mc))
+	assert.Equal(t, []string{"ne-2"}, missingMappedElements([]string{"ne-1", "ne-2"}, mc))
+	assert.Equal(t, []string{"ne-1"}, missingMappedElements([]string{"ne-1"}, &client.MetaportCluster{ID: "mpc-2"}))
+}
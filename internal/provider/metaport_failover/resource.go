@@ -14,6 +14,7 @@ func Resource() *schema.Resource {
 		ReadContext:   metaportFailoverRead,
 		UpdateContext: metaportFailoverUpdate,
 		DeleteContext: metaportFailoverDelete,
+		CustomizeDiff: validateClusters,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -0,0 +1,178 @@
+package metaport_failover_switch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	description = "Manually switches the active cluster of a MetaPort failover, e.g. during disaster recovery drills. " +
+		"Waits for the switch to take effect, and switches back to the cluster which was active before when destroyed."
+	metaportFailoverIdDesc = "ID of the MetaPort failover."
+	activeClusterDesc      = "ID of the MetaPort cluster to activate, either `cluster_1` or `cluster_2` of the failover. " +
+		"When the failover switches clusters on its own, e.g. by an automatic failback, the next plan switches back to this cluster."
+	restoreOnDestroyDesc = "Whether to switch back to the cluster which was active before the switch when destroyed. Defaults to true."
+	originalClusterDesc  = "ID of the MetaPort cluster which was active before the switch."
+)
+
+// pollInterval is the interval between checks of the failover's active cluster.
+const pollInterval = 5 * time.Second
+
+// switchCluster activates the cluster and waits until the failover reports it as active, checking it every interval.
+func switchCluster(ctx context.Context, c *client.Client, mfID, mcID string, timeout, interval time.Duration) (*client.MetaportFailover, error) {
+	_, err := client.SwitchMetaportFailover(ctx, c, mfID, mcID)
+	if err != nil {
+		return nil, err
+	}
+	return waitForCluster(ctx, c, mfID, mcID, timeout, interval)
+}
+
+// waitForCluster waits until the failover reports the cluster as active, checking it every interval.
+func waitForCluster(ctx context.Context, c *client.Client, mfID, mcID string, timeout, interval time.Duration) (*client.MetaportFailover, error) {
+	conf := &resource.StateChangeConf{
+		Pending: []string{"switching"},
+		Target:  []string{"active"},
+		Refresh: func() (interface{}, string, error) {
+			mf, err := client.GetMetaportFailover(ctx, c, mfID)
+			if err != nil {
+				return nil, "", err
+			}
+			if mf.ActiveCluster != nil && *mf.ActiveCluster == mcID {
+				return mf, "active", nil
+			}
+			return mf, "switching", nil
+		},
+		Timeout:      timeout,
+		PollInterval: interval,
+	}
+	mf, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("metaport failover %s didn't switch to cluster %s: %w", mfID, mcID, err)
+	}
+	return mf.(*client.MetaportFailover), nil
+}
+
+// validateActiveCluster validates that the cluster to activate is one of the failover's clusters.
+func validateActiveCluster(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("metaport_failover_id") || !d.NewValueKnown("active_cluster") || !d.HasChange("active_cluster") {
+		return nil
+	}
+	c, ok := meta.(*client.Client)
+	if !ok {
+		return nil
+	}
+	mfID := d.Get("metaport_failover_id").(string)
+	mf, err := client.GetMetaportFailover(ctx, c, mfID)
+	if err != nil {
+		errResponse, ok := err.(*client.ErrorResponse)
+		if ok && errResponse.Status == http.StatusNotFound {
+			// The failover is created in the same apply
+			return nil
+		}
+		return fmt.Errorf("could not validate the active cluster of metaport failover %s: %v", mfID, err)
+	}
+	mcID := d.Get("active_cluster").(string)
+	for _, cluster := range []*string{mf.Cluster1, mf.Cluster2} {
+		if cluster != nil && *cluster == mcID {
+			return nil
+		}
+	}
+	return fmt.Errorf("active_cluster %s is neither cluster_1 nor cluster_2 of metaport failover %s", mcID, mfID)
+}
+
+func switchToResource(d *schema.ResourceData, mf *client.MetaportFailover) diag.Diagnostics {
+	d.SetId(mf.ID)
+	err := d.Set("metaport_failover_id", mf.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if mf.ActiveCluster != nil {
+		err = d.Set("active_cluster", *mf.ActiveCluster)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func readResource(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(*client.Client)
+
+	mfID := d.Get("metaport_failover_id").(string)
+	mf, err := client.GetMetaportFailover(ctx, c, mfID)
+	if err != nil {
+		errResponse, ok := err.(*client.ErrorResponse)
+		if ok && errResponse.Status == http.StatusNotFound {
+			log.Printf("[WARN] Removing switch of metaport failover %s because it's gone", mfID)
+			d.SetId("")
+			return
+		} else {
+			return diag.FromErr(err)
+		}
+	}
+	return switchToResource(d, mf)
+}
+
+func createResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	mfID := d.Get("metaport_failover_id").(string)
+	mf, err := client.GetMetaportFailover(ctx, c, mfID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if mf.ActiveCluster != nil {
+		err = d.Set("original_active_cluster", *mf.ActiveCluster)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	mcID := d.Get("active_cluster").(string)
+	_, err = client.SwitchMetaportFailover(ctx, c, mfID, mcID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// The failover is switched from now on, so it's saved even if the wait fails, to be switched back when destroyed
+	d.SetId(mfID)
+	mf, err = waitForCluster(ctx, c, mfID, mcID, d.Timeout(schema.TimeoutCreate), pollInterval)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return switchToResource(d, mf)
+}
+
+func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	if !d.HasChange("active_cluster") {
+		return nil
+	}
+	mf, err := switchCluster(ctx, c, d.Id(), d.Get("active_cluster").(string), d.Timeout(schema.TimeoutUpdate), pollInterval)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return switchToResource(d, mf)
+}
+
+func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(*client.Client)
+
+	original := d.Get("original_active_cluster").(string)
+	if d.Get("restore_on_destroy").(bool) && original != "" && original != d.Get("active_cluster").(string) {
+		_, err := switchCluster(ctx, c, d.Id(), original, d.Timeout(schema.TimeoutDelete), pollInterval)
+		var errResponse *client.ErrorResponse
+		if err != nil && (!errors.As(err, &errResponse) || errResponse.Status != http.StatusNotFound) {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return
+}
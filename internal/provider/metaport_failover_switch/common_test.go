@@ -0,0 +1,92 @@
+package metaport_failover_switch
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSwitchCluster(t *testing.T) {
+	cases := map[string]struct {
+		SwitchAfter int
+		Gone        bool
+		Error       string
+	}{
+		"switches-immediately": {SwitchAfter: 0},
+		"switches-eventually":  {SwitchAfter: 2},
+		"never-switches":       {SwitchAfter: 1000, Error: "metaport failover mpf-123 didn't switch to cluster mpc-2"},
+		"failover-gone":        {Gone: true, Error: "metaport failover mpf-123 didn't switch to cluster mpc-2"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			reads, switched := 0, false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				active := "mpc-1"
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/v1/metaport_failovers/mpf-123/switch":
+					switched = true
+				case r.Method == http.MethodGet && r.URL.Path == "/v1/metaport_failovers/mpf-123" && !tc.Gone:
+					reads++
+					if switched && reads > tc.SwitchAfter {
+						active = "mpc-2"
+					}
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"status": 404, "title": "Not Found", "detail": "not found"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"id": "mpf-123", "cluster_1": "mpc-1", "cluster_2": "mpc-2", "active_cluster": "` + active + `"}`))
+			}))
+			defer server.Close()
+			c := &client.Client{
+				HTTP:        retryablehttp.NewClient(),
+				BaseURL:     server.URL,
+				Credentials: &client.Credentials{AccessToken: "token"},
+			}
+			mf, err := switchCluster(context.Background(), c, "mpf-123", "mpc-2", 500*time.Millisecond, 10*time.Millisecond)
+			if tc.Error == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, "mpc-2", *mf.ActiveCluster)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.Error)
+			}
+			var errResponse *client.ErrorResponse
+			assert.Equal(t, tc.Gone, errors.As(err, &errResponse), "not found errors should be unwrapped")
+		})
+	}
+}
+
+func TestCreateSavesSwitchedFailover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The failover never reports the new cluster as active
+		_, _ = w.Write([]byte(`{"id": "mpf-123", "cluster_1": "mpc-1", "cluster_2": "mpc-2", "active_cluster": "mpc-1"}`))
+	}))
+	defer server.Close()
+	c := &client.Client{
+		HTTP:        retryablehttp.NewClient(),
+		BaseURL:     server.URL,
+		Credentials: &client.Credentials{AccessToken: "token"},
+	}
+	r := Resource()
+	r.Timeouts.Create = schema.DefaultTimeout(100 * time.Millisecond)
+	d := r.Data(nil)
+	assert.NoError(t, d.Set("metaport_failover_id", "mpf-123"))
+	assert.NoError(t, d.Set("active_cluster", "mpc-2"))
+	diags := createResource(context.Background(), d, c)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "mpf-123", d.Id(), "the switched failover should be saved")
+	assert.Equal(t, "mpc-1", d.Get("original_active_cluster"))
+}
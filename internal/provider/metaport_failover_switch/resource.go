@@ -0,0 +1,53 @@
+package metaport_failover_switch
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+	"time"
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Description:   description,
+		CreateContext: createResource,
+		ReadContext:   readResource,
+		UpdateContext: updateResource,
+		DeleteContext: deleteResource,
+		CustomizeDiff: validateActiveCluster,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metaport_failover_id": {
+				Description:      metaportFailoverIdDesc,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: common.ValidateID(false, "mpf"),
+			},
+			"active_cluster": {
+				Description:      activeClusterDesc,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: common.ValidateID(false, "mpc"),
+			},
+			"restore_on_destroy": {
+				Description: restoreOnDestroyDesc,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"original_active_cluster": {
+				Description: originalClusterDesc,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package metaport_status
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+)
+
+const (
+	description = "Runtime status of a MetaPort, or of every MetaPort of a MetaPort cluster. " +
+		"Useful to check that MetaPorts are connected before shifting traffic onto them."
+	metaportIdDesc        = "ID of the MetaPort."
+	metaportClusterIdDesc = "ID of the MetaPort cluster whose MetaPorts are returned."
+	connectedDesc         = "Whether at least one of the MetaPorts is connected."
+	connectedCountDesc    = "Number of connected MetaPorts."
+	metaportsDesc         = "Statuses of the MetaPorts."
+	connectionStateDesc   = "Connection state of the MetaPort instance, `connected` when it's connected to at least one POP."
+	versionDesc           = "Software version of the MetaPort instance."
+	lastSeenDesc          = "Last time the MetaPort instance was connected."
+	publicIpDesc          = "Public IP address the MetaPort instance connects from."
+	connectedPopsDesc     = "POPs the MetaPort instance is connected to."
+)
+
+func statusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	var statuses []*client.MetaportStatus
+	if mcID := d.Get("metaport_cluster_id").(string); mcID != "" {
+		var err error
+		statuses, err = client.GetMetaportClusterStatus(ctx, c, mcID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(mcID)
+	} else {
+		mID := d.Get("metaport_id").(string)
+		status, err := client.GetMetaportStatus(ctx, c, mID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		statuses = []*client.MetaportStatus{status}
+		d.SetId(mID)
+	}
+	return statusesToResource(d, statuses)
+}
+
+func statusesToResource(d *schema.ResourceData, statuses []*client.MetaportStatus) diag.Diagnostics {
+	metaports := make([]map[string]interface{}, len(statuses))
+	connected := 0
+	for i, s := range statuses {
+		if s.ConnectionState == client.MetaportConnected {
+			connected++
+		}
+		metaports[i] = map[string]interface{}{
+			"id":               s.ID,
+			"connection_state": s.ConnectionState,
+			"version":          s.Version,
+			"last_seen":        s.LastSeen,
+			"public_ip":        s.PublicIp,
+			"connected_pops":   s.ConnectedPops,
+		}
+	}
+	err := d.Set("metaports", metaports)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("connected_count", connected)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("connected", connected > 0)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
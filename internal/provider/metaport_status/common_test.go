@@ -0,0 +1,21 @@
+package metaport_status
+
+import (
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStatusesToResource(t *testing.T) {
+	d := DataSource().TestResourceData()
+	diags := statusesToResource(d, []*client.MetaportStatus{
+		{ID: "mp-1", ConnectionState: "disconnected", LastSeen: "2022-12-01T10:00:00Z"},
+		{ID: "mp-2", ConnectionState: "connected", Version: "2.5.1", PublicIp: "203.0.113.10", ConnectedPops: []string{"fra1", "ams1"}},
+	})
+	assert.Empty(t, diags)
+	assert.Equal(t, true, d.Get("connected"))
+	assert.Equal(t, 1, d.Get("connected_count"))
+	assert.Equal(t, "mp-2", d.Get("metaports.1.id"))
+	assert.Equal(t, []interface{}{"fra1", "ams1"}, d.Get("metaports.1.connected_pops"))
+	assert.Equal(t, "2022-12-01T10:00:00Z", d.Get("metaports.0.last_seen"))
+}
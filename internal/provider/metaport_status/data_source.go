@@ -0,0 +1,83 @@
+package metaport_status
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+)
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: description,
+
+		ReadContext: statusRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metaport_id": {
+				Description:      metaportIdDesc,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: common.ValidateID(false, "mp"),
+				ExactlyOneOf:     []string{"metaport_id", "metaport_cluster_id"},
+			},
+			"metaport_cluster_id": {
+				Description:      metaportClusterIdDesc,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: common.ValidateID(false, "mpc"),
+			},
+			"connected": {
+				Description: connectedDesc,
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"connected_count": {
+				Description: connectedCountDesc,
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"metaports": {
+				Description: metaportsDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connection_state": {
+							Description: connectionStateDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"version": {
+							Description: versionDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_seen": {
+							Description: lastSeenDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"public_ip": {
+							Description: publicIpDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"connected_pops": {
+							Description: connectedPopsDesc,
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -2,11 +2,14 @@ package network_element
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
 	"log"
+	"net"
 	"net/http"
+	"strings"
 )
 
 const (
@@ -14,12 +17,63 @@ const (
 		"- Creating this resource with `mapped_subnets` generates a Mapped Subnet-type network element..\n" +
 		"- Creating this resource with `mapped_service` generates a Mapped Service-type network element.\n"
 	tagsDesc          = "Key/value attributes for combining elements together into Smart Groups, and placed as targets or sources in Policies"
-	mappedSubnetsDesc = "CIDRs that will be mapped to the subnet"
+	mappedSubnetsDesc = "IPv4 or IPv6 CIDRs that will be mapped to the subnet"
 	enabledDesc       = "Not allowed for mapped service and mapped domain"
 )
 
+const (
+	preventOverlappingSubnetsDesc = "Fail the plan when CIDRs added to `mapped_subnets` are equal to, contain or are contained in the mapped subnets " +
+		"of other network elements, since MetaPorts which carry overlapping subnets route them unpredictably. Defaults to false."
+)
+
 var excludedKeys = []string{"id", "tags", "aliases"}
 
+// validateMappedSubnets rejects added mapped subnets which overlap the mapped subnets of other network elements,
+// when prevent_overlapping_subnets is set.
+func validateMappedSubnets(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("prevent_overlapping_subnets").(bool) || !d.HasChange("mapped_subnets") || !d.NewValueKnown("mapped_subnets") {
+		return nil
+	}
+	o, n := d.GetChange("mapped_subnets")
+	added := n.(*schema.Set).Difference(o.(*schema.Set))
+	subnets := make([]client.MappedSubnet, 0, added.Len())
+	for _, cidr := range client.ResourceTypeSetToStringSlice(added) {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return err
+		}
+		subnets = append(subnets, client.MappedSubnet{NetworkElementID: d.Id(), Cidr: ipNet})
+	}
+	if len(subnets) == 0 {
+		return nil
+	}
+	c, ok := meta.(*client.Client)
+	if !ok {
+		return nil
+	}
+	rt, err := client.LoadRoutingTable(ctx, c)
+	if err != nil {
+		return fmt.Errorf("could not validate mapped_subnets: %v", err)
+	}
+	overlaps := rt.OverlapsWith(subnets)
+	if len(overlaps) == 0 {
+		return nil
+	}
+	conflicts := make([]string, len(overlaps))
+	for i, o := range overlaps {
+		added, other, relation := o.Subnet, o.Overlapping, "contains"
+		if other.NetworkElementID == d.Id() {
+			added, other, relation = o.Overlapping, o.Subnet, "is contained in"
+		}
+		if o.Equal() {
+			conflicts[i] = fmt.Sprintf("%s is also mapped by %s (%s)", added.Cidr, other.NetworkElementID, other.NetworkElementName)
+		} else {
+			conflicts[i] = fmt.Sprintf("%s %s %s of %s (%s)", added.Cidr, relation, other.Cidr, other.NetworkElementID, other.NetworkElementName)
+		}
+	}
+	return fmt.Errorf("mapped_subnets overlap the mapped subnets of other network elements:\n  %s", strings.Join(conflicts, "\n  "))
+}
+
 func networkElementsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	id := d.Get("id").(string)
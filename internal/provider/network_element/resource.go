@@ -14,6 +14,7 @@ func Resource() *schema.Resource {
 		ReadContext:   networkElementsRead,
 		UpdateContext: networkElementUpdate,
 		DeleteContext: networkElementDelete,
+		CustomizeDiff: validateMappedSubnets,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -44,15 +45,23 @@ func Resource() *schema.Resource {
 				Type:        schema.TypeSet,
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
-					ValidateDiagFunc: common.ValidateCIDR4(),
+					ValidateDiagFunc: common.ValidateCIDR(),
 				},
+				Set:           common.HashCIDR,
 				Optional:      true,
 				ConflictsWith: []string{"mapped_service", "platform", "owner_id"},
 			},
+			"prevent_overlapping_subnets": {
+				Description: preventOverlappingSubnetsDesc,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"mapped_service": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ConflictsWith: []string{"mapped_subnets", "platform", "owner_id"},
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"mapped_subnets", "platform", "owner_id"},
+				DiffSuppressFunc: common.SuppressEquivalentHostname,
 			},
 			"type": {
 				Type:     schema.TypeString,
@@ -0,0 +1,44 @@
+package network_element_mapped_domains
+
+import (
+	"context"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/network_element_mapped_entries"
+)
+
+const (
+	description = "Manages all the mapped domains of a network element: DNS suffixes to be resolved within the mapped subnet. " +
+		"Mapped domains of the network element which aren't listed are removed, and only the changed ones are set and deleted, " +
+		"which makes this resource suitable for large numbers of domains.\n\n" +
+		"~> **Note:** Don't use this resource together with `pfptmeta_mapped_domain` resources of the same network element, " +
+		"they would remove each other's mapped domains.\n\n" +
+		"The resource is imported by the ID of the network element."
+	networkElementIdDesc = "ID of the mapped subnet network element."
+	mappedDomainsDesc    = "Mapped domains of the network element."
+	nameDesc             = "DNS suffix as resolved by the clients, unique within the network element."
+	mappedDomainDesc     = "DNS suffix the name is resolved as within the mapped subnet."
+)
+
+var mappedDomains = &network_element_mapped_entries.Entries{
+	Key:            "mapped_domains",
+	ValueKey:       "mapped_domain",
+	Kind:           "domain",
+	CanonicalValue: common.CanonicalHostname,
+	ListEntries: func(ctx context.Context, c *client.Client, neID string) ([]network_element_mapped_entries.Entry, error) {
+		mds, err := client.ListMappedDomains(ctx, c, neID)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]network_element_mapped_entries.Entry, len(mds))
+		for i, md := range mds {
+			res[i] = network_element_mapped_entries.Entry{Name: md.Name, Value: md.MappedDomain}
+		}
+		return res, nil
+	},
+	SetEntry: func(ctx context.Context, c *client.Client, neID string, entry network_element_mapped_entries.Entry) error {
+		_, err := client.SetMappedDomain(ctx, c, neID, &client.MappedDomain{Name: entry.Name, MappedDomain: entry.Value})
+		return err
+	},
+	DeleteEntry: client.DeleteMappedDomain,
+}
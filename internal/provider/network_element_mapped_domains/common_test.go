@@ -0,0 +1,15 @@
+package network_element_mapped_domains
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHashMappedDomain(t *testing.T) {
+	assert.Equal(t,
+		mappedDomains.Hash(map[string]interface{}{"name": "corp.example.com", "mapped_domain": "corp.internal"}),
+		mappedDomains.Hash(map[string]interface{}{"name": "Corp.example.com", "mapped_domain": "CORP.internal"}))
+	assert.NotEqual(t,
+		mappedDomains.Hash(map[string]interface{}{"name": "corp.example.com", "mapped_domain": "corp.internal"}),
+		mappedDomains.Hash(map[string]interface{}{"name": "corp.example.com", "mapped_domain": "lab.internal"}))
+}
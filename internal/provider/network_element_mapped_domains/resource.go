@@ -0,0 +1,57 @@
+package network_element_mapped_domains
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: description,
+
+		CreateContext: mappedDomains.Create,
+		ReadContext:   mappedDomains.Read,
+		UpdateContext: mappedDomains.Update,
+		DeleteContext: mappedDomains.Delete,
+		CustomizeDiff: mappedDomains.ValidateUniqueNames,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"network_element_id": {
+				Description:      networkElementIdDesc,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: common.ValidateID(true, "ne"),
+			},
+			"mapped_domains": {
+				Description: mappedDomainsDesc,
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         mappedDomains.Hash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description:      nameDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: common.ValidateHostName(),
+						},
+						"mapped_domain": {
+							Description:      mappedDomainDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: common.ValidateHostName(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
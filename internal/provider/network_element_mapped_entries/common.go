@@ -0,0 +1,199 @@
+package network_element_mapped_entries
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+	"log"
+	"net/http"
+	"sort"
+)
+
+// Entry is a mapped entry of a network element, which maps a name to a value, e.g. a mapped host or a mapped domain.
+type Entry struct {
+	Name  string
+	Value string
+}
+
+// Entries implements the resources which manage all the mapped entries of a kind of a network element,
+// e.g. pfptmeta_network_element_mapped_hosts, which only differ in the attributes and the client functions of the entries.
+type Entries struct {
+	// Key is the attribute of the set of entries, e.g. "mapped_hosts".
+	Key string
+	// ValueKey is the attribute of the value of an entry, e.g. "mapped_host".
+	ValueKey string
+	// Kind is what the names are mapped to, used in messages, e.g. "host".
+	Kind string
+	// CanonicalValue returns the form the API returns the values in.
+	CanonicalValue func(string) string
+	ListEntries    func(ctx context.Context, c *client.Client, neID string) ([]Entry, error)
+	// SetEntry adds the entry, or maps the existing entry with the same name to the new value.
+	SetEntry    func(ctx context.Context, c *client.Client, neID string, entry Entry) error
+	DeleteEntry func(ctx context.Context, c *client.Client, neID, name string) error
+}
+
+// Hash hashes the canonical form of the entry, so that entries which the API returns in a different case
+// are the same set element.
+func (e *Entries) Hash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return schema.HashString(common.CanonicalHostname(m["name"].(string)) + "=" + e.CanonicalValue(m[e.ValueKey].(string)))
+}
+
+func (e *Entries) expand(s *schema.Set) []Entry {
+	res := make([]Entry, 0, s.Len())
+	for _, v := range s.List() {
+		m := v.(map[string]interface{})
+		res = append(res, Entry{Name: m["name"].(string), Value: m[e.ValueKey].(string)})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func (e *Entries) flatten(entries []Entry) []map[string]interface{} {
+	res := make([]map[string]interface{}, len(entries))
+	for i, entry := range entries {
+		res[i] = map[string]interface{}{"name": entry.Name, e.ValueKey: entry.Value}
+	}
+	return res
+}
+
+// diff returns the entries to add, which are new or mapped to a different value, and the names of the entries to remove.
+func (e *Entries) diff(old, new []Entry) (add []Entry, remove []string) {
+	oldValues := make(map[string]string, len(old))
+	for _, entry := range old {
+		oldValues[common.CanonicalHostname(entry.Name)] = e.CanonicalValue(entry.Value)
+	}
+	newNames := make(map[string]bool, len(new))
+	for _, entry := range new {
+		name := common.CanonicalHostname(entry.Name)
+		newNames[name] = true
+		if value, ok := oldValues[name]; !ok || value != e.CanonicalValue(entry.Value) {
+			add = append(add, entry)
+		}
+	}
+	for _, entry := range old {
+		if !newNames[common.CanonicalHostname(entry.Name)] {
+			remove = append(remove, entry.Name)
+		}
+	}
+	return
+}
+
+// apply removes the entries with the names in remove and then sets the entries in add, one at a time.
+// Entries which are already gone are ignored. The writes don't wait for the API to be consistent one by one,
+// only once after the last one, so applying many entries isn't as slow as many separate resources.
+func (e *Entries) apply(ctx context.Context, c *client.Client, neID string, add []Entry, remove []string) error {
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	defer client.WaitUntilConsistent()
+	ctx = client.WithoutConsistencySleep(ctx)
+	for _, name := range remove {
+		err := e.DeleteEntry(ctx, c, neID, name)
+		if err != nil {
+			errResponse, ok := err.(*client.ErrorResponse)
+			if !ok || errResponse.Status != http.StatusNotFound {
+				return err
+			}
+		}
+	}
+	for _, entry := range add {
+		err := e.SetEntry(ctx, c, neID, entry)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateUniqueNames validates that each name is mapped to a single value.
+func (e *Entries) ValidateUniqueNames(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(e.Key) {
+		return nil
+	}
+	names := make(map[string]bool)
+	for _, entry := range e.expand(d.Get(e.Key).(*schema.Set)) {
+		name := common.CanonicalHostname(entry.Name)
+		if names[name] {
+			return fmt.Errorf("%s is mapped more than once, each name can only be mapped to a single %s", entry.Name, e.Kind)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+func (e *Entries) Read(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(*client.Client)
+
+	neID := d.Id()
+	entries, err := e.ListEntries(ctx, c, neID)
+	if err != nil {
+		errResponse, ok := err.(*client.ErrorResponse)
+		if ok && errResponse.Status == http.StatusNotFound {
+			log.Printf("[WARN] Removing %s of network element %s because it's gone", e.Key, neID)
+			d.SetId("")
+			return
+		} else {
+			return diag.FromErr(err)
+		}
+	}
+	err = d.Set("network_element_id", neID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set(e.Key, e.flatten(entries))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return
+}
+
+func (e *Entries) Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	neID := d.Get("network_element_id").(string)
+	existing, err := e.ListEntries(ctx, c, neID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	add, remove := e.diff(existing, e.expand(d.Get(e.Key).(*schema.Set)))
+	err = e.apply(ctx, c, neID, add, remove)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(neID)
+	return e.Read(ctx, d, meta)
+}
+
+func (e *Entries) Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	neID := d.Id()
+	o, n := d.GetChange(e.Key)
+	add, remove := e.diff(e.expand(o.(*schema.Set)), e.expand(n.(*schema.Set)))
+	err := e.apply(ctx, c, neID, add, remove)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return e.Read(ctx, d, meta)
+}
+
+func (e *Entries) Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(*client.Client)
+
+	neID := d.Id()
+	entries := e.expand(d.Get(e.Key).(*schema.Set))
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name
+	}
+	err := e.apply(ctx, c, neID, nil, names)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return
+}
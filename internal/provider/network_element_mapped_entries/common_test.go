@@ -0,0 +1,45 @@
+package network_element_mapped_entries
+
+import (
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testEntries = &Entries{Key: "mapped_hosts", ValueKey: "mapped_host", Kind: "host", CanonicalValue: common.CanonicalDomain}
+
+func TestDiff(t *testing.T) {
+	old := []Entry{
+		{Name: "db.example.com", Value: "10.0.0.1"},
+		{Name: "web.example.com", Value: "10.0.0.2"},
+		{Name: "old.example.com", Value: "10.0.0.3"},
+	}
+	add, remove := testEntries.diff(old, []Entry{
+		{Name: "DB.example.com", Value: "10.0.0.1"},
+		{Name: "web.example.com", Value: "10.0.0.20"},
+		{Name: "new.example.com", Value: "New.internal"},
+	})
+	assert.Equal(t, []Entry{
+		{Name: "web.example.com", Value: "10.0.0.20"},
+		{Name: "new.example.com", Value: "New.internal"},
+	}, add)
+	assert.Equal(t, []string{"old.example.com"}, remove)
+
+	add, remove = testEntries.diff(old, old)
+	assert.Empty(t, add)
+	assert.Empty(t, remove)
+
+	add, remove = testEntries.diff([]Entry{{Name: "lab.example.com", Value: "lab.internal"}},
+		[]Entry{{Name: "lab.example.com", Value: "LAB.internal"}})
+	assert.Empty(t, add, "values which only differ in their case are the same")
+	assert.Empty(t, remove)
+}
+
+func TestFlatten(t *testing.T) {
+	entries := []Entry{{Name: "a.example.com", Value: "10.0.0.1"}, {Name: "b.example.com", Value: "10.0.0.2"}}
+	flattened := testEntries.flatten(entries)
+	assert.Equal(t, []map[string]interface{}{
+		{"name": "a.example.com", "mapped_host": "10.0.0.1"},
+		{"name": "b.example.com", "mapped_host": "10.0.0.2"},
+	}, flattened)
+}
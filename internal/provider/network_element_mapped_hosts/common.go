@@ -0,0 +1,44 @@
+package network_element_mapped_hosts
+
+import (
+	"context"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/network_element_mapped_entries"
+)
+
+const (
+	description = "Manages all the mapped hosts of a network element: additional domain names for specific hosts on the mapped subnet. " +
+		"Mapped hosts of the network element which aren't listed are removed, and only the changed ones are set and deleted, " +
+		"which makes this resource suitable for large numbers of hosts.\n\n" +
+		"~> **Note:** Don't use this resource together with `pfptmeta_mapped_host` resources of the same network element, " +
+		"they would remove each other's mapped hosts.\n\n" +
+		"The resource is imported by the ID of the network element."
+	networkElementIdDesc = "ID of the mapped subnet network element."
+	mappedHostsDesc      = "Mapped hosts of the network element."
+	nameDesc             = "Domain name of the host, unique within the network element."
+	mappedHostDesc       = "Hostname, IPv4 or IPv6 address the name is mapped to."
+)
+
+var mappedHosts = &network_element_mapped_entries.Entries{
+	Key:            "mapped_hosts",
+	ValueKey:       "mapped_host",
+	Kind:           "host",
+	CanonicalValue: common.CanonicalDomain,
+	ListEntries: func(ctx context.Context, c *client.Client, neID string) ([]network_element_mapped_entries.Entry, error) {
+		mhs, err := client.ListMappedHosts(ctx, c, neID)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]network_element_mapped_entries.Entry, len(mhs))
+		for i, mh := range mhs {
+			res[i] = network_element_mapped_entries.Entry{Name: mh.Name, Value: mh.MappedHost}
+		}
+		return res, nil
+	},
+	SetEntry: func(ctx context.Context, c *client.Client, neID string, entry network_element_mapped_entries.Entry) error {
+		_, err := client.SetMappedHost(ctx, c, neID, &client.MappedHost{Name: entry.Name, MappedHost: entry.Value})
+		return err
+	},
+	DeleteEntry: client.DeleteMappedHost,
+}
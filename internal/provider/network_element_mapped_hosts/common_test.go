@@ -0,0 +1,15 @@
+package network_element_mapped_hosts
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHashMappedHost(t *testing.T) {
+	assert.Equal(t,
+		mappedHosts.Hash(map[string]interface{}{"name": "db.example.com", "mapped_host": "2001:db8::1"}),
+		mappedHosts.Hash(map[string]interface{}{"name": "DB.example.com", "mapped_host": "2001:DB8::0001"}))
+	assert.NotEqual(t,
+		mappedHosts.Hash(map[string]interface{}{"name": "db.example.com", "mapped_host": "10.0.0.1"}),
+		mappedHosts.Hash(map[string]interface{}{"name": "db.example.com", "mapped_host": "10.0.0.2"}))
+}
@@ -0,0 +1,57 @@
+package network_element_mapped_hosts
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+)
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: description,
+
+		CreateContext: mappedHosts.Create,
+		ReadContext:   mappedHosts.Read,
+		UpdateContext: mappedHosts.Update,
+		DeleteContext: mappedHosts.Delete,
+		CustomizeDiff: mappedHosts.ValidateUniqueNames,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"network_element_id": {
+				Description:      networkElementIdDesc,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: common.ValidateID(true, "ne"),
+			},
+			"mapped_hosts": {
+				Description: mappedHostsDesc,
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         mappedHosts.Hash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description:      nameDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: common.ValidateHostName(),
+						},
+						"mapped_host": {
+							Description:      mappedHostDesc,
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: common.ValidateHostnameOrIP(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -2,15 +2,21 @@ package notification_channel
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
 	"log"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
 const (
-	description = `The system supports four channel notification types. Notifications are sent according to the logs generated by various system components.
+	description = `The system supports several channel notification types. Notifications are sent according to the logs generated by various system components.
 
 Channel – A conduit for delivering notifications to different systems. The following notification channels are supported:
 
@@ -20,18 +26,85 @@ Slack – Uses Slack Webhook API to send notifications to a specific Slack chann
 
 PagerDuty – Uses the PagerDuty API key created in your account to Send notifications to specific PagerDuty account.
 
-Webhook – Sends notifications to any system that supports a Webhook API.`
-	recipientsDesc          = "Up to 10 email addresses to which notifications will be sent."
-	pagerDutyApiKeyConfDesc = "PagerDuty API key created in your PagerDuty account. " + sensitiveDataWarning
-	slackURLDesc            = "Slack Webhook URL. " + sensitiveDataWarning
-	slackChannelDesc        = "Slack channel."
-	webHookConf             = "Used for any system that supports Webhook API"
-	payloadDesc             = "A custom JSON object to be used as a Webhook alert payload."
-	methodDesc              = "Enum: POST, PUT"
-	sensitiveDataWarning    = "Note that this may show up in logs, and it will be stored in the state file."
+Webhook – Sends notifications to any system that supports a Webhook API.
+
+Syslog – Sends notifications to a syslog server.
+
+Microsoft Teams, Opsgenie and ServiceNow – Webhook channels preconfigured with the URL, headers and payload of each service's API.
+They are created as webhook channels, so they are read as such by the data source and when imported.`
+	recipientsDesc         = "Up to 10 email addresses to which notifications will be sent."
+	slackChannelDesc       = "Slack channel."
+	webHookConf            = "Used for any system that supports Webhook API"
+	headersDesc            = "HTTP headers to send with every notification, mapping header names to their values."
+	methodDesc             = "Enum: POST, PUT"
+	teamsConfDesc          = "Sends notifications as message cards to a Microsoft Teams channel, using an incoming webhook."
+	opsgenieConfDesc       = "Creates Opsgenie alerts with the Opsgenie alert API."
+	opsgenieRegionDesc     = "Region of the Opsgenie account. ENUM: `US`, `EU`."
+	opsgeniePriorityDesc   = "Priority of the created alerts. ENUM: `P1`, `P2`, `P3`, `P4`, `P5`."
+	opsgenieTeamDesc       = "Name of the team the created alerts are assigned to."
+	opsgenieTagsDesc       = "Tags of the created alerts."
+	serviceNowConfDesc     = "Creates ServiceNow records, incidents by default, with the ServiceNow table API."
+	serviceNowInstanceDesc = "URL of the ServiceNow instance, e.g. https://example.service-now.com."
+	serviceNowUsernameDesc = "Name of the ServiceNow user creating the records."
+	serviceNowTableDesc    = "Table in which the records are created."
+	serviceNowUrgencyDesc  = "Urgency of the created records, from 1 (high) to 3 (low)."
+	serviceNowImpactDesc   = "Impact of the created records, from 1 (high) to 3 (low)."
+	serviceNowGroupDesc    = "Assignment group of the created records."
+	syslogConfDesc         = "Sends notifications to a syslog server."
+	syslogHostDesc         = "Hostname or IP address of the syslog server."
+	syslogPortDesc         = "Port of the syslog server."
+	syslogProtoDesc        = "ENUM: `tcp`, `udp`."
+	sendTestDesc           = "Send a test notification through the channel after it is created or updated. " +
+		"Failures to deliver it are reported as warnings."
+)
+
+const (
+	secretHashWarning          = "Note that this may show up in logs, only its SHA-256 hash is stored in the state file."
+	secretFileNote             = "The file is read during plan, without its trailing newline, so rotating its content updates the channel."
+	pagerDutyApiKeyDesc        = "PagerDuty API key created in your PagerDuty account. " + secretHashWarning
+	pagerDutyApiKeyFileDesc    = "Path of a file containing the PagerDuty API key, instead of `api_key`. " + secretFileNote
+	slackURLSecretDesc         = "Slack Webhook URL. " + secretHashWarning
+	slackURLFileDesc           = "Path of a file containing the Slack Webhook URL, instead of `url`. " + secretFileNote
+	clientSecretDesc           = "OAuth2 client secret. " + secretHashWarning
+	clientSecretFileDesc       = "Path of a file containing the OAuth2 client secret, instead of `client_secret`. " + secretFileNote
+	teamsURLDesc               = "Incoming webhook URL of the Teams channel. " + secretHashWarning
+	teamsURLFileDesc           = "Path of a file containing the incoming webhook URL of the Teams channel, instead of `url`. " + secretFileNote
+	opsgenieApiKeyDesc         = "API key of an Opsgenie API integration. " + secretHashWarning
+	opsgenieApiKeyFileDesc     = "Path of a file containing the Opsgenie API key, instead of `api_key`. " + secretFileNote
+	serviceNowPasswordDesc     = "Password of the ServiceNow user. " + secretHashWarning
+	serviceNowPasswordFileDesc = "Path of a file containing the password of the ServiceNow user, instead of `password`. " + secretFileNote
+	secretVersionDesc          = "Changing this value sends the secrets of the channel again, even if their hashes haven't changed. " +
+		"Secrets are otherwise only sent on create and when they change."
+	secretHashDesc = "SHA-256 hash of the secrets of the channel, including secrets read from files."
 )
 
-var ExcludedKeys = []string{"id", "email_config", "pagerduty_config", "slack_config", "webhook_config"}
+var serviceNowTablePattern = regexp.MustCompile("^[a-z0-9_]+$")
+
+// channelConfigs are the mutually exclusive configuration blocks of the notification channel types.
+var channelConfigs = []string{
+	"email_config", "pagerduty_config", "slack_config", "webhook_config",
+	"teams_config", "opsgenie_config", "servicenow_config", "syslog_config",
+}
+
+func otherChannelConfigs(config string) []string {
+	var res []string
+	for _, c := range channelConfigs {
+		if c != config {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+const payloadDesc = "A custom JSON object to be used as a Webhook alert payload. " +
+	"Alert variables can be used as `{{ variable }}` placeholders, which are validated during plan. " +
+	"String values are JSON escaped, so their placeholders should be quoted, e.g. `{\"name\": \"{{ alert_name }}\"}`. " +
+	"Use the `pfptmeta_notification_preview` data source to render the payload of a sample alert."
+
+const previewDesc = "Renders a webhook custom payload for a sample alert, without sending it. " +
+	"Useful to test payload templates, e.g. with `terraform console` or test assertions."
+
+var ExcludedKeys = []string{"id", "email_config", "pagerduty_config", "slack_config", "webhook_config", "syslog_config"}
 
 func notificationChannelToResource(d *schema.ResourceData, nc *client.NotificationChannel) (diags diag.Diagnostics) {
 	d.SetId(nc.ID)
@@ -53,46 +126,59 @@ func notificationChannelToResource(d *schema.ResourceData, nc *client.Notificati
 		}
 	case "slack":
 		if nc.SlackConfig != nil {
-			sConfig := []map[string]interface{}{
-				{"channel": nc.SlackConfig.Channel, "url": nc.SlackConfig.Url},
+			conf, managed := configuredBlock(d, "slack_config")
+			conf["channel"] = nc.SlackConfig.Channel
+			if !managed {
+				conf["url"] = hashSecret(nc.SlackConfig.Url)
 			}
-			err = d.Set("slack_config", sConfig)
+			err = d.Set("slack_config", []map[string]interface{}{conf})
 			if err != nil {
 				return diag.FromErr(err)
 			}
 		}
 	case "pagerduty":
 		if nc.PagerdutyConfig != nil {
-			pdConfig := []map[string]interface{}{
-				{"api_key": nc.PagerdutyConfig.ApiKey},
+			conf, managed := configuredBlock(d, "pagerduty_config")
+			if !managed {
+				conf["api_key"] = hashSecret(nc.PagerdutyConfig.ApiKey)
+			}
+			err = d.Set("pagerduty_config", []map[string]interface{}{conf})
+			if err != nil {
+				return diag.FromErr(err)
 			}
-			err = d.Set("pagerduty_config", pdConfig)
+		}
+	case "syslog":
+		if nc.SyslogConfig != nil {
+			sConfig := []map[string]interface{}{
+				{"host": nc.SyslogConfig.Host, "port": nc.SyslogConfig.Port, "proto": nc.SyslogConfig.Proto},
+			}
+			err = d.Set("syslog_config", sConfig)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 		}
 	case "webhook":
-		origWebHookVody := client.NewWebhookConfig(d)
+		if preset := configuredPreset(d); preset != "" {
+			return presetToResource(d, preset, nc.WebhookConfig)
+		}
 		if nc.WebhookConfig != nil {
 			whConfig := []map[string]interface{}{
 				{
 					"custom_payload": nc.WebhookConfig.CustomPayload,
-					"headers":        nc.WebhookConfig.Headers,
+					"headers":        headersToMap(nc.WebhookConfig.Headers),
 					"method":         nc.WebhookConfig.Method,
 					"url":            nc.WebhookConfig.Url,
 				},
 			}
 			if nc.WebhookConfig.Auth != nil {
+				oauth2Config, managed := configuredBlock(d, "webhook_config.0.auth.0.oauth2_config")
+				oauth2Config["client_id"] = nc.WebhookConfig.Auth.Oauth2Config.ClientId
+				oauth2Config["token_url"] = nc.WebhookConfig.Auth.Oauth2Config.TokenUrl
+				if !managed {
+					oauth2Config["client_secret"] = hashSecret(nc.WebhookConfig.Auth.Oauth2Config.ClientSecret)
+				}
 				whConfig[0]["auth"] = []map[string]interface{}{
-					{
-						"oauth2_config": []map[string]interface{}{
-							{
-								"client_id":     nc.WebhookConfig.Auth.Oauth2Config.ClientId,
-								"client_secret": origWebHookVody.Auth.Oauth2Config.ClientSecret,
-								"token_url":     nc.WebhookConfig.Auth.Oauth2Config.TokenUrl,
-							},
-						},
-					},
+					{"oauth2_config": []map[string]interface{}{oauth2Config}},
 				}
 			}
 			err = d.Set("webhook_config", whConfig)
@@ -104,6 +190,64 @@ func notificationChannelToResource(d *schema.ResourceData, nc *client.Notificati
 	return
 }
 
+// configuredBlock returns the first block at key as it is in the state, and whether there is one.
+// The API doesn't return secrets, so the secret attributes of a configured block, which hold hashes and file paths,
+// are kept as they are. Secrets returned for blocks which aren't configured yet, e.g. on import, are stored as hashes.
+func configuredBlock(d *schema.ResourceData, key string) (map[string]interface{}, bool) {
+	if blocks, ok := d.Get(key).([]interface{}); ok && len(blocks) > 0 && blocks[0] != nil {
+		return blocks[0].(map[string]interface{}), true
+	}
+	return map[string]interface{}{}, false
+}
+
+// configuredPreset returns the webhook preset block the resource is configured with, if any.
+// Presets are created as webhook channels, so they can only be told apart by the configuration.
+func configuredPreset(d *schema.ResourceData) string {
+	for _, preset := range []string{"teams_config", "opsgenie_config", "servicenow_config"} {
+		// The data source doesn't have preset blocks
+		if conf, ok := d.Get(preset).([]interface{}); ok && len(conf) > 0 {
+			return preset
+		}
+	}
+	return ""
+}
+
+// presetToResource updates the preset block with the fields that can be derived from the webhook's url,
+// the rest of its fields, e.g. secrets sent in headers, are kept as they are.
+func presetToResource(d *schema.ResourceData, preset string, whConfig *client.WebhookConfig) diag.Diagnostics {
+	if whConfig == nil {
+		return nil
+	}
+	conf := d.Get(preset).([]interface{})[0].(map[string]interface{})
+	switch preset {
+	case "teams_config":
+		// The url is a secret, its hash is only updated if it's configured inline and changed out of band
+		if conf["url"].(string) != "" {
+			conf["url"] = hashSecret(whConfig.Url)
+		}
+	case "opsgenie_config":
+		conf["region"] = client.OpsgenieRegion(whConfig.Url)
+	case "servicenow_config":
+		conf["instance_url"], conf["table"] = client.ParseServiceNowURL(whConfig.Url)
+	}
+	err := d.Set(preset, []map[string]interface{}{conf})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// headersToMap converts the "Name: value" headers of the API to a map of header names to values.
+func headersToMap(headers []string) map[string]string {
+	res := make(map[string]string, len(headers))
+	for _, header := range headers {
+		if match := common.HttpHeaderPattern.FindStringSubmatch(header); match != nil {
+			res[match[1]] = strings.TrimSpace(match[2])
+		}
+	}
+	return res
+}
+
 func ncRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	c := meta.(*client.Client)
 
@@ -121,15 +265,45 @@ func ncRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diag
 	}
 	return notificationChannelToResource(d, nc)
 }
+func previewRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	alert, err := sampleAlert(d.Get("alert").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	payload, err := renderPayload(d.Get("custom_payload").(string), alert)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("payload", payload)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("variables", payloadVariableNames())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(strconv.Itoa(schema.HashString(payload)))
+	return nil
+}
+
 func ncCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*client.Client)
 
 	body := client.NewNotificationChannel(d)
+	secrets, _, err := configSecrets(d.GetRawConfig())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setSecrets(d, body, secrets)
 	nc, err := client.CreateNotificationChannel(ctx, c, body)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	return notificationChannelToResource(d, nc)
+	diags := notificationChannelToResource(d, nc)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, sendTest(ctx, c, d)...)
 }
 
 func ncUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -137,11 +311,39 @@ func ncUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 
 	id := d.Id()
 	body := client.NewNotificationChannel(d)
+	secrets, err := changedSecrets(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setSecrets(d, body, secrets)
 	nc, err := client.UpdateNotificationChannel(ctx, c, id, body)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	return notificationChannelToResource(d, nc)
+	diags := notificationChannelToResource(d, nc)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, sendTest(ctx, c, d)...)
+}
+
+// sendTest sends a test notification through the channel if send_test_on_change is enabled.
+func sendTest(ctx context.Context, c *client.Client, d *schema.ResourceData) diag.Diagnostics {
+	if !d.Get("send_test_on_change").(bool) {
+		return nil
+	}
+	res, err := client.TestNotificationChannel(ctx, c, d.Id())
+	if err == nil && !res.Success {
+		err = errors.New(res.Error)
+	}
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Could not deliver a test notification through channel %s", d.Id()),
+			Detail:   err.Error(),
+		}}
+	}
+	return nil
 }
 
 func ncDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
@@ -0,0 +1,52 @@
+package notification_channel
+
+import (
+	"context"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendTest(t *testing.T) {
+	cases := map[string]struct {
+		Status   int
+		Response string
+		Warning  string
+	}{
+		"delivered":         {Status: http.StatusOK, Response: `{"success": true}`},
+		"delivery-failed":   {Status: http.StatusOK, Response: `{"success": false, "error": "connection refused"}`, Warning: "connection refused"},
+		"test-not-possible": {Status: http.StatusBadRequest, Response: `{"status": 400, "title": "Bad Request", "detail": "channel is disabled"}`, Warning: "channel is disabled"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/notification_channels/nch-123/test", r.URL.Path)
+				w.WriteHeader(tc.Status)
+				_, _ = w.Write([]byte(tc.Response))
+			}))
+			defer server.Close()
+			c := &client.Client{
+				HTTP:        retryablehttp.NewClient(),
+				BaseURL:     server.URL,
+				Credentials: &client.Credentials{AccessToken: "token"},
+			}
+			c.HTTP.CheckRetry = client.RetryPolicy
+			d := Resource().TestResourceData()
+			d.SetId("nch-123")
+			assert.NoError(t, d.Set("send_test_on_change", true))
+			diags := sendTest(context.Background(), c, d)
+			if tc.Warning == "" {
+				assert.Empty(t, diags)
+				return
+			}
+			if assert.Len(t, diags, 1) {
+				assert.Equal(t, diag.Warning, diags[0].Severity)
+				assert.Contains(t, diags[0].Detail, tc.Warning)
+			}
+		})
+	}
+}
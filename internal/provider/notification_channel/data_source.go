@@ -52,7 +52,7 @@ func DataSource() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"api_key": {
-							Description: pagerDutyApiKeyConfDesc,
+							Description: pagerDutyApiKeyDesc,
 							Type:        schema.TypeString,
 							Computed:    true,
 							Sensitive:   true,
@@ -61,7 +61,7 @@ func DataSource() *schema.Resource {
 				},
 			},
 			"slack_config": {
-				Description: slackURLDesc,
+				Description: slackURLSecretDesc,
 				Type:        schema.TypeList,
 				Computed:    true,
 				Elem: &schema.Resource{
@@ -72,7 +72,7 @@ func DataSource() *schema.Resource {
 							Computed:    true,
 						},
 						"url": {
-							Description: slackURLDesc,
+							Description: slackURLSecretDesc,
 							Type:        schema.TypeString,
 							Computed:    true,
 							Sensitive:   true,
@@ -102,7 +102,7 @@ func DataSource() *schema.Resource {
 													Sensitive: true,
 												},
 												"client_secret": {
-													Description: sensitiveDataWarning,
+													Description: clientSecretDesc,
 													Type:        schema.TypeString,
 													Computed:    true,
 													Sensitive:   true,
@@ -124,11 +124,12 @@ func DataSource() *schema.Resource {
 							Sensitive:   true,
 						},
 						"headers": {
-							Type:     schema.TypeList,
-							Computed: true,
+							Description: headersDesc,
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Sensitive:   true,
 							Elem: &schema.Schema{
-								Type:      schema.TypeString,
-								Sensitive: true,
+								Type: schema.TypeString,
 							},
 						},
 						"method": {
@@ -143,6 +144,69 @@ func DataSource() *schema.Resource {
 					},
 				},
 			},
+			"syslog_config": {
+				Description: syslogConfDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Description: syslogHostDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"port": {
+							Description: syslogPortDesc,
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"proto": {
+							Description: syslogProtoDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func PreviewDataSource() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: previewDesc,
+
+		ReadContext: previewRead,
+
+		Schema: map[string]*schema.Schema{
+			"custom_payload": {
+				Description:      "The webhook custom payload template to render.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validatePayloadTemplate(),
+			},
+			"alert": {
+				Description: "Alert variables overriding the values of the sample alert.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"payload": {
+				Description: "The payload that would be sent for the sample alert.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"variables": {
+				Description: "The alert variables supported in custom payloads.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,157 @@
+package notification_channel
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// payloadVariables are the alert variables which can be used in a webhook custom_payload,
+// mapped to the sample values used to validate and preview payloads.
+var payloadVariables = map[string]interface{}{
+	"alert_id":          "alr-1a2b3c4d5e6f7g8",
+	"alert_name":        "Suspicious login attempts",
+	"alert_description": "Multiple failed login attempts by the same user",
+	"notify_message":    "User exceeded the failed login threshold",
+	"source_type":       "security_audit",
+	"query_text":        "action:login AND status:failed",
+	"group_by":          "actor",
+	"group_value":       "user@example.com",
+	"hits":              12,
+	"threshold":         10,
+	"window_start":      "2022-01-01T10:00:00Z",
+	"window_end":        "2022-01-01T10:15:00Z",
+	"triggered_at":      "2022-01-01T10:15:03Z",
+	"org_shortname":     "example",
+	"console_url":       "https://admin.metanetworks.com/#/alerts/alr-1a2b3c4d5e6f7g8",
+}
+
+var placeholderPattern = regexp.MustCompile(`{{\s*([^{}]*?)\s*}}`)
+
+func payloadVariableNames() []string {
+	res := make([]string, 0, len(payloadVariables))
+	for name := range payloadVariables {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// renderPayload replaces every {{ variable }} placeholder in the template with the matching alert value.
+// String values are JSON escaped, so placeholders of strings should be quoted, e.g. {"name": "{{ alert_name }}"}.
+func renderPayload(template string, alert map[string]interface{}) (string, error) {
+	var unknown []string
+	rendered := placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		value, ok := alert[name]
+		if !ok {
+			unknown = append(unknown, name)
+			return placeholder
+		}
+		if s, ok := value.(string); ok {
+			escaped, _ := json.Marshal(s)
+			return string(escaped[1 : len(escaped)-1])
+		}
+		return fmt.Sprint(value)
+	})
+	if len(unknown) > 0 {
+		var errs []string
+		for _, name := range unknown {
+			err := fmt.Sprintf("unknown alert variable \"%s\"", name)
+			if suggestion := closestVariable(name); suggestion != "" {
+				err += fmt.Sprintf(", did you mean \"%s\"?", suggestion)
+			}
+			errs = append(errs, err)
+		}
+		return "", fmt.Errorf("%s. Supported variables are %s", strings.Join(errs, "; "), strings.Join(payloadVariableNames(), ", "))
+	}
+	if strings.Contains(rendered, "{{") || strings.Contains(rendered, "}}") {
+		return "", fmt.Errorf("payload contains an unclosed placeholder, placeholders should be in the form {{ variable }}")
+	}
+	return rendered, nil
+}
+
+// sampleAlert returns the sample alert values, overridden by the given values.
+// Overrides of numeric variables are converted to numbers.
+func sampleAlert(overrides map[string]interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{}, len(payloadVariables))
+	for name, value := range payloadVariables {
+		res[name] = value
+	}
+	for name, value := range overrides {
+		sample, ok := payloadVariables[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown alert variable \"%s\", supported variables are %s", name, strings.Join(payloadVariableNames(), ", "))
+		}
+		s := value.(string)
+		if _, numeric := sample.(int); numeric {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, fmt.Errorf("alert variable \"%s\" should be a number, got \"%s\"", name, s)
+			}
+			res[name] = n
+			continue
+		}
+		res[name] = s
+	}
+	return res, nil
+}
+
+func closestVariable(name string) string {
+	res, best := "", 4
+	for _, candidate := range payloadVariableNames() {
+		if distance := editDistance(name, candidate); distance < best {
+			res, best = candidate, distance
+		}
+	}
+	return res
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// validatePayloadTemplate validates that the placeholders of a custom payload are supported alert variables,
+// and that the payload is a valid json once rendered.
+func validatePayloadTemplate() func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, _ cty.Path) diag.Diagnostics {
+		template := input.(string)
+		rendered, err := renderPayload(template, payloadVariables)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		var js json.RawMessage
+		if err = json.Unmarshal([]byte(rendered), &js); err != nil {
+			return diag.Errorf("\"%.200s\" is not a valid json once rendered. %s", rendered, err)
+		}
+		return nil
+	}
+}
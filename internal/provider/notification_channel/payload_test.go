@@ -0,0 +1,106 @@
+package notification_channel
+
+import (
+	"context"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRenderPayload(t *testing.T) {
+	cases := map[string]struct {
+		Template string
+		Expected string
+		Error    string
+	}{
+		"strings-are-escaped": {
+			Template: `{"text": "{{ alert_name }}: {{query_text}}"}`,
+			Expected: `{"text": "Suspicious \"login\": action:login AND status:failed"}`,
+		},
+		"numbers": {
+			Template: `{"hits": {{ hits }}}`,
+			Expected: `{"hits": 12}`,
+		},
+		"misspelled-variable": {
+			Template: `{"name": "{{ alert_nme }}"}`,
+			Error:    `unknown alert variable "alert_nme", did you mean "alert_name"?`,
+		},
+		"unclosed-placeholder": {
+			Template: `{"name": "{{ alert_name"}`,
+			Error:    "unclosed placeholder",
+		},
+	}
+	alert, err := sampleAlert(map[string]interface{}{"alert_name": `Suspicious "login"`})
+	assert.NoError(t, err)
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			payload, err := renderPayload(tc.Template, alert)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, payload)
+		})
+	}
+}
+
+func TestSampleAlert(t *testing.T) {
+	alert, err := sampleAlert(map[string]interface{}{"hits": "3"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, alert["hits"])
+	_, err = sampleAlert(map[string]interface{}{"hits": "many"})
+	assert.Error(t, err)
+	_, err = sampleAlert(map[string]interface{}{"unknown": "value"})
+	assert.Error(t, err)
+}
+
+func TestValidatePayloadTemplate(t *testing.T) {
+	assert.False(t, validatePayloadTemplate()(`{"hits": {{ hits }}}`, nil).HasError())
+	assert.True(t, validatePayloadTemplate()(`{"name": {{ alert_name }}}`, nil).HasError())
+}
+
+func TestUpgradeHeadersV0(t *testing.T) {
+	state := map[string]interface{}{
+		"webhook_config": []interface{}{
+			map[string]interface{}{"headers": []interface{}{"Header1Name:Header1Value", "Header2Name: Header2Value"}},
+		},
+	}
+	upgraded, err := upgradeHeadersV0(context.Background(), state, nil)
+	assert.NoError(t, err)
+	headers := upgraded["webhook_config"].([]interface{})[0].(map[string]interface{})["headers"]
+	assert.Equal(t, map[string]interface{}{"Header1Name": "Header1Value", "Header2Name": "Header2Value"}, headers)
+}
+
+func TestResourceV0(t *testing.T) {
+	state := `{"id": "nch-123", "name": "webhook", "enabled": true, "type": "webhook",
+		"webhook_config": [{"headers": ["Header1Name:Header1Value"], "method": "POST", "url": "https://example.com"}]}`
+	_, err := ctyjson.Unmarshal([]byte(state), resourceV0().CoreConfigSchema().ImpliedType())
+	assert.NoError(t, err)
+}
+
+func TestPresetPayloads(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"teams_config": {"url": "https://example.webhook.office.com/webhookb2/test"},
+		"opsgenie_config": {
+			"api_key": "key", "region": "EU", "priority": "P2", "team": "soc", "tags": []interface{}{"meta"},
+		},
+		"servicenow_config": {
+			"instance_url": "https://example.service-now.com/", "username": "user", "password": "password",
+			"table": "incident", "urgency": 1, "impact": 2, "assignment_group": "soc",
+		},
+	}
+	for preset, conf := range cases {
+		t.Run(preset, func(t *testing.T) {
+			d := Resource().TestResourceData()
+			assert.NoError(t, d.Set(preset, []interface{}{conf}))
+			nc := client.NewNotificationChannel(d)
+			if assert.NotNil(t, nc.WebhookConfig) {
+				assert.False(t, validatePayloadTemplate()(nc.WebhookConfig.CustomPayload, nil).HasError())
+				assert.Equal(t, preset, configuredPreset(d))
+			}
+		})
+	}
+	assert.Empty(t, configuredPreset(DataSource().TestResourceData()))
+}
@@ -1,6 +1,8 @@
 package notification_channel
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
 )
@@ -17,7 +19,394 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: secretHashDiff,
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeHeadersV0,
+			},
+			{
+				Version: 1,
+				Type:    resourceV1().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeSecretsV1,
+			},
+		},
+
+		Schema: resourceSchema(),
+	}
+}
+
+func resourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"send_test_on_change": {
+			Description: sendTestDesc,
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"secret_version": {
+			Description: secretVersionDesc,
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"secret_hash": {
+			Description: secretHashDesc,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"email_config": {
+			Type:          schema.TypeList,
+			MaxItems:      1,
+			Optional:      true,
+			ConflictsWith: otherChannelConfigs("email_config"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"recipients": {
+						Description: recipientsDesc,
+						Type:        schema.TypeList,
+						MinItems:    1,
+						MaxItems:    10,
+						Required:    true,
+						Elem: &schema.Schema{
+							Type:             schema.TypeString,
+							ValidateDiagFunc: common.ValidateEmail(),
+						},
+					},
+				},
+			},
+		},
+		"pagerduty_config": {
+			Type:          schema.TypeList,
+			MaxItems:      1,
+			Optional:      true,
+			ConflictsWith: otherChannelConfigs("pagerduty_config"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"api_key": {
+						Description:  pagerDutyApiKeyDesc,
+						Type:         schema.TypeString,
+						Optional:     true,
+						Sensitive:    true,
+						StateFunc:    hashSecret,
+						ExactlyOneOf: []string{"pagerduty_config.0.api_key", "pagerduty_config.0.api_key_file"},
+					},
+					"api_key_file": {
+						Description: pagerDutyApiKeyFileDesc,
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+				},
+			},
+		},
+		"slack_config": {
+			Type:          schema.TypeList,
+			MaxItems:      1,
+			Optional:      true,
+			ConflictsWith: otherChannelConfigs("slack_config"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"channel": {
+						Description: slackChannelDesc,
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"url": {
+						Description:      slackURLSecretDesc,
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: common.ValidateURL(),
+						Sensitive:        true,
+						StateFunc:        hashSecret,
+						ExactlyOneOf:     []string{"slack_config.0.url", "slack_config.0.url_file"},
+					},
+					"url_file": {
+						Description: slackURLFileDesc,
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+				},
+			},
+		},
+		"webhook_config": {
+			Description:   webHookConf,
+			Type:          schema.TypeList,
+			MaxItems:      1,
+			Optional:      true,
+			ConflictsWith: otherChannelConfigs("webhook_config"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"auth": {
+						Type:     schema.TypeList,
+						MaxItems: 1,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"oauth2_config": {
+									Required: true,
+									Type:     schema.TypeList,
+									MinItems: 1,
+									MaxItems: 1,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"client_id": {
+												Type:      schema.TypeString,
+												Required:  true,
+												Sensitive: true,
+											},
+											"client_secret": {
+												Description: clientSecretDesc,
+												Type:        schema.TypeString,
+												Optional:    true,
+												Sensitive:   true,
+												StateFunc:   hashSecret,
+												ExactlyOneOf: []string{
+													"webhook_config.0.auth.0.oauth2_config.0.client_secret",
+													"webhook_config.0.auth.0.oauth2_config.0.client_secret_file",
+												},
+											},
+											"client_secret_file": {
+												Description: clientSecretFileDesc,
+												Type:        schema.TypeString,
+												Optional:    true,
+											},
+											"token_url": {
+												Type:             schema.TypeString,
+												Required:         true,
+												ValidateDiagFunc: common.ValidateURL(),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					"custom_payload": {
+						Description:      payloadDesc,
+						Type:             schema.TypeString,
+						Optional:         true,
+						Sensitive:        true,
+						ValidateDiagFunc: validatePayloadTemplate(),
+					},
+					"headers": {
+						Description:      headersDesc,
+						Type:             schema.TypeMap,
+						Optional:         true,
+						Sensitive:        true,
+						ValidateDiagFunc: common.ValidateHttpHeaders(),
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"method": {
+						Description:      methodDesc,
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: common.ValidateStringENUM("POST", "PUT"),
+					},
+					"url": {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: common.ValidateURL(),
+					},
+				},
+			},
+		},
+		"teams_config": {
+			Description:   teamsConfDesc,
+			Type:          schema.TypeList,
+			MaxItems:      1,
+			Optional:      true,
+			ConflictsWith: otherChannelConfigs("teams_config"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"url": {
+						Description:      teamsURLDesc,
+						Type:             schema.TypeString,
+						Optional:         true,
+						Sensitive:        true,
+						StateFunc:        hashSecret,
+						ValidateDiagFunc: common.ValidateURL(),
+						ExactlyOneOf:     []string{"teams_config.0.url", "teams_config.0.url_file"},
+					},
+					"url_file": {
+						Description: teamsURLFileDesc,
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+				},
+			},
+		},
+		"opsgenie_config": {
+			Description:   opsgenieConfDesc,
+			Type:          schema.TypeList,
+			MaxItems:      1,
+			Optional:      true,
+			ConflictsWith: otherChannelConfigs("opsgenie_config"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"api_key": {
+						Description:  opsgenieApiKeyDesc,
+						Type:         schema.TypeString,
+						Optional:     true,
+						Sensitive:    true,
+						StateFunc:    hashSecret,
+						ExactlyOneOf: []string{"opsgenie_config.0.api_key", "opsgenie_config.0.api_key_file"},
+					},
+					"api_key_file": {
+						Description: opsgenieApiKeyFileDesc,
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"region": {
+						Description:      opsgenieRegionDesc,
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "US",
+						ValidateDiagFunc: common.ValidateStringENUM("US", "EU"),
+					},
+					"priority": {
+						Description:      opsgeniePriorityDesc,
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "P3",
+						ValidateDiagFunc: common.ValidateStringENUM("P1", "P2", "P3", "P4", "P5"),
+					},
+					"team": {
+						Description: opsgenieTeamDesc,
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"tags": {
+						Description: opsgenieTagsDesc,
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"servicenow_config": {
+			Description:   serviceNowConfDesc,
+			Type:          schema.TypeList,
+			MaxItems:      1,
+			Optional:      true,
+			ConflictsWith: otherChannelConfigs("servicenow_config"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"instance_url": {
+						Description:      serviceNowInstanceDesc,
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: common.ValidateURL(),
+					},
+					"username": {
+						Description: serviceNowUsernameDesc,
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"password": {
+						Description:  serviceNowPasswordDesc,
+						Type:         schema.TypeString,
+						Optional:     true,
+						Sensitive:    true,
+						StateFunc:    hashSecret,
+						ExactlyOneOf: []string{"servicenow_config.0.password", "servicenow_config.0.password_file"},
+					},
+					"password_file": {
+						Description: serviceNowPasswordFileDesc,
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"table": {
+						Description:      serviceNowTableDesc,
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "incident",
+						ValidateDiagFunc: common.ValidatePattern(serviceNowTablePattern),
+					},
+					"urgency": {
+						Description:      serviceNowUrgencyDesc,
+						Type:             schema.TypeInt,
+						Optional:         true,
+						Default:          2,
+						ValidateDiagFunc: common.ValidateIntRange(1, 3),
+					},
+					"impact": {
+						Description:      serviceNowImpactDesc,
+						Type:             schema.TypeInt,
+						Optional:         true,
+						Default:          2,
+						ValidateDiagFunc: common.ValidateIntRange(1, 3),
+					},
+					"assignment_group": {
+						Description: serviceNowGroupDesc,
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+				},
+			},
+		},
+		"syslog_config": {
+			Description:   syslogConfDesc,
+			Type:          schema.TypeList,
+			MaxItems:      1,
+			Optional:      true,
+			ConflictsWith: otherChannelConfigs("syslog_config"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"host": {
+						Description:      syslogHostDesc,
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: common.ValidateHostnameOrIPV4(),
+					},
+					"port": {
+						Description:      syslogPortDesc,
+						Type:             schema.TypeInt,
+						Optional:         true,
+						Default:          514,
+						ValidateDiagFunc: common.ValidateIntRange(1, 65535),
+					},
+					"proto": {
+						Description:      syslogProtoDesc,
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "tcp",
+						ValidateDiagFunc: common.ValidateStringENUM("tcp", "udp"),
+					},
+				},
+			},
+		},
+	}
+}
 
+// resourceV0 is the schema of the resource before the webhook headers became a map.
+// It is a frozen copy, so later changes of the schema don't change how version 0 states are read.
+func resourceV0() *schema.Resource {
+	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -34,77 +423,65 @@ func Resource() *schema.Resource {
 			"enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  true,
 			},
 			"type": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"org_shortname": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"email_config": {
-				Type:          schema.TypeList,
-				MaxItems:      1,
-				Optional:      true,
-				ConflictsWith: []string{"pagerduty_config", "slack_config", "webhook_config"},
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"recipients": {
-							Description: recipientsDesc,
-							Type:        schema.TypeList,
-							MinItems:    1,
-							MaxItems:    10,
-							Required:    true,
-							Elem: &schema.Schema{
-								Type:             schema.TypeString,
-								ValidateDiagFunc: common.ValidateEmail(),
-							},
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
 					},
 				},
 			},
 			"pagerduty_config": {
-				Type:          schema.TypeList,
-				MaxItems:      1,
-				Optional:      true,
-				ConflictsWith: []string{"email_config", "slack_config", "webhook_config"},
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"api_key": {
-							Description: pagerDutyApiKeyConfDesc,
-							Type:        schema.TypeString,
-							Required:    true,
-							Sensitive:   true,
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
 						},
 					},
 				},
 			},
 			"slack_config": {
-				Type:          schema.TypeList,
-				MaxItems:      1,
-				Optional:      true,
-				ConflictsWith: []string{"email_config", "pagerduty_config", "webhook_config"},
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"channel": {
-							Description: slackChannelDesc,
-							Type:        schema.TypeString,
-							Optional:    true,
+							Type:     schema.TypeString,
+							Optional: true,
 						},
 						"url": {
-							Description:      slackURLDesc,
-							Type:             schema.TypeString,
-							Required:         true,
-							ValidateDiagFunc: common.ValidateURL(),
-							Sensitive:        true,
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
 						},
 					},
 				},
 			},
 			"webhook_config": {
-				Description:   webHookConf,
-				Type:          schema.TypeList,
-				MaxItems:      1,
-				Optional:      true,
-				ConflictsWith: []string{"email_config", "pagerduty_config", "slack_config"},
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"auth": {
@@ -113,9 +490,8 @@ func Resource() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"oauth2_config": {
-										Required: true,
 										Type:     schema.TypeList,
-										MinItems: 1,
+										Required: true,
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
 												"client_id": {
@@ -124,15 +500,13 @@ func Resource() *schema.Resource {
 													Sensitive: true,
 												},
 												"client_secret": {
-													Description: sensitiveDataWarning,
-													Type:        schema.TypeString,
-													Required:    true,
-													Sensitive:   true,
+													Type:      schema.TypeString,
+													Required:  true,
+													Sensitive: true,
 												},
 												"token_url": {
-													Type:             schema.TypeString,
-													Required:         true,
-													ValidateDiagFunc: common.ValidateURL(),
+													Type:     schema.TypeString,
+													Required: true,
 												},
 											},
 										},
@@ -141,32 +515,25 @@ func Resource() *schema.Resource {
 							},
 						},
 						"custom_payload": {
-							Description:      payloadDesc,
-							Type:             schema.TypeString,
-							Optional:         true,
-							Sensitive:        true,
-							ValidateDiagFunc: common.ValidateJson(),
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
 						},
 						"headers": {
 							Type:     schema.TypeList,
-							MinItems: 1,
 							Optional: true,
 							Elem: &schema.Schema{
-								Type:             schema.TypeString,
-								ValidateDiagFunc: common.ValidatePattern(common.HttpHeaderPattern),
-								Sensitive:        true,
+								Type:      schema.TypeString,
+								Sensitive: true,
 							},
 						},
 						"method": {
-							Description:      methodDesc,
-							Type:             schema.TypeString,
-							Required:         true,
-							ValidateDiagFunc: common.ValidateStringENUM("POST", "PUT"),
+							Type:     schema.TypeString,
+							Required: true,
 						},
 						"url": {
-							Type:             schema.TypeString,
-							Required:         true,
-							ValidateDiagFunc: common.ValidateURL(),
+							Type:     schema.TypeString,
+							Required: true,
 						},
 					},
 				},
@@ -174,3 +541,31 @@ func Resource() *schema.Resource {
 		},
 	}
 }
+
+// resourceV1 is the schema of the resource before only hashes of the secrets were stored in the state.
+// Attributes added since are read as null, so the current schema is used as is.
+func resourceV1() *schema.Resource {
+	return &schema.Resource{Schema: resourceSchema()}
+}
+
+func upgradeHeadersV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	webhookConfig, ok := rawState["webhook_config"].([]interface{})
+	if !ok || len(webhookConfig) == 0 {
+		return rawState, nil
+	}
+	conf, ok := webhookConfig[0].(map[string]interface{})
+	if !ok {
+		return rawState, nil
+	}
+	headers, _ := conf["headers"].([]interface{})
+	headerStrings := make([]string, len(headers))
+	for i, header := range headers {
+		headerStrings[i] = header.(string)
+	}
+	headerMap := make(map[string]interface{}, len(headers))
+	for name, value := range headersToMap(headerStrings) {
+		headerMap[name] = value
+	}
+	conf["headers"] = headerMap
+	return rawState, nil
+}
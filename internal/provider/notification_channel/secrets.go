@@ -0,0 +1,205 @@
+package notification_channel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"os"
+	"sort"
+	"strings"
+)
+
+// secretAttributes are the paths of the secrets in the channel configuration blocks.
+// Only hashes of the secrets are stored in the state, and each secret can be read from the file at its "_file" attribute.
+var secretAttributes = [][]string{
+	{"pagerduty_config", "api_key"},
+	{"slack_config", "url"},
+	{"webhook_config", "auth", "oauth2_config", "client_secret"},
+	{"teams_config", "url"},
+	{"opsgenie_config", "api_key"},
+	{"servicenow_config", "password"},
+}
+
+// channelSecrets maps the state path of every configured secret to its value.
+type channelSecrets map[string]string
+
+// secretPath returns the state path of the secret in the first block of every level.
+func secretPath(attr []string) string {
+	return strings.Join(attr, ".0.")
+}
+
+func secretFileAttr(attr []string) []string {
+	res := append([]string(nil), attr...)
+	res[len(res)-1] += "_file"
+	return res
+}
+
+// hashSecret is the StateFunc of the secret attributes, which stores a SHA-256 hash of the secret instead of its value.
+func hashSecret(v interface{}) string {
+	secret, _ := v.(string)
+	if secret == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// hash returns a hash of all the secrets, which changes whenever one of them is rotated.
+func (s channelSecrets) hash() string {
+	if len(s) == 0 {
+		return ""
+	}
+	paths := make([]string, 0, len(s))
+	for path := range s {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var b strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&b, "%s=%s\n", path, s[path])
+	}
+	return hashSecret(b.String())
+}
+
+// configString returns the string attribute at the path of the raw config, taking the first block of every level,
+// and whether its value is known.
+func configString(raw cty.Value, attr []string) (string, bool) {
+	v := raw
+	for i, step := range attr {
+		if !v.IsKnown() {
+			return "", false
+		}
+		if v.IsNull() {
+			return "", true
+		}
+		if i > 0 {
+			if v.LengthInt() == 0 {
+				return "", true
+			}
+			v = v.Index(cty.NumberIntVal(0))
+		}
+		v = v.GetAttr(step)
+	}
+	if !v.IsKnown() {
+		return "", false
+	}
+	if v.IsNull() {
+		return "", true
+	}
+	return v.AsString(), true
+}
+
+// configSecrets reads the secrets from the raw config, since the planned values of the secret attributes are hashes.
+// Secrets configured with a "_file" attribute are read from the file, without its trailing newline.
+// It returns false if any of the secrets is not known yet.
+func configSecrets(raw cty.Value) (channelSecrets, bool, error) {
+	res := channelSecrets{}
+	for _, attr := range secretAttributes {
+		secret, known := configString(raw, attr)
+		if !known {
+			return nil, false, nil
+		}
+		path, known := configString(raw, secretFileAttr(attr))
+		if !known {
+			return nil, false, nil
+		}
+		if path != "" {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, false, fmt.Errorf("could not read %s: %v", secretPath(secretFileAttr(attr)), err)
+			}
+			secret = strings.TrimRight(string(content), "\r\n")
+			if secret == "" {
+				return nil, false, fmt.Errorf("%s: file %s is empty", secretPath(secretFileAttr(attr)), path)
+			}
+		}
+		if secret != "" {
+			res[secretPath(attr)] = secret
+		}
+	}
+	return res, true, nil
+}
+
+// secretHashDiff plans a new secret_hash whenever a secret changes, including secrets read from files,
+// whose attributes only hold a path.
+func secretHashDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	secrets, known, err := configSecrets(d.GetRawConfig())
+	if err != nil {
+		return err
+	}
+	if !known {
+		return d.SetNewComputed("secret_hash")
+	}
+	if hash := secrets.hash(); d.Id() == "" || hash != d.Get("secret_hash").(string) {
+		return d.SetNew("secret_hash", hash)
+	}
+	return nil
+}
+
+// setSecrets replaces the hashes read from the resource data with the secrets.
+// Secrets missing from secrets are omitted from the request, so the API keeps their current values.
+func setSecrets(d *schema.ResourceData, nc *client.NotificationChannel, secrets channelSecrets) {
+	if nc.PagerdutyConfig != nil {
+		nc.PagerdutyConfig.ApiKey = secrets[secretPath(secretAttributes[0])]
+	}
+	if nc.SlackConfig != nil {
+		nc.SlackConfig.Url = secrets[secretPath(secretAttributes[1])]
+	}
+	if nc.WebhookConfig == nil {
+		return
+	}
+	if nc.WebhookConfig.Auth != nil {
+		nc.WebhookConfig.Auth.Oauth2Config.ClientSecret = secrets[secretPath(secretAttributes[2])]
+	}
+	switch configuredPreset(d) {
+	case "teams_config":
+		nc.WebhookConfig.Url = secrets[secretPath(secretAttributes[3])]
+	case "opsgenie_config":
+		nc.WebhookConfig.SetAuthHeader(client.OpsgenieAuthHeader(secrets[secretPath(secretAttributes[4])]))
+	case "servicenow_config":
+		username := d.Get("servicenow_config.0.username").(string)
+		password := secrets[secretPath(secretAttributes[5])]
+		nc.WebhookConfig.SetAuthHeader(client.ServiceNowAuthHeader(username, password))
+	}
+}
+
+// changedSecrets returns the secrets to send on update, only if they were rotated or secret_version was changed.
+// Presets are always sent with their secrets, since they are embedded in the url and headers of the webhook,
+// which are replaced as a whole.
+func changedSecrets(d *schema.ResourceData) (channelSecrets, error) {
+	if !d.HasChanges("secret_hash", "secret_version") && configuredPreset(d) == "" {
+		return channelSecrets{}, nil
+	}
+	secrets, _, err := configSecrets(d.GetRawConfig())
+	return secrets, err
+}
+
+// upgradeSecretsV1 replaces the secrets stored in the state with their hashes.
+func upgradeSecretsV1(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	secrets := channelSecrets{}
+	for _, attr := range secretAttributes {
+		block := rawState
+		for _, step := range attr[:len(attr)-1] {
+			blocks, _ := block[step].([]interface{})
+			if len(blocks) == 0 {
+				block = nil
+				break
+			}
+			block, _ = blocks[0].(map[string]interface{})
+			if block == nil {
+				break
+			}
+		}
+		key := attr[len(attr)-1]
+		if secret, _ := block[key].(string); secret != "" {
+			secrets[secretPath(attr)] = secret
+			block[key] = hashSecret(secret)
+		}
+	}
+	rawState["secret_hash"] = secrets.hash()
+	return rawState, nil
+}
@@ -2,7 +2,10 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/ssl_bypass_rule"
+	"reflect"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -10,6 +13,7 @@ import (
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/aac_rule"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/access_control"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/alert"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/alert_pack"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/app"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/catalog_app"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/certificate"
@@ -28,16 +32,23 @@ import (
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/idp"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/ip_network"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/location"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/locations"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/log_streaming_access_bridge"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/maintenance_window"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/mapped_domain"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/mapped_host"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/metaport"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/metaport_cluster"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/metaport_cluster_mapped_elements_attachment"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/metaport_enrollment"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/metaport_failover"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/metaport_failover_switch"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/metaport_mapped_elements_attachment"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/metaport_status"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/network_element"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/network_element_alias"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/network_element_mapped_domains"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/network_element_mapped_hosts"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/notification_channel"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/pac_file"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/policy"
@@ -45,9 +56,11 @@ import (
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/protocol_group"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/proxy_port_range"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/role"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/routing_analysis"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/routing_group"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/routing_group_mapped_elements_attachment"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/scan_rule"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/sub_org"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/tenant_restriction"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/threat_category"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/time_frame"
@@ -57,6 +70,7 @@ import (
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/user"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/user_roles_attachment"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/user_settings"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/zone_records"
 )
 
 func New(version string) func() *schema.Provider {
@@ -90,6 +104,59 @@ func New(version string) func() *schema.Provider {
 					Optional:         true,
 					ValidateDiagFunc: common.ValidateStringENUM("eu", "us"),
 				},
+				"access_token": {
+					Description: "A pre-issued access token, used instead of requesting one with an API key. " +
+						"The token is scoped to a single org, so resources with an org_shortname of another org can't be managed with it. " +
+						"Alternatively, use the `PFPTMETA_ACCESS_TOKEN` env variable",
+					Type:          schema.TypeString,
+					DefaultFunc:   schema.EnvDefaultFunc("PFPTMETA_ACCESS_TOKEN", nil),
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{"access_token_file"},
+				},
+				"access_token_file": {
+					Description: "Path to a file containing a pre-issued access token. The file is re-read periodically, " +
+						"so tokens rotated by an external agent are picked up. Like access_token, it can't manage resources of other orgs. Alternatively, use the `PFPTMETA_ACCESS_TOKEN_FILE` env variable",
+					Type:          schema.TypeString,
+					DefaultFunc:   schema.EnvDefaultFunc("PFPTMETA_ACCESS_TOKEN_FILE", nil),
+					Optional:      true,
+					ConflictsWith: []string{"access_token"},
+				},
+				"credential_process": {
+					Description: "A command that prints the credentials to stdout in the credentials file json format. " +
+						"Alternatively, use the `PFPTMETA_CREDENTIAL_PROCESS` env variable",
+					Type:        schema.TypeString,
+					DefaultFunc: schema.EnvDefaultFunc("PFPTMETA_CREDENTIAL_PROCESS", nil),
+					Optional:    true,
+				},
+				"profile": {
+					Description: "The profile to use from the credentials file, defaults to `default`. " +
+						"Alternatively, use the `PFPTMETA_PROFILE` env variable",
+					Type:        schema.TypeString,
+					DefaultFunc: schema.EnvDefaultFunc("PFPTMETA_PROFILE", nil),
+					Optional:    true,
+				},
+				"log_request_bodies": {
+					Description: "Include the request and response bodies in the API requests logged with `TF_LOG=DEBUG`. " +
+						"Secrets such as API secrets, tokens and API keys are redacted. Alternatively, use the `PFPTMETA_LOG_REQUEST_BODIES` env variable",
+					Type:        schema.TypeBool,
+					DefaultFunc: schema.EnvDefaultFunc("PFPTMETA_LOG_REQUEST_BODIES", false),
+					Optional:    true,
+				},
+				"har_file": {
+					Description: "Path of an HTTP Archive (HAR) file to which all the API requests are written, with secrets redacted. " +
+						"Useful for sending reproducible traces to support. Alternatively, use the `PFPTMETA_HAR_FILE` env variable",
+					Type:        schema.TypeString,
+					DefaultFunc: schema.EnvDefaultFunc("PFPTMETA_HAR_FILE", nil),
+					Optional:    true,
+				},
+				"credentials_file": {
+					Description: "Path to the credentials file, defaults to `~/.pfptmeta/credentials.json`. " +
+						"Alternatively, use the `PFPTMETA_CREDENTIALS_FILE` env variable",
+					Type:        schema.TypeString,
+					DefaultFunc: schema.EnvDefaultFunc("PFPTMETA_CREDENTIALS_FILE", nil),
+					Optional:    true,
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
 				"pfptmeta_network_element_alias":       network_element_alias.DataSource(),
@@ -101,15 +168,20 @@ func New(version string) func() *schema.Provider {
 				"pfptmeta_metaport":                    metaport.DataSource(),
 				"pfptmeta_metaport_cluster":            metaport_cluster.DataSource(),
 				"pfptmeta_metaport_failover":           metaport_failover.DataSource(),
+				"pfptmeta_metaport_status":             metaport_status.DataSource(),
 				"pfptmeta_enterprise_dns":              enterprise_dns.DataSource(),
 				"pfptmeta_protocol_group":              protocol_group.DataSource(),
 				"pfptmeta_role":                        role.DataSource(),
 				"pfptmeta_group":                       group.DataSource(),
 				"pfptmeta_user":                        user.DataSource(),
 				"pfptmeta_notification_channel":        notification_channel.DataSource(),
+				"pfptmeta_notification_preview":        notification_channel.PreviewDataSource(),
+				"pfptmeta_routing_analysis":            routing_analysis.DataSource(),
+				"pfptmeta_zone_records":                zone_records.DataSource(),
 				"pfptmeta_routing_group":               routing_group.DataSource(),
 				"pfptmeta_policy":                      policy.DataSource(),
 				"pfptmeta_location":                    location.DataSource(),
+				"pfptmeta_locations":                   locations.DataSource(),
 				"pfptmeta_egress_route":                egress_route.DataSource(),
 				"pfptmeta_alert":                       alert.DataSource(),
 				"pfptmeta_certificate":                 certificate.DataSource(),
@@ -123,6 +195,8 @@ func New(version string) func() *schema.Provider {
 				"pfptmeta_aac_rule":                    aac_rule.DataSource(),
 				"pfptmeta_app":                         app.DataSource(),
 				"pfptmeta_idp":                         idp.DataSource(),
+				"pfptmeta_sub_org":                     sub_org.DataSource(),
+				"pfptmeta_sub_orgs":                    sub_org.ListDataSource(),
 				//	SWG
 				"pfptmeta_content_category":   content_category.DataSource(),
 				"pfptmeta_ip_network":         ip_network.DataSource(),
@@ -145,11 +219,15 @@ func New(version string) func() *schema.Provider {
 				"pfptmeta_device_alias":                                device_alias.Resource(),
 				"pfptmeta_mapped_domain":                               mapped_domain.Resource(),
 				"pfptmeta_mapped_host":                                 mapped_host.Resource(),
+				"pfptmeta_network_element_mapped_domains":              network_element_mapped_domains.Resource(),
+				"pfptmeta_network_element_mapped_hosts":                network_element_mapped_hosts.Resource(),
 				"pfptmeta_metaport":                                    metaport.Resource(),
 				"pfptmeta_metaport_mapped_elements_attachment":         metaport_mapped_elements_attachment.Resource(),
 				"pfptmeta_metaport_cluster_mapped_elements_attachment": metaport_cluster_mapped_elements_attachment.Resource(),
 				"pfptmeta_metaport_cluster":                            metaport_cluster.Resource(),
 				"pfptmeta_metaport_failover":                           metaport_failover.Resource(),
+				"pfptmeta_metaport_failover_switch":                    metaport_failover_switch.Resource(),
+				"pfptmeta_metaport_enrollment":                         metaport_enrollment.Resource(),
 				"pfptmeta_enterprise_dns":                              enterprise_dns.Resource(),
 				"pfptmeta_protocol_group":                              protocol_group.Resource(),
 				"pfptmeta_role":                                        role.Resource(),
@@ -163,6 +241,8 @@ func New(version string) func() *schema.Provider {
 				"pfptmeta_notification_channel":                        notification_channel.Resource(),
 				"pfptmeta_egress_route":                                egress_route.Resource(),
 				"pfptmeta_alert":                                       alert.Resource(),
+				"pfptmeta_alert_pack":                                  alert_pack.Resource(),
+				"pfptmeta_maintenance_window":                          maintenance_window.Resource(),
 				"pfptmeta_certificate":                                 certificate.Resource(),
 				"pfptmeta_easylink":                                    easylink.Resource(),
 				"pfptmeta_posture_check":                               posture_check.Resource(),
@@ -175,6 +255,7 @@ func New(version string) func() *schema.Provider {
 				"pfptmeta_aac_rule":                                    aac_rule.Resource(),
 				"pfptmeta_app":                                         app.Resource(),
 				"pfptmeta_idp":                                         idp.Resource(),
+				"pfptmeta_sub_org":                                     sub_org.Resource(),
 				//	SWG
 				"pfptmeta_content_category":   content_category.Resource(),
 				"pfptmeta_ip_network":         ip_network.Resource(),
@@ -190,6 +271,16 @@ func New(version string) func() *schema.Provider {
 				"pfptmeta_scan_rule":          scan_rule.Resource(),
 			},
 		}
+		for _, r := range p.ResourcesMap {
+			withOrgOverride(r, true)
+			withAttributeDiagnostics(r)
+		}
+		for name, d := range p.DataSourcesMap {
+			if !offlineDataSources[name] {
+				withOrgOverride(d, false)
+			}
+			withAttributeDiagnostics(d)
+		}
 		p.ConfigureContextFunc = configure(version, p)
 		return p
 	}
@@ -198,10 +289,163 @@ func New(version string) func() *schema.Provider {
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		userAgent := p.UserAgent("terraform-provider-pfptmeta", version)
-		c, err := client.NewClient(ctx, d, userAgent)
+		c, err := client.NewClient(ctx, d, userAgent, version)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 		return c, nil
 	}
 }
+
+// offlineDataSources are the data sources which don't call the API, so they don't get the org_shortname attribute.
+var offlineDataSources = map[string]bool{
+	"pfptmeta_notification_preview": true,
+	"pfptmeta_zone_records":         true,
+}
+
+type operation = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+func wrapOperations(r *schema.Resource, wrap func(operation) operation) {
+	if r.CreateContext != nil {
+		r.CreateContext = wrap(r.CreateContext)
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = wrap(r.ReadContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = wrap(r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = wrap(r.DeleteContext)
+	}
+}
+
+// splitOrgImportID splits an import ID of the form <org_shortname>:<id>.
+func splitOrgImportID(importID string) (string, string, bool) {
+	org, id, found := strings.Cut(importID, ":")
+	if !found || id == "" || !common.OrgShortnamePattern.MatchString(org) {
+		return "", "", false
+	}
+	return org, id, true
+}
+
+// isPassthroughImporter returns whether the importer imports the ID as is, so the ID can be prefixed with an org.
+// Custom importers parse their own import IDs, which may contain colons themselves.
+func isPassthroughImporter(importer *schema.ResourceImporter) bool {
+	return importer != nil && importer.StateContext != nil &&
+		reflect.ValueOf(importer.StateContext).Pointer() == reflect.ValueOf(schema.ImportStatePassthroughContext).Pointer()
+}
+
+// withOrgImport lets resources of other orgs be imported with an ID of the form <org_shortname>:<id>.
+// The org is set as the org_shortname, so the read which follows the import is scoped to it.
+func withOrgImport(importer *schema.ResourceImporter) {
+	importState := importer.StateContext
+	importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		if org, id, ok := splitOrgImportID(d.Id()); ok {
+			orgClient, err := meta.(*client.Client).ForOrg(org)
+			if err != nil {
+				return nil, err
+			}
+			if err = d.Set("org_shortname", org); err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+			meta = orgClient
+		}
+		return importState(ctx, d, meta)
+	}
+}
+
+// withOrgOverride adds the org_shortname attribute to r.
+// When it is set, all of r's operations and its CustomizeDiff are executed with a client scoped to that org
+// instead of the provider's org. When the org isn't known during plan, CustomizeDiff gets no client.
+// Resources of other orgs which import their ID as is are imported with an ID of the form <org_shortname>:<id>.
+func withOrgOverride(r *schema.Resource, isResource bool) {
+	desc := "The shortname of the org to read from, i.e. a sub org of the provider's org."
+	if isResource {
+		desc = "The shortname of the org in which to manage this resource, i.e. a sub org of the provider's org."
+	}
+	if isResource && isPassthroughImporter(r.Importer) {
+		desc += " Resources of a sub org are imported with an ID of the form `<org_shortname>:<id>`."
+	}
+	r.Schema["org_shortname"] = &schema.Schema{
+		Description:      desc + " Defaults to the org the provider is configured with.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         isResource,
+		ValidateDiagFunc: common.ValidatePattern(common.OrgShortnamePattern),
+	}
+	if isPassthroughImporter(r.Importer) {
+		withOrgImport(r.Importer)
+	}
+	wrapOperations(r, func(f operation) operation {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if org, ok := d.GetOk("org_shortname"); ok {
+				orgClient, err := meta.(*client.Client).ForOrg(org.(string))
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				meta = orgClient
+			}
+			return f(ctx, d, meta)
+		}
+	})
+	if r.CustomizeDiff != nil {
+		customizeDiff := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			c, ok := meta.(*client.Client)
+			switch {
+			case !ok:
+			case !d.NewValueKnown("org_shortname"):
+				// The org isn't known yet, so checks which call the API are skipped
+				meta = nil
+			default:
+				orgClient, err := c.ForOrg(d.Get("org_shortname").(string))
+				if err != nil {
+					return err
+				}
+				meta = orgClient
+			}
+			return customizeDiff(ctx, d, meta)
+		}
+	}
+}
+
+// withAttributeDiagnostics makes the errors of r's operations point at the attributes the API reported as invalid,
+// so terraform can show the offending line in the configuration.
+func withAttributeDiagnostics(r *schema.Resource) {
+	wrapOperations(r, func(f operation) operation {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			ctx, recorder := client.WithErrorRecorder(ctx)
+			return attributeDiagnostics(f(ctx, d, meta), recorder, r.Schema)
+		}
+	})
+}
+
+func attributeDiagnostics(diags diag.Diagnostics, recorder *client.ErrorRecorder, s map[string]*schema.Schema) diag.Diagnostics {
+	var res diag.Diagnostics
+	for _, d := range diags {
+		var errResponse *client.ErrorResponse
+		if d.Severity == diag.Error && d.AttributePath == nil {
+			errResponse = recorder.Find(d.Summary)
+		}
+		if errResponse == nil || len(errResponse.FieldErrors) == 0 {
+			res = append(res, d)
+			continue
+		}
+		for _, fieldErr := range errResponse.FieldErrors {
+			path := common.AttributePath(s, fieldErr.Field)
+			summary := fieldErr.Message
+			if len(path) == 0 {
+				summary = fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Message)
+			}
+			res = append(res, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       summary,
+				Detail:        d.Summary,
+				AttributePath: path,
+			})
+		}
+	}
+	return res
+}
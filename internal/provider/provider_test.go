@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
@@ -22,6 +24,20 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestOrgOverride(t *testing.T) {
+	p := New("dev")()
+	for name, r := range p.ResourcesMap {
+		s, ok := r.Schema["org_shortname"]
+		if assert.True(t, ok, "%s is missing org_shortname", name) {
+			assert.True(t, s.ForceNew, "%s org_shortname should force a new resource", name)
+		}
+	}
+	for name, d := range p.DataSourcesMap {
+		_, ok := d.Schema["org_shortname"]
+		assert.Equal(t, !offlineDataSources[name], ok, "%s should have org_shortname only if it calls the API", name)
+	}
+}
+
 func TestConfigure(t *testing.T) {
 	server := configureAuthServer(t)
 	setEnvVar(t, "PFPTMETA_BASE_URL", server.URL)
@@ -90,6 +106,109 @@ func TestConfigureFromFile(t *testing.T) {
 	}
 }
 
+func TestConfigureFromProfile(t *testing.T) {
+	server := configureAuthServer(t)
+	setEnvVar(t, "PFPTMETA_BASE_URL", server.URL)
+	defer os.Unsetenv("PFPTMETA_BASE_URL")
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	profiles := map[string]*client.Config{
+		"default": {APIKey: "api-key", APISecret: "api-secret", OrgShortname: "org"},
+		"prod-eu": {APIKey: "api-key-eu", APISecret: "api-secret-eu", OrgShortname: "org-eu"},
+		"vault":   {CredentialProcess: `echo '{"api_key": "key", "api_secret": "secret", "org_shortname": "org"}'`},
+	}
+	configBytes, _ := json.Marshal(profiles)
+	err := os.WriteFile(path, configBytes, 0600)
+	if err != nil {
+		t.Fatalf("could not write config file: %v", err)
+	}
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		ExpectError bool
+	}{
+		"default-profile": {
+			Config: map[string]interface{}{"credentials_file": path},
+		},
+		"named-profile": {
+			Config: map[string]interface{}{"credentials_file": path, "profile": "prod-eu"},
+		},
+		"profile-with-credential-process": {
+			Config: map[string]interface{}{"credentials_file": path, "profile": "vault"},
+		},
+		"missing-profile": {
+			Config:      map[string]interface{}{"credentials_file": path, "profile": "missing"},
+			ExpectError: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := terraform.NewResourceConfigRaw(tc.Config)
+			diags := New("dev")().Configure(context.Background(), c)
+			assert.Equal(t, tc.ExpectError, diags.HasError(), "%+v", diags)
+		})
+	}
+}
+
+func TestConfigureWithCredentialProcess(t *testing.T) {
+	server := configureAuthServer(t)
+	setEnvVar(t, "PFPTMETA_BASE_URL", server.URL)
+	defer os.Unsetenv("PFPTMETA_BASE_URL")
+	cases := map[string]struct {
+		Process     string
+		ExpectError bool
+	}{
+		"valid-output": {
+			Process: `echo '{"api_key": "key", "api_secret": "secret", "org_shortname": "org"}'`,
+		},
+		"missing-secret": {
+			Process:     `echo '{"api_key": "key", "org_shortname": "org"}'`,
+			ExpectError: true,
+		},
+		"failing-process": {
+			Process:     "exit 1",
+			ExpectError: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := terraform.NewResourceConfigRaw(map[string]interface{}{"credential_process": tc.Process})
+			diags := New("dev")().Configure(context.Background(), c)
+			assert.Equal(t, tc.ExpectError, diags.HasError(), "%+v", diags)
+		})
+	}
+}
+
+func TestConfigureWithAccessToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	err := os.WriteFile(tokenFile, []byte("token-from-file\n"), 0600)
+	if err != nil {
+		t.Fatalf("could not write token file: %v", err)
+	}
+	cases := map[string]struct {
+		Config   map[string]interface{}
+		Expected string
+	}{
+		"access-token": {
+			Config:   map[string]interface{}{"access_token": "token-from-conf"},
+			Expected: "token-from-conf",
+		},
+		"access-token-file": {
+			Config:   map[string]interface{}{"access_token_file": tokenFile},
+			Expected: "token-from-file",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := New("dev")()
+			c := terraform.NewResourceConfigRaw(tc.Config)
+			diags := p.Configure(context.Background(), c)
+			if diags.HasError() {
+				t.Fatalf("%s failed: %+v", name, diags[0])
+			}
+			assert.Equal(t, tc.Expected, p.Meta().(*client.Client).Token.Token)
+		})
+	}
+}
+
 func configureAuthServer(t *testing.T) *httptest.Server {
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() == "/v1/oauth/token" {
@@ -124,3 +243,123 @@ func setEnvVar(t *testing.T, key, value string) {
 		t.Fatalf("Could not set %s env variable to %s: %v", key, value, err)
 	}
 }
+
+func TestAttributeDiagnostics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status": 400, "title": "Bad Request", "detail": "invalid request",
+			"errors": [{"field": "mapped_subnets[1]", "message": "invalid network"}, {"field": "unknown", "message": "invalid"}]}`))
+	}))
+	defer server.Close()
+	c := &client.Client{
+		HTTP:        retryablehttp.NewClient(),
+		BaseURL:     server.URL,
+		Credentials: &client.Credentials{AccessToken: "token"},
+	}
+	r := New("dev")().ResourcesMap["pfptmeta_network_element"]
+	d := r.TestResourceData()
+	_ = d.Set("name", "ne")
+	_ = d.Set("mapped_subnets", []string{"10.0.0.0/8", "invalid"})
+	diags := r.CreateContext(context.Background(), d, c)
+	if assert.Len(t, diags, 2) {
+		assert.Equal(t, "invalid network", diags[0].Summary)
+		assert.Equal(t, cty.GetAttrPath("mapped_subnets"), diags[0].AttributePath)
+		assert.Contains(t, diags[0].Detail, "invalid request")
+		assert.Equal(t, "unknown: invalid", diags[1].Summary)
+		assert.Nil(t, diags[1].AttributePath)
+	}
+}
+
+func TestOrgImport(t *testing.T) {
+	cases := map[string]struct {
+		importID    string
+		expectedID  string
+		expectedOrg string
+	}{
+		"parent-org": {importID: "ne-abcd1234", expectedID: "ne-abcd1234"},
+		"sub-org":    {importID: "child:ne-abcd1234", expectedID: "ne-abcd1234", expectedOrg: "child"},
+		"no-id":      {importID: "child:", expectedID: "child:"},
+	}
+	p := New("dev")()
+	r := p.ResourcesMap["pfptmeta_network_element"]
+	meta := &client.Client{Credentials: &client.Credentials{ClientID: "key", ClientSecret: "secret", Scope: "org:parent"}}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := r.Data(nil)
+			d.SetId(tc.importID)
+			res, err := r.Importer.StateContext(context.Background(), d, meta)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedID, res[0].Id())
+			assert.Equal(t, tc.expectedOrg, res[0].Get("org_shortname"))
+		})
+	}
+	t.Run("pre-issued-token", func(t *testing.T) {
+		d := r.Data(nil)
+		d.SetId("child:ne-abcd1234")
+		tokenMeta := &client.Client{Credentials: &client.Credentials{AccessToken: "token", Scope: "org:parent"}}
+		_, err := r.Importer.StateContext(context.Background(), d, tokenMeta)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAlertPackImport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/alerts/alr-123", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "alr-123", "name": "Posture check failures", "channels": ["nch-123"], "enabled": true,
+			"window": 60, "threshold_condition": {"formula": "count", "op": "greater", "threshold": 0}}`))
+	}))
+	defer server.Close()
+	meta := &client.Client{
+		HTTP:        retryablehttp.NewClient(),
+		BaseURL:     server.URL,
+		Credentials: &client.Credentials{AccessToken: "token"},
+	}
+	r := New("dev")().ResourcesMap["pfptmeta_alert_pack"]
+	d := r.Data(nil)
+	d.SetId("device_posture:posture_check_failures=alr-123")
+	res, err := r.Importer.StateContext(context.Background(), d, meta)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "device_posture", res[0].Get("name"))
+		assert.Equal(t, map[string]interface{}{"posture_check_failures": "alr-123"}, res[0].Get("alert_ids"))
+		assert.Equal(t, "", res[0].Get("org_shortname"))
+	}
+}
+
+// unknownValue is how the SDK represents unknown values in raw configurations.
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestOrgCustomizeDiff(t *testing.T) {
+	cases := map[string]struct {
+		org           interface{}
+		expectedScope string
+		noClient      bool
+	}{
+		"provider-org": {org: "", expectedScope: "org:parent"},
+		"sub-org":      {org: "child", expectedScope: "org:child"},
+		"unknown-org":  {org: unknownValue, noClient: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got interface{}
+			r := &schema.Resource{
+				Schema: map[string]*schema.Schema{"name": {Type: schema.TypeString, Optional: true}},
+				CustomizeDiff: func(_ context.Context, _ *schema.ResourceDiff, meta interface{}) error {
+					got = meta
+					return nil
+				},
+			}
+			withOrgOverride(r, true)
+			meta := &client.Client{Credentials: &client.Credentials{ClientID: "key", ClientSecret: "secret", Scope: "org:parent"}}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "name", "org_shortname": tc.org})
+			_, err := r.Diff(context.Background(), nil, config, meta)
+			assert.NoError(t, err)
+			if tc.noClient {
+				assert.Nil(t, got)
+			} else if assert.IsType(t, &client.Client{}, got) {
+				assert.Equal(t, tc.expectedScope, got.(*client.Client).Credentials.Scope)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package routing_analysis
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"net"
+)
+
+const (
+	description = "Analyzes the mapped subnets of all the network elements and the MetaPorts and MetaPort clusters they are mapped to. " +
+		"Reports mapped subnets of different network elements which overlap, and which MetaPorts and clusters serve a destination IP.\n\n" +
+		"Traffic to a destination is routed to the mapped subnet with the longest matching prefix. " +
+		"When more than one network element maps that prefix, the MetaPort which serves the destination is unpredictable."
+	destinationIpDesc    = "IPv4 or IPv6 address to look up the routes of."
+	overlapsDesc         = "Pairs of mapped subnets of different network elements, the first of which contains the second."
+	cidrDesc             = "The containing CIDR."
+	overlappingCidrDesc  = "The contained CIDR, equal to `cidr` when both network elements map the same CIDR."
+	equalDesc            = "Whether both network elements map the same CIDR, in which case neither of them is more specific."
+	routesDesc           = "Mapped subnets which contain `destination_ip`, the most specific first."
+	ambiguousDesc        = "Whether more than one network element maps the most specific CIDR which contains `destination_ip`."
+	metaportsDesc        = "IDs of the MetaPorts the network element is mapped to."
+	metaportClustersDesc = "IDs of the MetaPort clusters the network element is mapped to."
+	servingMetaportsDesc = "IDs of the MetaPorts which serve `destination_ip`, those of the most specific mapped subnets which contain it."
+	servingClustersDesc  = "IDs of the MetaPort clusters which serve `destination_ip`, those of the most specific mapped subnets which contain it."
+)
+
+func routingAnalysisRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	rt, err := client.LoadRoutingTable(ctx, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var ip net.IP
+	if destination := d.Get("destination_ip").(string); destination != "" {
+		ip = net.ParseIP(destination)
+		d.SetId(destination)
+	} else {
+		d.SetId("routing_analysis")
+	}
+	return routingTableToResource(d, rt, ip)
+}
+
+func mappedSubnetToResource(s client.MappedSubnet) map[string]interface{} {
+	return map[string]interface{}{
+		"network_element_id":   s.NetworkElementID,
+		"network_element_name": s.NetworkElementName,
+		"cidr":                 s.Cidr.String(),
+		"metaports":            s.Metaports,
+		"metaport_clusters":    s.MetaportClusters,
+	}
+}
+
+// appendNew appends the values of b which a doesn't contain yet.
+func appendNew(a []string, b ...string) []string {
+	for _, v := range b {
+		if !client.Contains(v, a) {
+			a = append(a, v)
+		}
+	}
+	return a
+}
+
+func routingTableToResource(d *schema.ResourceData, rt client.RoutingTable, ip net.IP) diag.Diagnostics {
+	overlaps := rt.Overlaps()
+	overlapsList := make([]map[string]interface{}, len(overlaps))
+	for i, o := range overlaps {
+		overlapsList[i] = map[string]interface{}{
+			"network_element_id":               o.Subnet.NetworkElementID,
+			"network_element_name":             o.Subnet.NetworkElementName,
+			"cidr":                             o.Subnet.Cidr.String(),
+			"overlapping_network_element_id":   o.Overlapping.NetworkElementID,
+			"overlapping_network_element_name": o.Overlapping.NetworkElementName,
+			"overlapping_cidr":                 o.Overlapping.Cidr.String(),
+			"equal":                            o.Equal(),
+		}
+	}
+	err := d.Set("overlaps", overlapsList)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var routes []client.MappedSubnet
+	if ip != nil {
+		routes = rt.Route(ip)
+	}
+	routesList := make([]map[string]interface{}, len(routes))
+	var metaports, clusters []string
+	ambiguous := false
+	for i, r := range routes {
+		routesList[i] = mappedSubnetToResource(r)
+		if r.Cidr.String() != routes[0].Cidr.String() {
+			continue
+		}
+		if r.NetworkElementID != routes[0].NetworkElementID {
+			ambiguous = true
+		}
+		metaports = appendNew(metaports, r.Metaports...)
+		clusters = appendNew(clusters, r.MetaportClusters...)
+	}
+	err = d.Set("routes", routesList)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("ambiguous", ambiguous)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("metaports", metaports)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("metaport_clusters", clusters)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package routing_analysis
+
+import (
+	"context"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoutingTableToResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/network_elements":
+			_, _ = w.Write([]byte(`[
+				{"id": "ne-1", "name": "default", "mapped_subnets": ["0.0.0.0/0"]},
+				{"id": "ne-2", "name": "office", "mapped_subnets": ["10.20.0.0/16"]},
+				{"id": "ne-3", "name": "lab", "mapped_subnets": ["10.20.0.0/16"]}]`))
+		case "/v1/metaports":
+			_, _ = w.Write([]byte(`[{"id": "mp-1", "mapped_elements": ["ne-1", "ne-2"]}, {"id": "mp-2", "mapped_elements": ["ne-3"]}]`))
+		case "/v1/metaport_clusters":
+			_, _ = w.Write([]byte(`[{"id": "mpc-1", "mapped_elements": ["ne-2"], "metaports": ["mp-1"]}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := &client.Client{
+		HTTP:        retryablehttp.NewClient(),
+		BaseURL:     server.URL,
+		Credentials: &client.Credentials{AccessToken: "token"},
+	}
+	rt, err := client.LoadRoutingTable(context.Background(), c)
+	assert.NoError(t, err)
+
+	d := DataSource().TestResourceData()
+	diags := routingTableToResource(d, rt, net.ParseIP("10.20.1.1"))
+	assert.Empty(t, diags)
+	assert.Equal(t, 3, d.Get("overlaps.#"))
+	assert.Equal(t, "ne-2", d.Get("overlaps.2.network_element_id"))
+	assert.Equal(t, "ne-3", d.Get("overlaps.2.overlapping_network_element_id"))
+	assert.Equal(t, true, d.Get("overlaps.2.equal"))
+	assert.Equal(t, 3, d.Get("routes.#"))
+	assert.Equal(t, "0.0.0.0/0", d.Get("routes.2.cidr"))
+	assert.Equal(t, true, d.Get("ambiguous"))
+	assert.Equal(t, []interface{}{"mp-1", "mp-2"}, d.Get("metaports"))
+	assert.Equal(t, []interface{}{"mpc-1"}, d.Get("metaport_clusters"))
+
+	d = DataSource().TestResourceData()
+	diags = routingTableToResource(d, rt, net.ParseIP("192.0.2.1"))
+	assert.Empty(t, diags)
+	assert.Equal(t, false, d.Get("ambiguous"))
+	assert.Equal(t, []interface{}{"mp-1"}, d.Get("metaports"))
+}
@@ -0,0 +1,119 @@
+package routing_analysis
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+)
+
+func mappedSubnetSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"network_element_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"network_element_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"cidr": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"metaports": {
+			Description: metaportsDesc,
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"metaport_clusters": {
+			Description: metaportClustersDesc,
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: description,
+
+		ReadContext: routingAnalysisRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"destination_ip": {
+				Description:      destinationIpDesc,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: common.ValidateIP(),
+			},
+			"overlaps": {
+				Description: overlapsDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_element_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network_element_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cidr": {
+							Description: cidrDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"overlapping_network_element_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"overlapping_network_element_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"overlapping_cidr": {
+							Description: overlappingCidrDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"equal": {
+							Description: equalDesc,
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"routes": {
+				Description: routesDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: mappedSubnetSchema()},
+			},
+			"ambiguous": {
+				Description: ambiguousDesc,
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"metaports": {
+				Description: servingMetaportsDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"metaport_clusters": {
+				Description: servingClustersDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
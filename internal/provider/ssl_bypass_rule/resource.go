@@ -84,6 +84,7 @@ func Resource() *schema.Resource {
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: common.ValidateCustomUrlOrIPV4(),
+					DiffSuppressFunc: common.SuppressEquivalentDomain,
 				},
 				Optional: true,
 			},
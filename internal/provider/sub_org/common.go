@@ -0,0 +1,125 @@
+package sub_org
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+)
+
+const (
+	description = "Sub orgs are child orgs of an MSP-style org hierarchy. " +
+		"Resources can be managed in a sub org by setting their `org_shortname` attribute, " +
+		"as long as the provider's API key belongs to the parent org."
+	listDescription = "Lists all the sub orgs of the org the provider is configured with."
+	shortnameDesc   = "The org shortname, used to scope API tokens and to set the `org_shortname` attribute of resources managed in this sub org."
+	parentOrgIDDesc = "The ID of the org this sub org belongs to."
+)
+
+var excludedKeys = []string{"id"}
+
+func subOrgToResource(d *schema.ResourceData, so *client.SubOrg) diag.Diagnostics {
+	d.SetId(so.ID)
+	err := client.MapResponseToResource(so, d, excludedKeys)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func subOrgCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	body := client.NewSubOrg(d)
+	so, err := client.CreateSubOrg(ctx, c, body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return subOrgToResource(d, so)
+}
+
+func subOrgRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*client.Client)
+	var so *client.SubOrg
+	var err error
+	id, exists := d.GetOk("id")
+	if exists {
+		so, err = client.GetSubOrgByID(ctx, c, id.(string))
+	} else if shortname, exists := d.GetOk("shortname"); exists {
+		so, err = client.GetSubOrgByShortname(ctx, c, shortname.(string))
+		if err == nil && so == nil {
+			return diag.Errorf("could not find sub org with shortname \"%s\"", shortname)
+		}
+	}
+	if err != nil {
+		errResponse, ok := err.(*client.ErrorResponse)
+		if ok && errResponse.Status == http.StatusNotFound {
+			log.Printf("[WARN] Removing sub org %s because it's gone", id)
+			d.SetId("")
+			return diags
+		} else {
+			return diag.FromErr(err)
+		}
+	}
+	return subOrgToResource(d, so)
+}
+
+func subOrgUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	id := d.Id()
+	body := client.NewSubOrg(d)
+	so, err := client.UpdateSubOrg(ctx, c, id, body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return subOrgToResource(d, so)
+}
+
+func subOrgDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*client.Client)
+
+	id := d.Id()
+	_, err := client.DeleteSubOrg(ctx, c, id)
+	if err != nil {
+		errResponse, ok := err.(*client.ErrorResponse)
+		if ok && errResponse.Status == http.StatusNotFound {
+			d.SetId("")
+		} else {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return diags
+}
+
+func subOrgsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	subOrgs, err := client.ListSubOrgs(ctx, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res := make([]map[string]interface{}, len(subOrgs))
+	for i, so := range subOrgs {
+		res[i] = map[string]interface{}{
+			"id":            so.ID,
+			"name":          so.Name,
+			"shortname":     so.Shortname,
+			"description":   so.Description,
+			"enabled":       so.Enabled,
+			"parent_org_id": so.ParentOrgID,
+		}
+	}
+	err = d.Set("sub_orgs", res)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("sub_orgs")
+	return nil
+}
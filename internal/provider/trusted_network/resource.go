@@ -81,7 +81,8 @@ func Resource() *schema.Resource {
 										Required: true,
 										Elem: &schema.Schema{
 											Type:             schema.TypeString,
-											ValidateDiagFunc: common.ValidateCIDR4(),
+											ValidateDiagFunc: common.ValidateCIDR(),
+											DiffSuppressFunc: common.SuppressEquivalentCIDR,
 										},
 									},
 								},
@@ -101,12 +102,14 @@ func Resource() *schema.Resource {
 										Required: true,
 										Elem: &schema.Schema{
 											Type:             schema.TypeString,
-											ValidateDiagFunc: common.ValidateCIDR4(),
+											ValidateDiagFunc: common.ValidateCIDR(),
+											DiffSuppressFunc: common.SuppressEquivalentCIDR,
 										},
 									},
 									"hostname": {
 										Type:             schema.TypeString,
 										ValidateDiagFunc: common.ValidateHostName(),
+										DiffSuppressFunc: common.SuppressEquivalentHostname,
 										Required:         true,
 									},
 								},
@@ -2,20 +2,50 @@ package tunnel
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
 )
 
-var excludedKeys = []string{"id", "gre_config"}
+var excludedKeys = []string{"id", "gre_config", "ipsec_config", "endpoints"}
 
 const (
 	description = "Tunnels represent the origin of the connection from " +
-		"the customer’s site to the Proofpoint cloud."
+		"the customer’s site to the Proofpoint cloud. A tunnel is either a GRE tunnel or, for sites which can't use GRE, " +
+		"an IPsec tunnel. Changing the type of the tunnel recreates it."
+	sourceIpsDesc      = "IPv4 or IPv6 addresses the GRE tunnels of the site originate from."
+	ipsecConfigDesc    = "Route based IPsec tunnels, with a virtual tunnel interface on the site router."
+	ipsecSourceIpsDesc = "IPv4 or IPv6 addresses the IPsec tunnels of the site originate from."
+	ikeVersionDesc     = "IKE version, ENUM: [1, 2], defaults to 2."
+	pskDesc            = "Pre-shared key which both sides of the tunnel authenticate with."
+	ikeProposalsDesc   = "IKE proposals, by order of preference, in the strongSwan notation `<encryption>-<integrity>-<DH group>`. " +
+		"Encryption: [aes128, aes256], integrity: [sha256, sha384], DH group: [modp2048, ecp256, ecp384]. " +
+		"Defaults to aes256-sha256-modp2048."
+	espProposalsDesc = "ESP proposals, in the same notation as the IKE proposals, the DH group is the group of the perfect forward secrecy. " +
+		"Defaults to aes256-sha256-modp2048."
+	popsDesc = "Names of the POPs to establish the tunnel with, e.g. the names of the nearest locations of the pfptmeta_locations data source. " +
+		"Defaults to POPs chosen by Proofpoint."
+	endpointsDesc         = "The Proofpoint side of the tunnel in every POP the tunnel is established with."
+	popDesc               = "The POP of the endpoint."
+	endpointIpDesc        = "Public IP address of the endpoint, the destination of the tunnel."
+	innerIpDesc           = "Inner IP address of the Proofpoint side of the tunnel."
+	peerInnerIpDesc       = "Inner IP address of the site side of the tunnel, to assign to the tunnel interface of the site router."
+	innerPrefixLengthDesc = "Prefix length of the inner addresses."
+	routerConfigDesc      = "Ready to paste configuration of the site router, with a tunnel to every endpoint. " +
+		"Each tunnel originates from the first source IP of the address family of its endpoint. " +
+		"The Juniper and Palo Alto configurations terminate the tunnels on the " + junosWanInterface + " and " + panosWanInterface +
+		" interfaces, replace them with the WAN interface of the router if needed."
+	ciscoIosDesc   = "Cisco IOS configuration."
+	junosDesc      = "Juniper Junos configuration, in set commands."
+	panosDesc      = "Palo Alto PAN-OS configuration, in set commands."
+	strongswanDesc = "strongSwan swanctl.conf configuration of IPsec tunnels, with the commands creating their XFRM interfaces in comments. " +
+		"Empty for GRE tunnels."
 )
 
 func greTunnelConfigFromResource(d *schema.ResourceData) *client.GreTunnelConfig {
@@ -29,6 +59,28 @@ func greTunnelConfigFromResource(d *schema.ResourceData) *client.GreTunnelConfig
 	return &client.GreTunnelConfig{SourceIps: ips}
 }
 
+func ipsecTunnelConfigFromResource(d *schema.ResourceData) *client.IpsecTunnelConfig {
+	i := d.Get("ipsec_config").([]interface{})
+	if len(i) == 0 {
+		return nil
+	}
+	o := i[0].(map[string]interface{})
+	res := &client.IpsecTunnelConfig{
+		SourceIps:    client.ResourceTypeSetToStringSlice(o["source_ips"].(*schema.Set)),
+		IkeVersion:   o["ike_version"].(int),
+		Psk:          o["psk"].(string),
+		IkeProposals: client.ConfigToStringSlice("ipsec_config.0.ike_proposals", d),
+		EspProposals: client.ConfigToStringSlice("ipsec_config.0.esp_proposals", d),
+	}
+	if len(res.IkeProposals) == 0 {
+		res.IkeProposals = []string{defaultProposal}
+	}
+	if len(res.EspProposals) == 0 {
+		res.EspProposals = []string{defaultProposal}
+	}
+	return res
+}
+
 func tunnelFromResource(d *schema.ResourceData) *client.Tunnel {
 	res := &client.Tunnel{}
 	if d.HasChange("name") {
@@ -38,6 +90,8 @@ func tunnelFromResource(d *schema.ResourceData) *client.Tunnel {
 
 	res.Description = d.Get("description").(string)
 	res.GreConfig = greTunnelConfigFromResource(d)
+	res.IpsecConfig = ipsecTunnelConfigFromResource(d)
+	res.Pops = client.ConfigToStringSlice("pops", d)
 
 	enabled := d.Get("enabled").(bool)
 	res.Enabled = &enabled
@@ -61,9 +115,74 @@ func tunnelToResource(d *schema.ResourceData, t *client.Tunnel) diag.Diagnostics
 			return diag.FromErr(err)
 		}
 	}
+	if t.IpsecConfig != nil {
+		// The API doesn't return the pre-shared key
+		if t.IpsecConfig.Psk == "" {
+			t.IpsecConfig.Psk = d.Get("ipsec_config.0.psk").(string)
+		}
+		i := []map[string]interface{}{
+			{
+				"source_ips":    t.IpsecConfig.SourceIps,
+				"ike_version":   t.IpsecConfig.IkeVersion,
+				"psk":           t.IpsecConfig.Psk,
+				"ike_proposals": t.IpsecConfig.IkeProposals,
+				"esp_proposals": t.IpsecConfig.EspProposals,
+			},
+		}
+		if err := d.Set("ipsec_config", i); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	endpoints := make([]map[string]interface{}, len(t.Endpoints))
+	for i, e := range t.Endpoints {
+		endpoints[i] = map[string]interface{}{
+			"pop":                 e.Pop,
+			"ip":                  e.Ip,
+			"inner_ip":            e.InnerIp,
+			"peer_inner_ip":       e.PeerInnerIp,
+			"inner_prefix_length": e.InnerPrefixLength,
+		}
+	}
+	if err := d.Set("endpoints", endpoints); err != nil {
+		return diag.FromErr(err)
+	}
+	var routerConfig []map[string]interface{}
+	configs, err := routerConfigs(t)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Could not render the router configuration of tunnel %s", t.ID),
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath("router_config"),
+		})
+	}
+	if configs != nil {
+		routerConfig = []map[string]interface{}{{}}
+		for platform, config := range configs {
+			routerConfig[0][platform] = config
+		}
+	}
+	if err := d.Set("router_config", routerConfig); err != nil {
+		return diag.FromErr(err)
+	}
 	return diags
 }
 
+// ipsecConfigAddedOrRemoved returns whether the tunnel changes from or to an IPsec tunnel, which recreates it.
+func ipsecConfigAddedOrRemoved(_ context.Context, old, new, _ interface{}) bool {
+	return len(old.([]interface{})) != len(new.([]interface{}))
+}
+
+// routerConfigChanged returns whether the router configuration is rendered with changed attributes.
+func routerConfigChanged(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+	return d.HasChanges("name", "gre_config", "ipsec_config", "pops")
+}
+
+// popsChanged returns whether the tunnel is established with other POPs, and so with other endpoints.
+func popsChanged(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+	return d.HasChange("pops")
+}
+
 func tunnelRead(keepInStateOnMissing bool, ctx context.Context,
 	d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
@@ -173,3 +292,64 @@ func tunnelDelete(ctx context.Context, d *schema.ResourceData, meta interface{})
 	}
 	return diags
 }
+
+func endpointsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: endpointsDesc,
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"pop": {
+					Description: popDesc,
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"ip": {
+					Description: endpointIpDesc,
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"inner_ip": {
+					Description: innerIpDesc,
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"peer_inner_ip": {
+					Description: peerInnerIpDesc,
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"inner_prefix_length": {
+					Description: innerPrefixLengthDesc,
+					Type:        schema.TypeInt,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func routerConfigSchema() *schema.Schema {
+	platformSchema := func(desc string) *schema.Schema {
+		return &schema.Schema{
+			Description: desc,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+		}
+	}
+	return &schema.Schema{
+		Description: routerConfigDesc,
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cisco_ios":  platformSchema(ciscoIosDesc),
+				"junos":      platformSchema(junosDesc),
+				"pan_os":     platformSchema(panosDesc),
+				"strongswan": platformSchema(strongswanDesc),
+			},
+		},
+	}
+}
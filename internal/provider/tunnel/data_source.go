@@ -46,6 +46,60 @@ func DataSource() *schema.Resource {
 				Optional: true,
 				MaxItems: 1,
 			},
+			"ipsec_config": {
+				Description: ipsecConfigDesc,
+				Type:        schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_ips": {
+							Description: ipsecSourceIpsDesc,
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"ike_version": {
+							Description: ikeVersionDesc,
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"psk": {
+							Description: pskDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+						},
+						"ike_proposals": {
+							Description: ikeProposalsDesc,
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"esp_proposals": {
+							Description: espProposalsDesc,
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+				Computed: true,
+			},
+			"pops": {
+				Description: popsDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"endpoints":     endpointsSchema(),
+			"router_config": routerConfigSchema(),
 		},
 	}
 }
@@ -1,7 +1,9 @@
 package tunnel
 
 import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
 )
 
 func Resource() *schema.Resource {
@@ -15,6 +17,11 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			customdiff.ForceNewIfChange("ipsec_config", ipsecConfigAddedOrRemoved),
+			customdiff.ComputedIf("endpoints", popsChanged),
+			customdiff.ComputedIf("router_config", routerConfigChanged),
+		),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -38,17 +45,87 @@ func Resource() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"source_ips": {
-							Type:     schema.TypeSet,
-							Optional: true,
+							Description: sourceIpsDesc,
+							Type:        schema.TypeSet,
+							Optional:    true,
 							Elem: &schema.Schema{
-								Type: schema.TypeString,
+								Type:             schema.TypeString,
+								ValidateDiagFunc: common.ValidateIP(),
 							},
+							Set: common.HashIP,
 						},
 					},
 				},
-				Optional: true,
-				MaxItems: 1,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"ipsec_config"},
+			},
+			"ipsec_config": {
+				Description: ipsecConfigDesc,
+				Type:        schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_ips": {
+							Description: ipsecSourceIpsDesc,
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: common.ValidateIP(),
+							},
+							Set: common.HashIP,
+						},
+						"ike_version": {
+							Description:      ikeVersionDesc,
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          2,
+							ValidateDiagFunc: common.ValidateIntENUM(1, 2),
+						},
+						"psk": {
+							Description: pskDesc,
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"ike_proposals": {
+							Description: ikeProposalsDesc,
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validateProposal(),
+							},
+						},
+						"esp_proposals": {
+							Description: espProposalsDesc,
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validateProposal(),
+							},
+						},
+					},
+				},
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"gre_config"},
+			},
+			"pops": {
+				Description: popsDesc,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
+			"endpoints":     endpointsSchema(),
+			"router_config": routerConfigSchema(),
 		},
 	}
 }
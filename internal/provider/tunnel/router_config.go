@@ -0,0 +1,477 @@
+package tunnel
+
+import (
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"net"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultProposal = "aes256-sha256-modp2048"
+	// The WAN interfaces which the Juniper and Palo Alto configurations terminate the tunnels on.
+	junosWanInterface = "ge-0/0/0.0"
+	panosWanInterface = "ethernet1/1"
+)
+
+// algorithm holds the keywords of an algorithm of an IKE or ESP proposal in the configuration of each platform.
+type algorithm struct {
+	ciscoIKEv2 string
+	ciscoIKEv1 string
+	ciscoESP   string
+	junosIKE   string
+	junosESP   string
+	panos      string
+}
+
+var (
+	encryptionAlgorithms = map[string]algorithm{
+		"aes128": {"aes-cbc-128", "aes 128", "esp-aes 128", "aes-128-cbc", "aes-128-cbc", "aes-128-cbc"},
+		"aes256": {"aes-cbc-256", "aes 256", "esp-aes 256", "aes-256-cbc", "aes-256-cbc", "aes-256-cbc"},
+	}
+	integrityAlgorithms = map[string]algorithm{
+		"sha256": {"sha256", "sha256", "esp-sha256-hmac", "sha-256", "hmac-sha-256-128", "sha256"},
+		"sha384": {"sha384", "sha384", "esp-sha384-hmac", "sha-384", "hmac-sha-384", "sha384"},
+	}
+	dhGroups = map[string]algorithm{
+		"modp2048": {"14", "14", "group14", "group14", "group14", "group14"},
+		"ecp256":   {"19", "19", "group19", "group19", "group19", "group19"},
+		"ecp384":   {"20", "20", "group20", "group20", "group20", "group20"},
+	}
+)
+
+// proposal is an IKE or ESP proposal in the strongSwan notation: <encryption>-<integrity>-<DH group>.
+// The DH group of ESP proposals is the group of the perfect forward secrecy.
+type proposal struct {
+	encryption algorithm
+	integrity  algorithm
+	dhGroup    algorithm
+}
+
+func parseProposal(p string) (proposal, error) {
+	parts := strings.Split(p, "-")
+	if len(parts) != 3 {
+		return proposal{}, fmt.Errorf("\"%s\" is not a proposal of the form <encryption>-<integrity>-<DH group>", p)
+	}
+	encryption, ok := encryptionAlgorithms[parts[0]]
+	if !ok {
+		return proposal{}, fmt.Errorf("\"%s\" has an unsupported encryption algorithm, supported: %s", p, algorithmNames(encryptionAlgorithms))
+	}
+	integrity, ok := integrityAlgorithms[parts[1]]
+	if !ok {
+		return proposal{}, fmt.Errorf("\"%s\" has an unsupported integrity algorithm, supported: %s", p, algorithmNames(integrityAlgorithms))
+	}
+	dhGroup, ok := dhGroups[parts[2]]
+	if !ok {
+		return proposal{}, fmt.Errorf("\"%s\" has an unsupported DH group, supported: %s", p, algorithmNames(dhGroups))
+	}
+	return proposal{encryption: encryption, integrity: integrity, dhGroup: dhGroup}, nil
+}
+
+func algorithmNames(algorithms map[string]algorithm) string {
+	names := make([]string, 0, len(algorithms))
+	for name := range algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func validateProposal() func(interface{}, cty.Path) diag.Diagnostics {
+	return func(input interface{}, _ cty.Path) diag.Diagnostics {
+		if _, err := parseProposal(input.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+}
+
+func parseProposals(proposals []string) ([]proposal, error) {
+	if len(proposals) == 0 {
+		proposals = []string{defaultProposal}
+	}
+	res := make([]proposal, 0, len(proposals))
+	for _, p := range proposals {
+		// The proposals of the resource are validated by the schema, but not the ones read by the data source
+		parsed, err := parseProposal(p)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, parsed)
+	}
+	return res, nil
+}
+
+// routerTunnel is a tunnel of the site router to an endpoint of a POP.
+type routerTunnel struct {
+	unit     int
+	pop      string
+	sourceIp net.IP
+	endpoint net.IP
+	innerIp  net.IP
+	innerLen int
+}
+
+func (rt routerTunnel) ipv6() bool {
+	return rt.endpoint.To4() == nil
+}
+
+func (rt routerTunnel) innerIpv6() bool {
+	return rt.innerIp.To4() == nil
+}
+
+// innerCIDR is the inner address of the site side of the tunnel, e.g. 169.254.10.2/30.
+func (rt routerTunnel) innerCIDR() string {
+	return fmt.Sprintf("%s/%d", rt.innerIp, rt.innerLen)
+}
+
+// innerMask is the netmask of the inner addresses, e.g. 255.255.255.252.
+func (rt routerTunnel) innerMask() string {
+	return net.IP(net.CIDRMask(rt.innerLen, 8*len(rt.innerIp))).String()
+}
+
+// routerTunnels pairs every endpoint with the first source IP of its address family.
+// Endpoints without a source IP of their family, or without valid addresses, are skipped.
+func routerTunnels(sourceIps []string, endpoints []client.TunnelEndpoint) []routerTunnel {
+	sorted := append([]string(nil), sourceIps...)
+	sort.Strings(sorted)
+	var res []routerTunnel
+	for _, e := range endpoints {
+		endpoint, innerIp := net.ParseIP(e.Ip), net.ParseIP(e.PeerInnerIp)
+		if endpoint == nil || innerIp == nil {
+			continue
+		}
+		if ip4 := innerIp.To4(); ip4 != nil {
+			innerIp = ip4
+		}
+		for _, s := range sorted {
+			sourceIp := net.ParseIP(s)
+			if sourceIp != nil && (sourceIp.To4() == nil) == (endpoint.To4() == nil) {
+				res = append(res, routerTunnel{
+					unit:     len(res) + 1,
+					pop:      e.Pop,
+					sourceIp: sourceIp,
+					endpoint: endpoint,
+					innerIp:  innerIp,
+					innerLen: e.InnerPrefixLength,
+				})
+				break
+			}
+		}
+	}
+	return res
+}
+
+// routerConfigs renders the configurations of the site router for every platform, keyed by its attribute name.
+// strongSwan only terminates IPsec tunnels, so its configuration is empty for GRE tunnels.
+func routerConfigs(t *client.Tunnel) (map[string]string, error) {
+	switch {
+	case t.GreConfig != nil:
+		tunnels := routerTunnels(t.GreConfig.SourceIps, t.Endpoints)
+		if len(tunnels) == 0 {
+			return nil, nil
+		}
+		return map[string]string{
+			"cisco_ios":  ciscoGreConfig(t.Name, tunnels),
+			"junos":      junosGreConfig(t.Name, tunnels),
+			"pan_os":     panosGreConfig(tunnels),
+			"strongswan": "",
+		}, nil
+	case t.IpsecConfig != nil:
+		tunnels := routerTunnels(t.IpsecConfig.SourceIps, t.Endpoints)
+		if len(tunnels) == 0 {
+			return nil, nil
+		}
+		c := t.IpsecConfig
+		ike, err := parseProposals(c.IkeProposals)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ike_proposals: %v", err)
+		}
+		esp, err := parseProposals(c.EspProposals)
+		if err != nil {
+			return nil, fmt.Errorf("invalid esp_proposals: %v", err)
+		}
+		return map[string]string{
+			"cisco_ios":  ciscoIpsecConfig(t.Name, c, ike, esp, tunnels),
+			"junos":      junosIpsecConfig(t.Name, c, ike, esp, tunnels),
+			"pan_os":     panosIpsecConfig(c, ike, esp, tunnels),
+			"strongswan": strongswanConfig(c, tunnels),
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func ciscoTunnelInterface(b *strings.Builder, name string, rt routerTunnel, mode string) {
+	fmt.Fprintf(b, "interface Tunnel%d\n", rt.unit)
+	fmt.Fprintf(b, " description pfptmeta %s %s\n", name, rt.pop)
+	if rt.innerIpv6() {
+		fmt.Fprintf(b, " ipv6 address %s\n", rt.innerCIDR())
+	} else {
+		fmt.Fprintf(b, " ip address %s %s\n", rt.innerIp, rt.innerMask())
+	}
+	fmt.Fprintf(b, " tunnel source %s\n", rt.sourceIp)
+	if rt.ipv6() {
+		fmt.Fprintf(b, " tunnel mode %s ipv6\n", mode)
+	} else if mode != "gre" {
+		fmt.Fprintf(b, " tunnel mode %s ipv4\n", mode)
+	}
+	fmt.Fprintf(b, " tunnel destination %s\n", rt.endpoint)
+}
+
+func ciscoGreConfig(name string, tunnels []routerTunnel) string {
+	var b strings.Builder
+	for _, rt := range tunnels {
+		ciscoTunnelInterface(&b, name, rt, "gre")
+		b.WriteString("!\n")
+	}
+	return b.String()
+}
+
+func ciscoIpsecConfig(name string, c *client.IpsecTunnelConfig, ike, esp []proposal, tunnels []routerTunnel) string {
+	var b strings.Builder
+	if c.IkeVersion == 1 {
+		for i, p := range ike {
+			fmt.Fprintf(&b, "crypto isakmp policy %d\n", 10*(i+1))
+			fmt.Fprintf(&b, " encryption %s\n", p.encryption.ciscoIKEv1)
+			fmt.Fprintf(&b, " hash %s\n", p.integrity.ciscoIKEv1)
+			b.WriteString(" authentication pre-share\n")
+			fmt.Fprintf(&b, " group %s\n", p.dhGroup.ciscoIKEv1)
+			b.WriteString("!\n")
+		}
+		for _, rt := range tunnels {
+			fmt.Fprintf(&b, "crypto isakmp key %s address %s\n", c.Psk, rt.endpoint)
+		}
+		b.WriteString("!\n")
+	} else {
+		for i, p := range ike {
+			fmt.Fprintf(&b, "crypto ikev2 proposal pfptmeta-%d\n", i+1)
+			fmt.Fprintf(&b, " encryption %s\n", p.encryption.ciscoIKEv2)
+			fmt.Fprintf(&b, " integrity %s\n", p.integrity.ciscoIKEv2)
+			fmt.Fprintf(&b, " group %s\n", p.dhGroup.ciscoIKEv2)
+			b.WriteString("!\n")
+		}
+		b.WriteString("crypto ikev2 policy pfptmeta\n")
+		for i := range ike {
+			fmt.Fprintf(&b, " proposal pfptmeta-%d\n", i+1)
+		}
+		b.WriteString("!\ncrypto ikev2 keyring pfptmeta\n")
+		for _, rt := range tunnels {
+			fmt.Fprintf(&b, " peer pfptmeta-%d\n", rt.unit)
+			fmt.Fprintf(&b, "  address %s\n", rt.endpoint)
+			fmt.Fprintf(&b, "  pre-shared-key %s\n", c.Psk)
+			b.WriteString(" !\n")
+		}
+		b.WriteString("!\ncrypto ikev2 profile pfptmeta\n")
+		for _, rt := range tunnels {
+			if rt.ipv6() {
+				fmt.Fprintf(&b, " match identity remote address %s/128\n", rt.endpoint)
+			} else {
+				fmt.Fprintf(&b, " match identity remote address %s 255.255.255.255\n", rt.endpoint)
+			}
+		}
+		b.WriteString(" authentication remote pre-share\n")
+		b.WriteString(" authentication local pre-share\n")
+		b.WriteString(" keyring local pfptmeta\n")
+		b.WriteString("!\n")
+	}
+	transformSets := make([]string, len(esp))
+	for i, p := range esp {
+		transformSets[i] = fmt.Sprintf("pfptmeta-%d", i+1)
+		fmt.Fprintf(&b, "crypto ipsec transform-set %s %s %s\n", transformSets[i], p.encryption.ciscoESP, p.integrity.ciscoESP)
+		b.WriteString(" mode tunnel\n")
+		b.WriteString("!\n")
+	}
+	b.WriteString("crypto ipsec profile pfptmeta\n")
+	fmt.Fprintf(&b, " set transform-set %s\n", strings.Join(transformSets, " "))
+	// IOS supports a single PFS group, which is the group of the preferred ESP proposal
+	fmt.Fprintf(&b, " set pfs %s\n", esp[0].dhGroup.ciscoESP)
+	if c.IkeVersion != 1 {
+		b.WriteString(" set ikev2-profile pfptmeta\n")
+	}
+	b.WriteString("!\n")
+	for _, rt := range tunnels {
+		ciscoTunnelInterface(&b, name, rt, "ipsec")
+		b.WriteString(" tunnel protection ipsec profile pfptmeta\n")
+		b.WriteString("!\n")
+	}
+	return b.String()
+}
+
+func junosInterfaceAddress(b *strings.Builder, iface string, rt routerTunnel) {
+	family := "inet"
+	if rt.innerIpv6() {
+		family = "inet6"
+	}
+	fmt.Fprintf(b, "set interfaces %s unit %d family %s address %s\n", iface, rt.unit, family, rt.innerCIDR())
+}
+
+func junosGreConfig(name string, tunnels []routerTunnel) string {
+	var b strings.Builder
+	for _, rt := range tunnels {
+		fmt.Fprintf(&b, "set interfaces gr-0/0/0 unit %d description \"pfptmeta %s %s\"\n", rt.unit, name, rt.pop)
+		fmt.Fprintf(&b, "set interfaces gr-0/0/0 unit %d tunnel source %s\n", rt.unit, rt.sourceIp)
+		fmt.Fprintf(&b, "set interfaces gr-0/0/0 unit %d tunnel destination %s\n", rt.unit, rt.endpoint)
+		junosInterfaceAddress(&b, "gr-0/0/0", rt)
+	}
+	return b.String()
+}
+
+func junosIpsecConfig(name string, c *client.IpsecTunnelConfig, ike, esp []proposal, tunnels []routerTunnel) string {
+	var b strings.Builder
+	ikeNames := make([]string, len(ike))
+	for i, p := range ike {
+		ikeNames[i] = fmt.Sprintf("pfptmeta-%d", i+1)
+		fmt.Fprintf(&b, "set security ike proposal %s authentication-method pre-shared-keys\n", ikeNames[i])
+		fmt.Fprintf(&b, "set security ike proposal %s dh-group %s\n", ikeNames[i], p.dhGroup.junosIKE)
+		fmt.Fprintf(&b, "set security ike proposal %s authentication-algorithm %s\n", ikeNames[i], p.integrity.junosIKE)
+		fmt.Fprintf(&b, "set security ike proposal %s encryption-algorithm %s\n", ikeNames[i], p.encryption.junosIKE)
+	}
+	fmt.Fprintf(&b, "set security ike policy pfptmeta proposals [ %s ]\n", strings.Join(ikeNames, " "))
+	fmt.Fprintf(&b, "set security ike policy pfptmeta pre-shared-key ascii-text \"%s\"\n", c.Psk)
+	if c.IkeVersion == 1 {
+		b.WriteString("set security ike policy pfptmeta mode main\n")
+	}
+	espNames := make([]string, len(esp))
+	for i, p := range esp {
+		espNames[i] = fmt.Sprintf("pfptmeta-%d", i+1)
+		fmt.Fprintf(&b, "set security ipsec proposal %s protocol esp\n", espNames[i])
+		fmt.Fprintf(&b, "set security ipsec proposal %s authentication-algorithm %s\n", espNames[i], p.integrity.junosESP)
+		fmt.Fprintf(&b, "set security ipsec proposal %s encryption-algorithm %s\n", espNames[i], p.encryption.junosESP)
+	}
+	fmt.Fprintf(&b, "set security ipsec policy pfptmeta perfect-forward-secrecy keys %s\n", esp[0].dhGroup.junosESP)
+	fmt.Fprintf(&b, "set security ipsec policy pfptmeta proposals [ %s ]\n", strings.Join(espNames, " "))
+	version := "v2-only"
+	if c.IkeVersion == 1 {
+		version = "v1-only"
+	}
+	for _, rt := range tunnels {
+		gateway := fmt.Sprintf("pfptmeta-%d", rt.unit)
+		fmt.Fprintf(&b, "set security ike gateway %s ike-policy pfptmeta\n", gateway)
+		fmt.Fprintf(&b, "set security ike gateway %s address %s\n", gateway, rt.endpoint)
+		fmt.Fprintf(&b, "set security ike gateway %s local-address %s\n", gateway, rt.sourceIp)
+		fmt.Fprintf(&b, "set security ike gateway %s external-interface %s\n", gateway, junosWanInterface)
+		fmt.Fprintf(&b, "set security ike gateway %s version %s\n", gateway, version)
+		fmt.Fprintf(&b, "set interfaces st0 unit %d description \"pfptmeta %s %s\"\n", rt.unit, name, rt.pop)
+		junosInterfaceAddress(&b, "st0", rt)
+		fmt.Fprintf(&b, "set security ipsec vpn %s bind-interface st0.%d\n", gateway, rt.unit)
+		fmt.Fprintf(&b, "set security ipsec vpn %s ike gateway %s\n", gateway, gateway)
+		fmt.Fprintf(&b, "set security ipsec vpn %s ike ipsec-policy pfptmeta\n", gateway)
+		fmt.Fprintf(&b, "set security ipsec vpn %s establish-tunnels immediately\n", gateway)
+	}
+	return b.String()
+}
+
+func panosTunnelInterface(b *strings.Builder, rt routerTunnel) {
+	if rt.innerIpv6() {
+		fmt.Fprintf(b, "set network interface tunnel units tunnel.%d ipv6 enabled yes\n", rt.unit)
+		fmt.Fprintf(b, "set network interface tunnel units tunnel.%d ipv6 address %s\n", rt.unit, rt.innerCIDR())
+	} else {
+		fmt.Fprintf(b, "set network interface tunnel units tunnel.%d ip %s\n", rt.unit, rt.innerCIDR())
+	}
+}
+
+func panosGreConfig(tunnels []routerTunnel) string {
+	var b strings.Builder
+	for _, rt := range tunnels {
+		panosTunnelInterface(&b, rt)
+		fmt.Fprintf(&b, "set network tunnel gre pfptmeta-%d tunnel-interface tunnel.%d\n", rt.unit, rt.unit)
+		fmt.Fprintf(&b, "set network tunnel gre pfptmeta-%d local-address interface %s\n", rt.unit, panosWanInterface)
+		fmt.Fprintf(&b, "set network tunnel gre pfptmeta-%d peer-address ip %s\n", rt.unit, rt.endpoint)
+	}
+	return b.String()
+}
+
+// panosAlgorithms returns the distinct keywords of the proposals, as Palo Alto crypto profiles list every algorithm once.
+func panosAlgorithms(proposals []proposal, get func(proposal) string) string {
+	var res []string
+	for _, p := range proposals {
+		if a := get(p); !client.Contains(a, res) {
+			res = append(res, a)
+		}
+	}
+	return strings.Join(res, " ")
+}
+
+func panosIpsecConfig(c *client.IpsecTunnelConfig, ike, esp []proposal, tunnels []routerTunnel) string {
+	var b strings.Builder
+	encryption := func(p proposal) string { return p.encryption.panos }
+	integrity := func(p proposal) string { return p.integrity.panos }
+	dhGroup := func(p proposal) string { return p.dhGroup.panos }
+	fmt.Fprintf(&b, "set network ike crypto-profiles ike-crypto-profiles pfptmeta encryption [ %s ]\n", panosAlgorithms(ike, encryption))
+	fmt.Fprintf(&b, "set network ike crypto-profiles ike-crypto-profiles pfptmeta hash [ %s ]\n", panosAlgorithms(ike, integrity))
+	fmt.Fprintf(&b, "set network ike crypto-profiles ike-crypto-profiles pfptmeta dh-group [ %s ]\n", panosAlgorithms(ike, dhGroup))
+	fmt.Fprintf(&b, "set network ike crypto-profiles ipsec-crypto-profiles pfptmeta esp encryption [ %s ]\n", panosAlgorithms(esp, encryption))
+	fmt.Fprintf(&b, "set network ike crypto-profiles ipsec-crypto-profiles pfptmeta esp authentication [ %s ]\n", panosAlgorithms(esp, integrity))
+	fmt.Fprintf(&b, "set network ike crypto-profiles ipsec-crypto-profiles pfptmeta dh-group %s\n", esp[0].dhGroup.panos)
+	version := "ikev2"
+	if c.IkeVersion == 1 {
+		version = "ikev1"
+	}
+	for _, rt := range tunnels {
+		gateway := fmt.Sprintf("pfptmeta-%d", rt.unit)
+		fmt.Fprintf(&b, "set network ike gateway %s authentication pre-shared-key key \"%s\"\n", gateway, c.Psk)
+		fmt.Fprintf(&b, "set network ike gateway %s protocol version %s\n", gateway, version)
+		fmt.Fprintf(&b, "set network ike gateway %s protocol %s ike-crypto-profile pfptmeta\n", gateway, version)
+		fmt.Fprintf(&b, "set network ike gateway %s local-address interface %s\n", gateway, panosWanInterface)
+		fmt.Fprintf(&b, "set network ike gateway %s peer-address ip %s\n", gateway, rt.endpoint)
+		panosTunnelInterface(&b, rt)
+		fmt.Fprintf(&b, "set network tunnel ipsec %s tunnel-interface tunnel.%d\n", gateway, rt.unit)
+		fmt.Fprintf(&b, "set network tunnel ipsec %s auto-key ike-gateway %s\n", gateway, gateway)
+		fmt.Fprintf(&b, "set network tunnel ipsec %s auto-key ipsec-crypto-profile pfptmeta\n", gateway)
+	}
+	return b.String()
+}
+
+// strongswanConfig renders a swanctl.conf of route based tunnels, each bound to the XFRM interface of its if_id.
+func strongswanConfig(c *client.IpsecTunnelConfig, tunnels []routerTunnel) string {
+	ike, esp := c.IkeProposals, c.EspProposals
+	if len(ike) == 0 {
+		ike = []string{defaultProposal}
+	}
+	if len(esp) == 0 {
+		esp = []string{defaultProposal}
+	}
+	version := 2
+	if c.IkeVersion == 1 {
+		version = 1
+	}
+	var b strings.Builder
+	b.WriteString("connections {\n")
+	for _, rt := range tunnels {
+		trafficSelector := "0.0.0.0/0"
+		if rt.innerIpv6() {
+			trafficSelector = "::/0"
+		}
+		fmt.Fprintf(&b, "  # %s: ip link add xfrm%d type xfrm if_id %d && ip addr add %s dev xfrm%d\n",
+			rt.pop, rt.unit, rt.unit, rt.innerCIDR(), rt.unit)
+		fmt.Fprintf(&b, "  pfptmeta-%d {\n", rt.unit)
+		fmt.Fprintf(&b, "    version = %d\n", version)
+		fmt.Fprintf(&b, "    local_addrs = %s\n", rt.sourceIp)
+		fmt.Fprintf(&b, "    remote_addrs = %s\n", rt.endpoint)
+		fmt.Fprintf(&b, "    proposals = %s\n", strings.Join(ike, ","))
+		fmt.Fprintf(&b, "    if_id_in = %d\n", rt.unit)
+		fmt.Fprintf(&b, "    if_id_out = %d\n", rt.unit)
+		b.WriteString("    local {\n      auth = psk\n    }\n")
+		b.WriteString("    remote {\n      auth = psk\n    }\n")
+		b.WriteString("    children {\n")
+		fmt.Fprintf(&b, "      pfptmeta-%d {\n", rt.unit)
+		fmt.Fprintf(&b, "        local_ts = %s\n", trafficSelector)
+		fmt.Fprintf(&b, "        remote_ts = %s\n", trafficSelector)
+		fmt.Fprintf(&b, "        esp_proposals = %s\n", strings.Join(esp, ","))
+		b.WriteString("        start_action = start\n")
+		b.WriteString("      }\n    }\n  }\n")
+	}
+	b.WriteString("}\nsecrets {\n")
+	for _, rt := range tunnels {
+		fmt.Fprintf(&b, "  ike-pfptmeta-%d {\n", rt.unit)
+		fmt.Fprintf(&b, "    id = %s\n", rt.endpoint)
+		fmt.Fprintf(&b, "    secret = \"%s\"\n", c.Psk)
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,228 @@
+package tunnel
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testEndpoints = []client.TunnelEndpoint{
+	{Pop: "us-east", Ip: "203.0.113.10", InnerIp: "169.254.10.1", PeerInnerIp: "169.254.10.2", InnerPrefixLength: 30},
+	{Pop: "eu-west", Ip: "2001:db8:ffff::10", InnerIp: "169.254.10.5", PeerInnerIp: "169.254.10.6", InnerPrefixLength: 30},
+}
+
+func TestParseProposal(t *testing.T) {
+	cases := map[string]struct {
+		proposal string
+		err      string
+	}{
+		"valid":               {proposal: "aes128-sha384-ecp256"},
+		"missing DH group":    {proposal: "aes256-sha256", err: "\"aes256-sha256\" is not a proposal of the form <encryption>-<integrity>-<DH group>"},
+		"unknown encryption":  {proposal: "3des-sha256-modp2048", err: "\"3des-sha256-modp2048\" has an unsupported encryption algorithm, supported: aes128, aes256"},
+		"unknown integrity":   {proposal: "aes256-md5-modp2048", err: "\"aes256-md5-modp2048\" has an unsupported integrity algorithm, supported: sha256, sha384"},
+		"unknown DH group":    {proposal: "aes256-sha256-modp1024", err: "\"aes256-sha256-modp1024\" has an unsupported DH group, supported: ecp256, ecp384, modp2048"},
+		"upper case keywords": {proposal: "AES256-SHA256-MODP2048", err: "\"AES256-SHA256-MODP2048\" has an unsupported encryption algorithm, supported: aes128, aes256"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseProposal(tc.proposal)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestRouterTunnels(t *testing.T) {
+	tunnels := routerTunnels([]string{"198.51.100.20", "2001:db8::20", "198.51.100.15"}, append(testEndpoints,
+		client.TunnelEndpoint{Pop: "invalid", Ip: "203.0.113.20"}))
+	assert.Len(t, tunnels, 2)
+	assert.Equal(t, 1, tunnels[0].unit)
+	assert.Equal(t, "198.51.100.15", tunnels[0].sourceIp.String())
+	assert.Equal(t, "169.254.10.2/30", tunnels[0].innerCIDR())
+	assert.Equal(t, "255.255.255.252", tunnels[0].innerMask())
+	assert.Equal(t, 2, tunnels[1].unit)
+	assert.Equal(t, "2001:db8::20", tunnels[1].sourceIp.String())
+
+	assert.Len(t, routerTunnels([]string{"198.51.100.15"}, testEndpoints[1:]), 0)
+}
+
+func TestRouterConfigsGre(t *testing.T) {
+	configs, err := routerConfigs(&client.Tunnel{
+		Name:      "hq",
+		GreConfig: &client.GreTunnelConfig{SourceIps: []string{"198.51.100.15"}},
+		Endpoints: testEndpoints,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `interface Tunnel1
+ description pfptmeta hq us-east
+ ip address 169.254.10.2 255.255.255.252
+ tunnel source 198.51.100.15
+ tunnel destination 203.0.113.10
+!
+`, configs["cisco_ios"])
+	assert.Equal(t, `set interfaces gr-0/0/0 unit 1 description "pfptmeta hq us-east"
+set interfaces gr-0/0/0 unit 1 tunnel source 198.51.100.15
+set interfaces gr-0/0/0 unit 1 tunnel destination 203.0.113.10
+set interfaces gr-0/0/0 unit 1 family inet address 169.254.10.2/30
+`, configs["junos"])
+	assert.Equal(t, `set network interface tunnel units tunnel.1 ip 169.254.10.2/30
+set network tunnel gre pfptmeta-1 tunnel-interface tunnel.1
+set network tunnel gre pfptmeta-1 local-address interface ethernet1/1
+set network tunnel gre pfptmeta-1 peer-address ip 203.0.113.10
+`, configs["pan_os"])
+	assert.Empty(t, configs["strongswan"])
+
+	configs, err = routerConfigs(&client.Tunnel{Name: "hq", GreConfig: &client.GreTunnelConfig{SourceIps: []string{"198.51.100.15"}}})
+	assert.NoError(t, err)
+	assert.Nil(t, configs)
+	configs, err = routerConfigs(&client.Tunnel{Name: "hq", Endpoints: testEndpoints})
+	assert.NoError(t, err)
+	assert.Nil(t, configs)
+}
+
+func TestRouterConfigsIpsec(t *testing.T) {
+	configs, err := routerConfigs(&client.Tunnel{
+		Name: "hq",
+		IpsecConfig: &client.IpsecTunnelConfig{
+			SourceIps:    []string{"198.51.100.15"},
+			IkeVersion:   2,
+			Psk:          "secret",
+			IkeProposals: []string{"aes256-sha384-ecp384", "aes128-sha256-modp2048"},
+			EspProposals: []string{"aes256-sha256-ecp384"},
+		},
+		Endpoints: testEndpoints[:1],
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `crypto ikev2 proposal pfptmeta-1
+ encryption aes-cbc-256
+ integrity sha384
+ group 20
+!
+crypto ikev2 proposal pfptmeta-2
+ encryption aes-cbc-128
+ integrity sha256
+ group 14
+!
+crypto ikev2 policy pfptmeta
+ proposal pfptmeta-1
+ proposal pfptmeta-2
+!
+crypto ikev2 keyring pfptmeta
+ peer pfptmeta-1
+  address 203.0.113.10
+  pre-shared-key secret
+ !
+!
+crypto ikev2 profile pfptmeta
+ match identity remote address 203.0.113.10 255.255.255.255
+ authentication remote pre-share
+ authentication local pre-share
+ keyring local pfptmeta
+!
+crypto ipsec transform-set pfptmeta-1 esp-aes 256 esp-sha256-hmac
+ mode tunnel
+!
+crypto ipsec profile pfptmeta
+ set transform-set pfptmeta-1
+ set pfs group20
+ set ikev2-profile pfptmeta
+!
+interface Tunnel1
+ description pfptmeta hq us-east
+ ip address 169.254.10.2 255.255.255.252
+ tunnel source 198.51.100.15
+ tunnel mode ipsec ipv4
+ tunnel destination 203.0.113.10
+ tunnel protection ipsec profile pfptmeta
+!
+`, configs["cisco_ios"])
+	assert.Contains(t, configs["junos"], "set security ike gateway pfptmeta-1 version v2-only\n")
+	assert.Contains(t, configs["junos"], "set security ike policy pfptmeta proposals [ pfptmeta-1 pfptmeta-2 ]\n")
+	assert.Contains(t, configs["pan_os"], "set network ike crypto-profiles ike-crypto-profiles pfptmeta hash [ sha384 sha256 ]\n")
+	assert.Contains(t, configs["pan_os"], "set network ike gateway pfptmeta-1 protocol version ikev2\n")
+	assert.Equal(t, `connections {
+  # us-east: ip link add xfrm1 type xfrm if_id 1 && ip addr add 169.254.10.2/30 dev xfrm1
+  pfptmeta-1 {
+    version = 2
+    local_addrs = 198.51.100.15
+    remote_addrs = 203.0.113.10
+    proposals = aes256-sha384-ecp384,aes128-sha256-modp2048
+    if_id_in = 1
+    if_id_out = 1
+    local {
+      auth = psk
+    }
+    remote {
+      auth = psk
+    }
+    children {
+      pfptmeta-1 {
+        local_ts = 0.0.0.0/0
+        remote_ts = 0.0.0.0/0
+        esp_proposals = aes256-sha256-ecp384
+        start_action = start
+      }
+    }
+  }
+}
+secrets {
+  ike-pfptmeta-1 {
+    id = 203.0.113.10
+    secret = "secret"
+  }
+}
+`, configs["strongswan"])
+}
+
+func TestRouterConfigsIkeV1(t *testing.T) {
+	configs, err := routerConfigs(&client.Tunnel{
+		Name:        "hq",
+		IpsecConfig: &client.IpsecTunnelConfig{SourceIps: []string{"198.51.100.15"}, IkeVersion: 1, Psk: "secret"},
+		Endpoints:   testEndpoints[:1],
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, configs["cisco_ios"], `crypto isakmp policy 10
+ encryption aes 256
+ hash sha256
+ authentication pre-share
+ group 14
+!
+crypto isakmp key secret address 203.0.113.10
+`)
+	assert.NotContains(t, configs["cisco_ios"], "ikev2")
+	assert.Contains(t, configs["junos"], "set security ike gateway pfptmeta-1 version v1-only\n")
+	assert.Contains(t, configs["pan_os"], "set network ike gateway pfptmeta-1 protocol ikev1 ike-crypto-profile pfptmeta\n")
+	assert.Contains(t, configs["strongswan"], "    version = 1\n")
+}
+
+func TestRouterConfigsInvalidProposal(t *testing.T) {
+	configs, err := routerConfigs(&client.Tunnel{
+		Name: "hq",
+		IpsecConfig: &client.IpsecTunnelConfig{
+			SourceIps:    []string{"198.51.100.15"},
+			IkeVersion:   2,
+			Psk:          "secret",
+			EspProposals: []string{"aes256-gcm16"},
+		},
+		Endpoints: testEndpoints[:1],
+	})
+	assert.EqualError(t, err, "invalid esp_proposals: \"aes256-gcm16\" is not a proposal of the form <encryption>-<integrity>-<DH group>")
+	assert.Nil(t, configs)
+
+	d := DataSource().TestResourceData()
+	diags := tunnelToResource(d, &client.Tunnel{
+		ID:          "tun-123",
+		Name:        "hq",
+		IpsecConfig: &client.IpsecTunnelConfig{SourceIps: []string{"198.51.100.15"}, IkeProposals: []string{"des-md5"}},
+		Endpoints:   testEndpoints[:1],
+	})
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Contains(t, diags[0].Detail, "invalid ike_proposals")
+	}
+	assert.Empty(t, d.Get("router_config"))
+}
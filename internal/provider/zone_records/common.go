@@ -0,0 +1,92 @@
+package zone_records
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"strings"
+)
+
+const (
+	description = "Parses the records of an RFC 1035 zone file, or of content in the `/etc/hosts` format, " +
+		"to the mapped hosts and mapped domains which resolve the same names within mapped subnets.\n\n" +
+		"A, AAAA and CNAME records are converted to mapped hosts, DNAME records, which map a whole subtree of names, " +
+		"are converted to mapped domains. The data source doesn't call the API."
+	contentDesc = "Content of the zone file or hosts file, e.g. read with the `file` function."
+	formatDesc  = "ENUM: [zone, hosts], defaults to zone."
+	originDesc  = "Domain names of the zone file are relative to this origin, until the zone file sets its own `$ORIGIN`. " +
+		"Unused for hosts files, whose names are used as is."
+	recordTypesDesc   = "Types of the records to return, ENUM: [A, AAAA, CNAME, DNAME], defaults to all of them. Hosts files only have A and AAAA records."
+	suffixDesc        = "Only return records of this domain and its subdomains."
+	recordsDesc       = "The records, in the order of the content."
+	recordNameDesc    = "Absolute domain name of the record, lower case and without the trailing dot."
+	recordTypeDesc    = "Type of the record."
+	recordTargetDesc  = "IP address of A and AAAA records, absolute domain name of CNAME and DNAME records."
+	mappedHostsDesc   = "A, AAAA and CNAME records as mapped hosts. Names with several records are mapped to the first one, wildcard names are skipped."
+	mappedDomainsDesc = "DNAME records as mapped domains. Names with several records are mapped to the first one, wildcard names are skipped."
+)
+
+var recordTypes = []string{"A", "AAAA", "CNAME", "DNAME"}
+
+func zoneRecordsRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	content := d.Get("content").(string)
+	format := d.Get("format").(string)
+	origin := d.Get("origin").(string)
+	var records []record
+	var err error
+	if format == "hosts" {
+		records, err = parseHosts(content)
+	} else {
+		records, err = parseZone(content, origin)
+	}
+	if err != nil {
+		return diag.Errorf("could not parse %s content: %v", format, err)
+	}
+	types := recordTypes
+	if configured := client.ConfigToStringSlice("record_types", d); len(configured) > 0 {
+		types = configured
+	}
+	records = filterRecords(records, types, d.Get("suffix").(string))
+
+	hash := sha256.Sum256([]byte(format + "\n" + origin + "\n" + content))
+	d.SetId(hex.EncodeToString(hash[:]))
+	return recordsToResource(d, records)
+}
+
+func recordsToResource(d *schema.ResourceData, records []record) diag.Diagnostics {
+	recordsList := make([]map[string]interface{}, len(records))
+	var mappedHosts, mappedDomains []map[string]interface{}
+	mapped := make(map[string]bool)
+	for i, r := range records {
+		recordsList[i] = map[string]interface{}{"name": r.Name, "type": r.Type, "target": r.Target}
+		key := r.Name
+		if r.Type == "DNAME" {
+			key = "DNAME " + r.Name
+		}
+		if mapped[key] || strings.Contains(r.Name, "*") {
+			continue
+		}
+		mapped[key] = true
+		if r.Type == "DNAME" {
+			mappedDomains = append(mappedDomains, map[string]interface{}{"name": r.Name, "mapped_domain": r.Target})
+		} else {
+			mappedHosts = append(mappedHosts, map[string]interface{}{"name": r.Name, "mapped_host": r.Target})
+		}
+	}
+	err := d.Set("records", recordsList)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("mapped_hosts", mappedHosts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("mapped_domains", mappedDomains)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package zone_records
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/provider/common"
+)
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: description,
+
+		ReadContext: zoneRecordsRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content": {
+				Description: contentDesc,
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"format": {
+				Description:      formatDesc,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "zone",
+				ValidateDiagFunc: common.ValidateStringENUM("zone", "hosts"),
+			},
+			"origin": {
+				Description: originDesc,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"record_types": {
+				Description: recordTypesDesc,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: common.ValidateStringENUM(recordTypes...),
+				},
+			},
+			"suffix": {
+				Description:      suffixDesc,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: common.ValidateHostName(),
+			},
+			"records": {
+				Description: recordsDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: recordNameDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: recordTypeDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"target": {
+							Description: recordTargetDesc,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"mapped_hosts": {
+				Description: mappedHostsDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mapped_host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"mapped_domains": {
+				Description: mappedDomainsDesc,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mapped_domain": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,244 @@
+package zone_records
+
+import (
+	"fmt"
+	"github.com/nsofnetworks/terraform-provider-pfptmeta/internal/client"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// record is a resource record which maps a name to a target: an IP address for A and AAAA records,
+// or a domain name for CNAME and DNAME records. Names are absolute, lower case and without the trailing dot.
+type record struct {
+	Name   string
+	Type   string
+	Target string
+}
+
+var (
+	ttlPattern   = regexp.MustCompile(`^([0-9]+[smhdwSMHDW]?)+$`)
+	classPattern = regexp.MustCompile(`^(?i)(IN|CH|HS|CS|CLASS[0-9]+)$`)
+)
+
+// zoneEntry is a logical line of a zone file, which may span several lines in parentheses.
+type zoneEntry struct {
+	line int
+	// sameOwner is set for entries which start with a blank, and so have the owner of the previous entry.
+	sameOwner bool
+	tokens    []string
+}
+
+// zoneEntries splits the content of a zone file to entries, dropping comments and empty lines.
+func zoneEntries(content string) ([]zoneEntry, error) {
+	var entries []zoneEntry
+	var cur *zoneEntry
+	var tok strings.Builder
+	inTok, quoted, escaped, comment := false, false, false, false
+	depth, line := 0, 1
+	flush := func() {
+		if inTok {
+			cur.tokens = append(cur.tokens, tok.String())
+			tok.Reset()
+			inTok = false
+		}
+	}
+	for _, r := range content + "\n" {
+		if comment && r != '\n' {
+			continue
+		}
+		comment = false
+		if cur == nil {
+			cur = &zoneEntry{line: line, sameOwner: r == ' ' || r == '\t'}
+		}
+		switch {
+		case escaped:
+			tok.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			tok.WriteRune(r)
+			inTok, escaped = true, true
+		case quoted && r != '"':
+			if r == '\n' {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+			}
+			tok.WriteRune(r)
+		case r == '"':
+			quoted, inTok = !quoted, true
+		case r == ';':
+			flush()
+			comment = true
+		case r == '(':
+			flush()
+			depth++
+		case r == ')':
+			flush()
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses", line)
+			}
+		case r == '\n':
+			flush()
+			line++
+			if depth == 0 {
+				if len(cur.tokens) > 0 {
+					entries = append(entries, *cur)
+				}
+				cur = nil
+			}
+		case r == ' ' || r == '\t' || r == '\r':
+			flush()
+		default:
+			tok.WriteRune(r)
+			inTok = true
+		}
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", cur.line)
+	}
+	return entries, nil
+}
+
+// absoluteName returns the absolute form of a domain name of a zone file, relative to origin unless it ends with a dot.
+func absoluteName(name, origin string) (string, error) {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		name = origin
+	case strings.HasSuffix(name, "."):
+		name = strings.TrimSuffix(name, ".")
+	case origin == "":
+		return "", fmt.Errorf("%s is relative, but there's no $ORIGIN or origin", name)
+	default:
+		name = name + "." + origin
+	}
+	if name == "" {
+		return "", fmt.Errorf("the root domain can't be mapped")
+	}
+	return name, nil
+}
+
+// parseZone parses the A, AAAA, CNAME and DNAME records of an RFC 1035 zone file, names are relative to origin
+// until the zone file sets its own $ORIGIN. Other records are skipped, $INCLUDE directives aren't supported.
+func parseZone(content, origin string) ([]record, error) {
+	entries, err := zoneEntries(content)
+	if err != nil {
+		return nil, err
+	}
+	origin = strings.ToLower(strings.TrimSuffix(origin, "."))
+	var records []record
+	var owner string
+	for _, e := range entries {
+		tokens := e.tokens
+		if !e.sameOwner && strings.HasPrefix(tokens[0], "$") {
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN expects a single domain name", e.line)
+				}
+				origin, err = absoluteName(tokens[1], origin)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %v", e.line, err)
+				}
+			case "$TTL":
+			default:
+				return nil, fmt.Errorf("line %d: %s directive is not supported", e.line, tokens[0])
+			}
+			continue
+		}
+		if e.sameOwner {
+			if owner == "" {
+				return nil, fmt.Errorf("line %d: record without an owner name", e.line)
+			}
+		} else {
+			owner, err = absoluteName(tokens[0], origin)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", e.line, err)
+			}
+			tokens = tokens[1:]
+		}
+		for len(tokens) > 0 && (ttlPattern.MatchString(tokens[0]) || classPattern.MatchString(tokens[0])) {
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: record without a type", e.line)
+		}
+		recordType, rdata := strings.ToUpper(tokens[0]), tokens[1:]
+		if !client.Contains(recordType, recordTypes) {
+			continue
+		}
+		if len(rdata) != 1 {
+			return nil, fmt.Errorf("line %d: %s record expects a single value, got %d", e.line, recordType, len(rdata))
+		}
+		var target string
+		switch recordType {
+		case "A", "AAAA":
+			ip := net.ParseIP(rdata[0])
+			if ip == nil || strings.Contains(rdata[0], ":") != (recordType == "AAAA") {
+				return nil, fmt.Errorf("line %d: %s is not a valid %s record address", e.line, rdata[0], recordType)
+			}
+			target = ip.String()
+		default:
+			target, err = absoluteName(rdata[0], origin)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", e.line, err)
+			}
+		}
+		records = append(records, record{Name: owner, Type: recordType, Target: target})
+	}
+	return records, nil
+}
+
+// parseHosts parses content in the /etc/hosts format, every name of a line is an A or AAAA record of its address.
+// Loopback, unspecified and multicast addresses, e.g. of localhost, are skipped.
+func parseHosts(content string) ([]record, error) {
+	var records []record
+	for i, line := range strings.Split(content, "\n") {
+		if comment := strings.Index(line, "#"); comment >= 0 {
+			line = line[:comment]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		address := fields[0]
+		if zone := strings.Index(address, "%"); zone >= 0 {
+			address = address[:zone]
+		}
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return nil, fmt.Errorf("line %d: %s is not an IP address", i+1, fields[0])
+		}
+		if ip.IsLoopback() || ip.IsUnspecified() || ip.IsMulticast() {
+			continue
+		}
+		recordType := "AAAA"
+		if ip.To4() != nil {
+			recordType = "A"
+		}
+		for _, name := range fields[1:] {
+			records = append(records, record{
+				Name:   strings.ToLower(strings.TrimSuffix(name, ".")),
+				Type:   recordType,
+				Target: ip.String(),
+			})
+		}
+	}
+	return records, nil
+}
+
+// filterRecords returns the records of the given types whose names are suffix or one of its subdomains.
+func filterRecords(records []record, types []string, suffix string) []record {
+	suffix = strings.ToLower(strings.Trim(suffix, "."))
+	res := make([]record, 0, len(records))
+	for _, r := range records {
+		if !client.Contains(r.Type, types) {
+			continue
+		}
+		if suffix != "" && r.Name != suffix && !strings.HasSuffix(r.Name, "."+suffix) {
+			continue
+		}
+		res = append(res, r)
+	}
+	return res
+}
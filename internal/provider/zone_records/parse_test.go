@@ -0,0 +1,97 @@
+package zone_records
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const zoneFile = `$ORIGIN example.com.
+$TTL 3600
+@       IN  SOA ns1 hostmaster ( 2023010101 ; serial
+                7200 3600 1209600 3600 )
+        IN  NS  ns1
+        IN  MX  10 mail
+ns1     IN  A   10.0.0.1
+DB      300 IN A 10.0.0.2 ; primary
+        IN  AAAA 2001:0DB8::0002
+www     CNAME web.internal.
+web     IN  CNAME db
+*.apps  IN  A 10.0.0.3
+txt     IN  TXT "v=spf1 ; include"
+$ORIGIN corp.example.com.
+legacy  IN  DNAME legacy.internal.
+`
+
+func TestParseZone(t *testing.T) {
+	records, err := parseZone(zoneFile, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []record{
+		{Name: "ns1.example.com", Type: "A", Target: "10.0.0.1"},
+		{Name: "db.example.com", Type: "A", Target: "10.0.0.2"},
+		{Name: "db.example.com", Type: "AAAA", Target: "2001:db8::2"},
+		{Name: "www.example.com", Type: "CNAME", Target: "web.internal"},
+		{Name: "web.example.com", Type: "CNAME", Target: "db.example.com"},
+		{Name: "*.apps.example.com", Type: "A", Target: "10.0.0.3"},
+		{Name: "legacy.corp.example.com", Type: "DNAME", Target: "legacy.internal"},
+	}, records)
+}
+
+func TestParseZoneOrigin(t *testing.T) {
+	records, err := parseZone("db A 10.0.0.2\n@ CNAME lb.internal.", "Example.com.")
+	assert.NoError(t, err)
+	assert.Equal(t, []record{
+		{Name: "db.example.com", Type: "A", Target: "10.0.0.2"},
+		{Name: "example.com", Type: "CNAME", Target: "lb.internal"},
+	}, records)
+}
+
+func TestParseZoneErrors(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		err     string
+	}{
+		"relative without origin": {"db A 10.0.0.1", "line 1: db is relative, but there's no $ORIGIN or origin"},
+		"unbalanced parentheses":  {"$ORIGIN example.com.\n@ SOA ns1 hostmaster ( 1 2 3\n", "line 2: unbalanced parentheses"},
+		"unterminated quote":      {"$ORIGIN example.com.\ntxt TXT \"abc\n", "line 2: unterminated quoted string"},
+		"include":                 {"$INCLUDE other.zone", "line 1: $INCLUDE directive is not supported"},
+		"no owner":                {"  A 10.0.0.1", "line 1: record without an owner name"},
+		"ipv6 in A record":        {"db.example.com. A 2001:db8::1", "line 1: 2001:db8::1 is not a valid A record address"},
+		"ipv4 in AAAA record":     {"db.example.com. AAAA 10.0.0.1", "line 1: 10.0.0.1 is not a valid AAAA record address"},
+		"several values":          {"db.example.com. A 10.0.0.1 10.0.0.2", "line 1: A record expects a single value, got 2"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseZone(tc.content, "")
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestParseHosts(t *testing.T) {
+	records, err := parseHosts(`127.0.0.1   localhost
+::1         localhost ip6-localhost
+# internal hosts
+10.0.0.2    DB.example.com db  # primary
+fe80::1%eth0 router.example.com
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, []record{
+		{Name: "db.example.com", Type: "A", Target: "10.0.0.2"},
+		{Name: "db", Type: "A", Target: "10.0.0.2"},
+		{Name: "router.example.com", Type: "AAAA", Target: "fe80::1"},
+	}, records)
+
+	_, err = parseHosts("db.example.com 10.0.0.2")
+	assert.EqualError(t, err, "line 1: db.example.com is not an IP address")
+}
+
+func TestFilterRecords(t *testing.T) {
+	records := []record{
+		{Name: "example.com", Type: "A", Target: "10.0.0.1"},
+		{Name: "db.example.com", Type: "AAAA", Target: "2001:db8::2"},
+		{Name: "www.example.com", Type: "CNAME", Target: "web.internal"},
+		{Name: "notexample.com", Type: "A", Target: "10.0.0.4"},
+	}
+	assert.Equal(t, records[:3], filterRecords(records, recordTypes, ".Example.com"))
+	assert.Equal(t, []record{records[0], records[3]}, filterRecords(records, []string{"A"}, ""))
+}